@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/importer"
+	"github.com/umtdemr/wb-backend/internal/jsonHelper"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"mime"
+	"net/http"
+)
+
+// exportBoardHandler handles downloading the whole board as a board file, see data.BoardFile.
+// All the members of the board can export it.
+func (app *application) exportBoardHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readMemberBoard(w, r)
+	if !ok {
+		return
+	}
+
+	file, err := app.models.Boards.Export(board)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	// the file is written as it is, so that it can be imported back without any change
+	js, err := json.Marshal(file)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": board.Name + ".wb.json"}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(js, '\n'))
+}
+
+// importBoardHandler handles creating a new board of the user from a board file, or from a file of another
+// whiteboard app that the importer package supports. The parts of the other files that cannot be imported are
+// reported as warnings.
+func (app *application) importBoardHandler(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+
+	err := app.readJSONWithLimit(w, r, &body, data.MaxBoardFileSize)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	file := &data.BoardFile{}
+	warnings := []string{}
+
+	switch importer.DetectType(body) {
+	case data.BoardFileType:
+		err = jsonHelper.ReadJson(bytes.NewReader(body), file)
+		if err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+	case importer.ExcalidrawFileType:
+		result, err := importer.Excalidraw(body, data.DefaultBoardName)
+		if err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+		file = result.File
+		warnings = result.Warnings
+	default:
+		v.AddError("type", fmt.Sprintf("must be one of %s or %s", data.BoardFileType, importer.ExcalidrawFileType))
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	if data.ValidateBoardFile(v, file); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	slugId, err := data.GenerateSlugId()
+	if err != nil {
+		log.Error().Err(err).Msg("error while generating slug id")
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	board, err := app.models.Boards.Import(int64(user.ID), slugId, file)
+	if err != nil {
+		log.Error().Err(err).Msg("error while importing board")
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	app.enqueueThumbnail(r, board.SlugId)
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"board": board, "warnings": warnings}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
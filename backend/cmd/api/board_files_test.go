@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	mockworker "github.com/umtdemr/wb-backend/internal/worker/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBoardFileHandlers tests exporting the boards as board files and importing them back
+func TestBoardFileHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	publisher := mockworker.NewMockPublisher(ctrl)
+
+	app.models = data.Models{
+		Boards: boardModel,
+	}
+	app.jobPublisher = publisher
+
+	viewerBoard := &data.BoardResult{Id: 3, OwnerId: 5, Name: "Roadmap", SlugId: "valid-12-ch-", Role: data.BoardRoleViewer}
+	boardFile := &data.BoardFile{
+		Type:       data.BoardFileType,
+		Version:    data.BoardFileVersion,
+		ExportedAt: time.Date(2025, 1, 25, 10, 0, 0, 0, time.UTC),
+		Board:      data.BoardFileBoard{Name: "Roadmap"},
+		Pages: []data.BoardFilePage{
+			{
+				Name: "Page 1",
+				Elements: []data.BoardFileElement{
+					{Type: data.ElementTypeRectangle, Width: 120, Height: 80, StrokeColor: data.ColorBlack, StrokeWidth: 2, FontSize: 14},
+				},
+			},
+		},
+	}
+	encodedFile, err := json.Marshal(boardFile)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "Export board of non member",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/export",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Viewer exports board",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/export",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+				require.Equal(t, "attachment; filename=Roadmap.wb.json", recorder.Header().Get("Content-Disposition"))
+
+				// the file is not wrapped, so it can be imported as it is
+				var file data.BoardFile
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &file))
+				require.Equal(t, *boardFile, file)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				boardModel.EXPECT().
+					Export(gomock.Eq(viewerBoard)).
+					Return(boardFile, nil)
+			},
+		},
+		{
+			name:   "Import unknown fields",
+			method: http.MethodPost,
+			url:    "/v1/boards/import",
+			body:   `{"type": "wb.board", "owner": 5}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "Import invalid file",
+			method: http.MethodPost,
+			url:    "/v1/boards/import",
+			body: `{"type": "wb.board", "version": 2, "board": {"name": "Roadmap"},
+				"pages": [{"name": "Page 1", "elements": [{"type": "star", "font_size": 14}]}]}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"version"`)
+				require.Contains(t, recorder.Body.String(), `"pages[0].elements[0].type"`)
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "Import board",
+			method: http.MethodPost,
+			url:    "/v1/boards/import",
+			body:   string(encodedFile),
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"slug_id":"new-12-chars"`)
+				require.Contains(t, recorder.Body.String(), `"warnings":[]`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					Import(int64(1), gomock.Any(), gomock.Eq(boardFile)).
+					DoAndReturn(func(ownerId int64, slugId string, file *data.BoardFile) (*data.Board, error) {
+						require.Len(t, slugId, 12)
+						return &data.Board{Id: 9, OwnerId: ownerId, Name: file.Board.Name, SlugId: "new-12-chars"}, nil
+					})
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), worker.Job{
+						Type: worker.JobTypeThumbnail,
+						Data: worker.ThumbnailJob{BoardSlugId: "new-12-chars"},
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Import unknown file type",
+			method: http.MethodPost,
+			url:    "/v1/boards/import",
+			body:   `{"type": "miro", "elements": []}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"type"`)
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "Import excalidraw file",
+			method: http.MethodPost,
+			url:    "/v1/boards/import",
+			body: `{"type": "excalidraw", "version": 2, "source": "https://excalidraw.com", "appState": {}, "files": {},
+				"elements": [
+					{"id": "r1", "type": "rectangle", "x": 0, "y": 0, "width": 100, "height": 50, "strokeColor": "#1e1e1e",
+					 "backgroundColor": "transparent", "strokeWidth": 2, "opacity": 100, "roundness": null},
+					{"id": "a1", "type": "arrow", "x": 0, "y": 0, "width": 100, "height": 0, "points": [[0, 0], [100, 0]]}
+				]}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"warnings":["arrow elements are not supported and skipped"]`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					Import(int64(1), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ownerId int64, slugId string, file *data.BoardFile) (*data.Board, error) {
+						require.Equal(t, data.DefaultBoardName, file.Board.Name)
+						require.Len(t, file.Pages[0].Elements, 1)
+						require.Equal(t, data.ElementTypeRectangle, file.Pages[0].Elements[0].Type)
+						return &data.Board{Id: 9, OwnerId: ownerId, Name: file.Board.Name, SlugId: slugId}, nil
+					})
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId", app.requireActivatedUser(app.boardCollectionActionHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/export", app.requireActivatedUser(app.exportBoardHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// TestImportBoardHandlerSizeLimit tests that the board files can be larger than the other request bodies
+func TestImportBoardHandlerSizeLimit(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	app.models = data.Models{Boards: boardModel}
+
+	// a 2 MB text fails the validation, but it is read
+	body := `{"type": "wb.board", "version": 1, "board": {"name": "Roadmap"},
+		"pages": [{"name": "Page 1", "elements": [{"type": "text_box", "font_size": 14, "text": "` + strings.Repeat("a", 2<<20) + `"}]}]}`
+
+	recorder := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodPost, "/v1/boards/import", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req = addUserToContext(t, app, req)
+
+	router := httprouter.New()
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId", app.requireActivatedUser(app.boardCollectionActionHandler))
+	router.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"pages[0].elements[0].text"`)
+}
@@ -6,11 +6,36 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/umtdemr/wb-backend/internal/data"
 	"github.com/umtdemr/wb-backend/internal/validator"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	"github.com/umtdemr/wb-backend/internal/ws"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
-// createBoardHandler handles create board POST requests
+// createBoardHandler handles create board POST requests. The request body is optional, the board is created
+// from a template when a template id is given.
 func (app *application) createBoardHandler(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TemplateId int64 `json:"template_id"`
+	}
+
+	if r.ContentLength != 0 {
+		err := app.readJSON(w, r, &body)
+		if err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+	}
+
+	v := validator.New()
+	v.Check(body.TemplateId >= 0, "template_id", "must be a positive integer")
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
 	slugId, err := data.GenerateSlugId()
 	if err != nil {
 		log.Error().Err(err).Msg("error while generating slug id")
@@ -20,18 +45,34 @@ func (app *application) createBoardHandler(w http.ResponseWriter, r *http.Reques
 
 	user := app.contextGetUser(r)
 	input := &data.Board{
-		Name:    data.DefaultBoardName,
-		SlugId:  slugId,
-		OwnerId: int64(user.ID),
+		Name:       data.DefaultBoardName,
+		SlugId:     slugId,
+		OwnerId:    int64(user.ID),
+		TemplateId: body.TemplateId,
+	}
+
+	// boards created from a template are named after the template
+	if input.TemplateId != 0 {
+		input.Name = ""
 	}
 
 	board, err := app.models.Boards.CreateBoard(input)
 	if err != nil {
-		log.Error().Err(err).Msg("error while creating board")
-		app.serverErrorResponse(w, r, err)
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("template_id", "template not found")
+			app.fieldValidationResponse(w, r, v.Errors)
+		default:
+			log.Error().Err(err).Msg("error while creating board")
+			app.serverErrorResponse(w, r, err)
+		}
 		return
 	}
 
+	if input.TemplateId != 0 {
+		app.enqueueThumbnail(r, board.SlugId)
+	}
+
 	err = app.writeJSON(w, http.StatusCreated, envelope{"board": board}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
@@ -39,17 +80,53 @@ func (app *application) createBoardHandler(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// getAllBoardsHandler handles getting all boards for the user
+// enqueueThumbnail enqueues a job to render the thumbnail of a board that is created with elements in it.
+// The thumbnails of the boards edited over the websocket connections are scheduled by the hub instead.
+func (app *application) enqueueThumbnail(r *http.Request, boardSlugId string) {
+	err := app.jobPublisher.EnqueueJob(r.Context(), worker.Job{
+		Type: worker.JobTypeThumbnail,
+		Data: worker.ThumbnailJob{BoardSlugId: boardSlugId},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to enqueue thumbnail job")
+	}
+}
+
+// getAllBoardsHandler handles listing the boards of the user. The list is paginated with a cursor and
+// can be searched by name, filtered by ownership, folder and stars, and sorted.
 func (app *application) getAllBoardsHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	qs := r.URL.Query()
+
+	filters := data.BoardFilters{
+		Search:    strings.TrimSpace(app.readString(qs, "search", "")),
+		Ownership: app.readString(qs, "filter", data.BoardOwnershipAll),
+		Sort:      app.readString(qs, "sort", "-created_at"),
+		PageSize:  app.readInt(qs, "page_size", data.DefaultBoardPageSize, v),
+		Cursor:    app.readString(qs, "cursor", ""),
+		FolderId:  int64(app.readInt(qs, "folder", 0, v)),
+		Starred:   app.readBool(qs, "starred", false, v),
+	}
+
+	if data.ValidateBoardFilters(v, filters); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
 	user := app.contextGetUser(r)
-	results, err := app.models.Boards.GetAllBoards(int64(user.ID))
+	results, metadata, err := app.models.Boards.GetAllBoards(int64(user.ID), filters)
 
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		switch {
+		case errors.Is(err, data.ErrInvalidCursor):
+			app.fieldValidationResponse(w, r, map[string]string{"cursor": "must be a valid cursor"})
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
 		return
 	}
 
-	err = app.writeJSON(w, http.StatusOK, envelope{"board_results": results}, nil)
+	err = app.writeJSON(w, http.StatusOK, envelope{"board_results": results, "metadata": metadata}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
 		return
@@ -69,8 +146,20 @@ func (app *application) getBoardBySlugIdHandler(w http.ResponseWriter, r *http.R
 	}
 
 	user := app.contextGetUser(r)
+	shareToken := app.readShareToken(r)
+
+	// members access the board with their own role even if they open a share link
+	var board *data.Board
+	err := data.ErrRecordNotFound
+	if !user.IsAnonymous() {
+		board, err = app.models.Boards.RetrieveBoard(int64(user.ID), slugId)
+	}
+
+	isShared := shareToken != "" && errors.Is(err, data.ErrRecordNotFound)
+	if isShared {
+		board, err = app.models.Boards.RetrieveSharedBoard(slugId, shareToken)
+	}
 
-	board, err := app.models.Boards.RetrieveBoard(int64(user.ID), slugId)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -81,10 +170,14 @@ func (app *application) getBoardBySlugIdHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	users, err := app.models.Boards.GetBoardUsers(board.Id)
-	if err != nil {
-		app.serverErrorResponse(w, r, err)
-		return
+	// members of the board are not listed to the people with a share link
+	users := []data.BoardUser{}
+	if !isShared {
+		users, err = app.models.Boards.GetBoardUsers(board.Id)
+		if err != nil {
+			app.serverErrorResponse(w, r, err)
+			return
+		}
 	}
 
 	err = app.writeJSON(
@@ -105,40 +198,96 @@ func (app *application) getBoardBySlugIdHandler(w http.ResponseWriter, r *http.R
 	}
 }
 
+// boardCollectionGetHandler dispatches the GET requests to /v1/boards/:slugId, see boardCollectionActionHandler.
+// Boards can be retrieved with a share token, the other views need an activated user.
+func (app *application) boardCollectionGetHandler(w http.ResponseWriter, r *http.Request) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	switch params.ByName("slugId") {
+	case "trash":
+		app.requireActivatedUser(app.getDeletedBoardsHandler)(w, r)
+	case "recent":
+		app.requireActivatedUser(app.getRecentBoardsHandler)(w, r)
+	default:
+		app.requireActivatedUserOrShareToken(app.getBoardBySlugIdHandler)(w, r)
+	}
+}
+
+// boardCollectionActionHandler dispatches the POST requests to /v1/boards/:slugId. httprouter does not allow
+// static segments next to a wildcard, so the actions on the board collection share the route with the slug id.
+// Slug ids are always 12 characters long, so they cannot clash with the action names.
+func (app *application) boardCollectionActionHandler(w http.ResponseWriter, r *http.Request) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	switch params.ByName("slugId") {
+	case "invite":
+		app.inviteUserToBoardHandler(w, r)
+	case "import":
+		app.importBoardHandler(w, r)
+	default:
+		app.notFoundResponse(w, r)
+	}
+}
+
 // inviteUserToBoardHandler handles inviting an user to given board
 func (app *application) inviteUserToBoardHandler(w http.ResponseWriter, r *http.Request) {
 	var input struct {
 		Email   string `json:"email"`
 		BoardId int64  `json:"board_id"`
+		Role    string `json:"role"`
 	}
 
 	err := app.readJSON(w, r, &input)
 	if err != nil {
-		app.serverErrorResponse(w, r, err)
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
+	// users are invited as editors unless another role is given
+	if input.Role == "" {
+		input.Role = data.BoardRoleEditor
+	}
+
 	v := validator.New()
 	data.ValidateEmail(v, input.Email)
+	data.ValidateMemberRole(v, input.Role)
 
 	if !v.Valid() {
 		app.fieldValidationResponse(w, r, v.Errors)
 		return
 	}
 
+	// only the owner of the board manages its members
+	board, err := app.models.Boards.GetBoardById(int64(app.contextGetUser(r).ID), input.BoardId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	if !board.IsOwner {
+		app.notPermittedResponse(w, r)
+		return
+	}
+
 	// get user by email
 	user, err := app.models.User.GetByEmail(input.Email)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
-			app.notFoundResponse(w, r)
+			// people without an account are invited by email
+			app.inviteEmailToBoard(w, r, input.Email, board.Id, input.Role)
 		default:
 			app.serverErrorResponse(w, r, err)
 		}
 		return
 	}
 
-	err = app.models.Boards.InviteUser(user, input.BoardId)
+	err = app.models.Boards.InviteUser(user, board.Id, input.Role)
 	if err != nil {
 		switch {
 		case errors.Is(err, data.ErrRecordNotFound):
@@ -155,3 +304,321 @@ func (app *application) inviteUserToBoardHandler(w http.ResponseWriter, r *http.
 		return
 	}
 }
+
+// inviteEmailToBoard creates a pending invitation for an email without an account and sends the invitation email.
+// The invitation turns into a board membership when the person follows the link in the email, or when they
+// activate their account or log in with the email.
+func (app *application) inviteEmailToBoard(w http.ResponseWriter, r *http.Request, email string, boardId int64, role string) {
+	inviter := app.contextGetUser(r)
+
+	invitation, err := app.models.Invitations.New(boardId, email, role, int64(inviter.ID))
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	var emailJobData = worker.Job{
+		Type: worker.JobTypeEmail,
+		Data: worker.EmailJob{
+			To: invitation.Email,
+			TmplData: map[string]any{
+				"inviterName":     inviter.FullName,
+				"boardName":       invitation.BoardName,
+				"role":            invitation.Role,
+				"invitationToken": invitation.Token,
+				"acceptURL":       app.invitationAcceptURL(invitation.Token),
+			},
+			TmplFile: "board_invitation.tmpl",
+		},
+	}
+
+	err = app.jobPublisher.EnqueueJob(r.Context(), emailJobData)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to enqueue job")
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"invitation": invitation}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// invitationAcceptURL returns the link of the web client that accepts the invitation with the token
+func (app *application) invitationAcceptURL(invitationToken string) string {
+	return strings.TrimSuffix(app.config.ClientUrl, "/") + "/invitations/accept?token=" + url.QueryEscape(invitationToken)
+}
+
+// renameBoardHandler handles changing the name of the board. Owners and editors can rename the board.
+func (app *application) renameBoardHandler(w http.ResponseWriter, r *http.Request) {
+	slugId, err := app.readSlugIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	var input struct {
+		Name string `json:"name"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	board, err := app.models.Boards.GetBoard(int64(user.ID), slugId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	if !data.CanEditBoard(board.Role) {
+		app.notPermittedResponse(w, r)
+		return
+	}
+
+	v := validator.New()
+	data.ValidateBoard(v, &data.Board{Name: input.Name, SlugId: board.SlugId, OwnerId: board.OwnerId})
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	renamed, err := app.models.Boards.Rename(board.Id, input.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.BroadcastEvent(slugId, ws.EventBoardRenamed, envelope{"name": renamed.Name})
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"board": renamed}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// deleteBoardHandler handles moving the board to the trash. Only the owner can delete the board.
+func (app *application) deleteBoardHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.models.Boards.Delete(board.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.BroadcastEvent(board.SlugId, ws.EventBoardDeleted, envelope{})
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "board is moved to the trash"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// getDeletedBoardsHandler handles listing the boards of the user in the trash
+func (app *application) getDeletedBoardsHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	results, err := app.models.Boards.GetDeletedBoards(int64(user.ID))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"board_results": results}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// getRecentBoardsHandler handles listing the boards that the user opened lately
+func (app *application) getRecentBoardsHandler(w http.ResponseWriter, r *http.Request) {
+	v := validator.New()
+	limit := app.readInt(r.URL.Query(), "limit", data.DefaultRecentBoardsLimit, v)
+	v.Check(limit > 0 && limit <= data.MaxRecentBoardsLimit, "limit", "must be between 1 and 50")
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	results, err := app.models.Boards.GetRecentBoards(int64(user.ID), limit)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"board_results": results}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// restoreDeletedBoardHandler handles taking the board back from the trash. Only the owner can restore the board.
+func (app *application) restoreDeletedBoardHandler(w http.ResponseWriter, r *http.Request) {
+	slugId, err := app.readSlugIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	board, err := app.models.Boards.Restore(int64(user.ID), slugId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.BroadcastEvent(slugId, ws.EventBoardRestored, envelope{})
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"board": board}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// duplicateBoardHandler handles copying the board with its pages. The user becomes the owner of the copy.
+// Only the owner and the editors can copy the board, viewers cannot take its content out of it.
+func (app *application) duplicateBoardHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readEditableBoard(w, r)
+	if !ok {
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	newSlugId, err := data.GenerateSlugId()
+	if err != nil {
+		log.Error().Err(err).Msg("error while generating slug id")
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	duplicated, err := app.models.Boards.Duplicate(board.Id, &data.Board{
+		Name:    data.DuplicateBoardName(board.Name),
+		SlugId:  newSlugId,
+		OwnerId: int64(user.ID),
+	})
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	app.enqueueThumbnail(r, duplicated.SlugId)
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"board": duplicated}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// readMemberBoard reads the board from the slug id parameter for any member of the board.
+// It writes the error response and reports false if the user is not a member of the board.
+func (app *application) readMemberBoard(w http.ResponseWriter, r *http.Request) (*data.BoardResult, bool) {
+	slugId, err := app.readSlugIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	user := app.contextGetUser(r)
+
+	board, err := app.models.Boards.GetBoard(int64(user.ID), slugId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	return board, true
+}
+
+// starBoardHandler handles adding the board to the starred boards of the user
+func (app *application) starBoardHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readMemberBoard(w, r)
+	if !ok {
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	err := app.models.Boards.Star(board.Id, int64(user.ID))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "board is starred"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// unstarBoardHandler handles removing the board from the starred boards of the user
+func (app *application) unstarBoardHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readMemberBoard(w, r)
+	if !ok {
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	err := app.models.Boards.Unstar(board.Id, int64(user.ID))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "board is unstarred"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,9 +12,13 @@ import (
 	"github.com/umtdemr/wb-backend/internal/data"
 	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
 	"github.com/umtdemr/wb-backend/internal/validator"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	mockworker "github.com/umtdemr/wb-backend/internal/worker/mock"
+	"github.com/umtdemr/wb-backend/internal/ws"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 // boardInputMatcher is a valid board input checker
@@ -114,6 +119,7 @@ func TestGetAllBoardsHandler(t *testing.T) {
 
 	testCases := []struct {
 		name          string
+		query         string
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 		buildStub     func()
 	}{
@@ -124,10 +130,22 @@ func TestGetAllBoardsHandler(t *testing.T) {
 			},
 			buildStub: func() {
 				boardModel.EXPECT().
-					GetAllBoards(gomock.Any()).
-					Return(nil, errors.New("unexpected error"))
+					GetAllBoards(gomock.Any(), gomock.Any()).
+					Return(nil, data.Metadata{}, errors.New("unexpected error"))
 			},
 		},
+		{
+			name:  "Invalid filters",
+			query: "?filter=others&sort=owner&page_size=abc&cursor=invalid",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "filter")
+				require.Contains(t, recorder.Body.String(), "sort")
+				require.Contains(t, recorder.Body.String(), "page_size")
+				require.Contains(t, recorder.Body.String(), "cursor")
+			},
+			buildStub: func() {},
+		},
 		{
 			name: "Successful retrieve",
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -135,17 +153,40 @@ func TestGetAllBoardsHandler(t *testing.T) {
 			},
 			buildStub: func() {
 				boardModel.EXPECT().
-					GetAllBoards(gomock.Eq(int64(1))).
+					GetAllBoards(gomock.Eq(int64(1)), gomock.Eq(data.BoardFilters{
+						Ownership: data.BoardOwnershipAll,
+						Sort:      "-created_at",
+						PageSize:  data.DefaultBoardPageSize,
+					})).
 					Return(
 						[]*data.BoardResult{
 							{
 								Id: 1,
 							},
 						},
+						data.Metadata{PageSize: data.DefaultBoardPageSize},
 						nil,
 					)
 			},
 		},
+		{
+			name:  "Search, filter and sort",
+			query: "?search=+design+&filter=owned&sort=name&page_size=10",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"next_cursor":"next"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetAllBoards(gomock.Eq(int64(1)), gomock.Eq(data.BoardFilters{
+						Search:    "design",
+						Ownership: data.BoardOwnershipOwned,
+						Sort:      "name",
+						PageSize:  10,
+					})).
+					Return([]*data.BoardResult{{Id: 1}}, data.Metadata{PageSize: 10, NextCursor: "next"}, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -155,7 +196,7 @@ func TestGetAllBoardsHandler(t *testing.T) {
 			recorder := httptest.NewRecorder()
 			handler := app.requireActivatedUser(app.getAllBoardsHandler)
 
-			req, err := http.NewRequest(http.MethodGet, "/testing", nil)
+			req, err := http.NewRequest(http.MethodGet, "/testing"+tc.query, nil)
 			require.NoError(t, err)
 			require.NotEmpty(t, req)
 
@@ -184,6 +225,8 @@ func TestGetBoardBySlugIdHandler(t *testing.T) {
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 		buildStub     func()
 		slugId        string
+		shareToken    string
+		anonymous     bool
 	}{
 		{
 			name: "Invalid slug id length",
@@ -248,21 +291,89 @@ func TestGetBoardBySlugIdHandler(t *testing.T) {
 			},
 			slugId: "valid-12-ch-",
 		},
+		{
+			name: "Anonymous user without share token",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+			buildStub: func() {
+			},
+			slugId:    "valid-12-ch-",
+			anonymous: true,
+		},
+		{
+			name: "Invalid share token",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					RetrieveBoard(gomock.Any(), gomock.Any()).
+					Return(nil, data.ErrRecordNotFound)
+				boardModel.EXPECT().
+					RetrieveSharedBoard("valid-12-ch-", "share-token").
+					Return(nil, data.ErrRecordNotFound)
+			},
+			slugId:     "valid-12-ch-",
+			shareToken: "share-token",
+		},
+		{
+			name: "Member with share token",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"role":"editor"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					RetrieveBoard(int64(1), "valid-12-ch-").
+					Return(&data.Board{Name: "testing", Role: data.BoardRoleEditor}, nil)
+				boardModel.EXPECT().
+					GetBoardUsers(gomock.Any()).
+					Return(nil, nil)
+			},
+			slugId:     "valid-12-ch-",
+			shareToken: "share-token",
+		},
+		{
+			name: "Successful retrieve with share token",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"role":"viewer"`)
+				require.Contains(t, recorder.Body.String(), `"users":[]`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					RetrieveSharedBoard("valid-12-ch-", "share-token").
+					Return(&data.Board{Name: "testing", Role: data.BoardRoleViewer}, nil)
+			},
+			slugId:     "valid-12-ch-",
+			shareToken: "share-token",
+			anonymous:  true,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStub()
 			router := httprouter.New()
-			router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId", app.requireActivatedUser(app.getBoardBySlugIdHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId", app.requireActivatedUserOrShareToken(app.getBoardBySlugIdHandler))
 
 			recorder := httptest.NewRecorder()
 
-			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/boards/%s", tc.slugId), nil)
+			url := fmt.Sprintf("/v1/boards/%s", tc.slugId)
+			if tc.shareToken != "" {
+				url += "?share_token=" + tc.shareToken
+			}
+
+			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 			require.NotEmpty(t, req)
 
-			req = addUserToContext(t, app, req)
+			if tc.anonymous {
+				req = app.contextSetUser(req, data.AnonymousUser)
+			} else {
+				req = addUserToContext(t, app, req)
+			}
 
 			router.ServeHTTP(recorder, req)
 
@@ -278,23 +389,41 @@ func TestInviteUserToBoardHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	boardModel := mockdata.NewMockBoardModel(ctrl)
 	userModel := mockdata.NewMockUserModel(ctrl)
+	invitationModel := mockdata.NewMockInvitationModel(ctrl)
+	publisher := mockworker.NewMockPublisher(ctrl)
 
 	app.models = data.Models{
-		Boards: boardModel,
-		User:   userModel,
+		Boards:      boardModel,
+		User:        userModel,
+		Invitations: invitationModel,
 	}
+	app.jobPublisher = publisher
+	app.config.ClientUrl = "http://client.test/"
 
 	type inviteInput struct {
 		Email   string `json:"email"`
 		BoardId int64  `json:"board_id"`
+		Role    string `json:"role,omitempty"`
 	}
 
+	ownedBoard := &data.BoardResult{Id: 12, OwnerId: 1, Name: "test", IsOwner: true, Role: data.BoardRoleOwner}
+	editorBoard := &data.BoardResult{Id: 12, OwnerId: 5, Name: "test", Role: data.BoardRoleEditor}
+
 	testCases := []struct {
 		name          string
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 		buildStub     func()
 		body          any
 	}{
+		{
+			name: "Malformed body",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+			buildStub: func() {
+			},
+			body: "invalid body",
+		},
 		{
 			name: "Invalid email",
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -305,23 +434,118 @@ func TestInviteUserToBoardHandler(t *testing.T) {
 			body: inviteInput{BoardId: 12, Email: "invalidEmail"},
 		},
 		{
-			name: "User not found",
+			name: "Invalid role",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "role")
+			},
+			buildStub: func() {
+			},
+			body: inviteInput{BoardId: 12, Email: "valid@email.com", Role: data.BoardRoleOwner},
+		},
+		{
+			name: "Inviting to a board that the user is not a member of",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(nil, data.ErrRecordNotFound)
+			},
+			body: inviteInput{BoardId: 12, Email: "valid@email.com"},
+		},
+		{
+			name: "Inviting oneself to a board to get around the membership check on join",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(nil, data.ErrRecordNotFound)
+				boardModel.EXPECT().
+					InviteUser(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			body: inviteInput{BoardId: 12, Email: "test@test.com", Role: data.BoardRoleEditor},
+		},
+		{
+			name: "Inviting as a member who is not the owner",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(editorBoard, nil)
+			},
+			body: inviteInput{BoardId: 12, Email: "valid@email.com", Role: data.BoardRoleEditor},
+		},
+		{
+			name: "Board not found for the invitation",
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(ownedBoard, nil)
 				userModel.EXPECT().
 					GetByEmail(gomock.Any()).
 					Return(nil, data.ErrRecordNotFound)
+				invitationModel.EXPECT().
+					New(int64(12), "valid@email.com", data.BoardRoleEditor, gomock.Any()).
+					Return(nil, data.ErrRecordNotFound)
 			},
 			body: inviteInput{BoardId: 12, Email: "valid@email.com"},
 		},
+		{
+			name: "Successful invitation by email",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "invitation")
+				// only the invited email gets the token
+				require.NotContains(t, recorder.Body.String(), "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(ownedBoard, nil)
+				userModel.EXPECT().
+					GetByEmail(gomock.Any()).
+					Return(nil, data.ErrRecordNotFound)
+				invitationModel.EXPECT().
+					New(int64(12), "new@email.com", data.BoardRoleViewer, gomock.Any()).
+					Return(&data.Invitation{
+						BoardId:   12,
+						BoardName: "test",
+						Email:     "new@email.com",
+						Role:      data.BoardRoleViewer,
+						Token:     "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+					}, nil)
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job worker.Job) error {
+						emailJob := job.Data.(worker.EmailJob)
+						require.Equal(t, "board_invitation.tmpl", emailJob.TmplFile)
+						require.Equal(t, "new@email.com", emailJob.To)
+						require.Equal(t, "ABCDEFGHIJKLMNOPQRSTUVWXYZ", emailJob.TmplData["invitationToken"])
+						require.Equal(t, "http://client.test/invitations/accept?token=ABCDEFGHIJKLMNOPQRSTUVWXYZ", emailJob.TmplData["acceptURL"])
+						return nil
+					})
+			},
+			body: inviteInput{BoardId: 12, Email: "new@email.com", Role: data.BoardRoleViewer},
+		},
 		{
 			name: "Unexpected error on GetByEmail",
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(ownedBoard, nil)
 				userModel.EXPECT().
 					GetByEmail(gomock.Any()).
 					Return(nil, errors.New("err"))
@@ -334,11 +558,14 @@ func TestInviteUserToBoardHandler(t *testing.T) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(ownedBoard, nil)
 				userModel.EXPECT().
 					GetByEmail(gomock.Any()).
 					Return(&data.User{ID: 12}, nil)
 				boardModel.EXPECT().
-					InviteUser(gomock.Any(), gomock.Any()).
+					InviteUser(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(data.ErrRecordNotFound)
 			},
 			body: inviteInput{BoardId: 12, Email: "valid@email.com"},
@@ -349,11 +576,14 @@ func TestInviteUserToBoardHandler(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(ownedBoard, nil)
 				userModel.EXPECT().
 					GetByEmail(gomock.Any()).
 					Return(&data.User{ID: 12}, nil)
 				boardModel.EXPECT().
-					InviteUser(gomock.Any(), gomock.Any()).
+					InviteUser(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("err"))
 			},
 			body: inviteInput{BoardId: 12, Email: "valid@email.com"},
@@ -364,15 +594,36 @@ func TestInviteUserToBoardHandler(t *testing.T) {
 				require.Equal(t, http.StatusCreated, recorder.Code)
 			},
 			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(ownedBoard, nil)
 				userModel.EXPECT().
 					GetByEmail(gomock.Any()).
 					Return(&data.User{ID: 12}, nil)
 				boardModel.EXPECT().
-					InviteUser(gomock.Any(), int64(12)).
+					InviteUser(gomock.Any(), int64(12), data.BoardRoleEditor).
 					Return(nil)
 			},
 			body: inviteInput{BoardId: 12, Email: "valid@email.com"},
 		},
+		{
+			name: "Successful invitation as a viewer",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(12)).
+					Return(ownedBoard, nil)
+				userModel.EXPECT().
+					GetByEmail(gomock.Any()).
+					Return(&data.User{ID: 12}, nil)
+				boardModel.EXPECT().
+					InviteUser(gomock.Any(), int64(12), data.BoardRoleViewer).
+					Return(nil)
+			},
+			body: inviteInput{BoardId: 12, Email: "valid@email.com", Role: data.BoardRoleViewer},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -402,3 +653,248 @@ func TestInviteUserToBoardHandler(t *testing.T) {
 		})
 	}
 }
+
+// TestBoardManagementHandlers tests renaming, deleting, restoring and duplicating boards
+func TestBoardManagementHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	publisher := mockworker.NewMockPublisher(ctrl)
+
+	app.models = data.Models{
+		Boards: boardModel,
+	}
+	app.jobPublisher = publisher
+	app.wsHub = ws.NewHub(app.models, nil, nil)
+
+	ownedBoard := &data.BoardResult{Id: 3, OwnerId: 1, Name: "test board", SlugId: "valid-12-ch-", IsOwner: true, Role: data.BoardRoleOwner}
+	viewerBoard := &data.BoardResult{Id: 3, OwnerId: 5, Name: "test board", SlugId: "valid-12-ch-", Role: data.BoardRoleViewer}
+	editorBoard := &data.BoardResult{Id: 3, OwnerId: 5, Name: "test board", SlugId: "valid-12-ch-", Role: data.BoardRoleEditor}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "Renaming as a viewer",
+			method: http.MethodPatch,
+			url:    "/v1/boards/valid-12-ch-",
+			body:   `{"name": "new name"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+			},
+		},
+		{
+			name:   "Invalid name",
+			method: http.MethodPatch,
+			url:    "/v1/boards/valid-12-ch-",
+			body:   `{"name": "ab"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "name")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+			},
+		},
+		{
+			name:   "Successful rename",
+			method: http.MethodPatch,
+			url:    "/v1/boards/valid-12-ch-",
+			body:   `{"name": "new name"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"name":"new name"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 3, OwnerId: 5, SlugId: "valid-12-ch-", Role: data.BoardRoleEditor}, nil)
+				boardModel.EXPECT().
+					Rename(int64(3), "new name").
+					Return(&data.Board{Id: 3, Name: "new name", SlugId: "valid-12-ch-"}, nil)
+			},
+		},
+		{
+			name:   "Deleting as a member",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(viewerBoard, nil)
+			},
+		},
+		{
+			name:   "Successful delete",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				boardModel.EXPECT().
+					Delete(int64(3)).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Listing the trash",
+			method: http.MethodGet,
+			url:    "/v1/boards/trash",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "deleted_at")
+			},
+			buildStub: func() {
+				deletedAt := time.Now()
+				boardModel.EXPECT().
+					GetDeletedBoards(int64(1)).
+					Return([]*data.BoardResult{{Id: 3, Name: "test board", DeletedAt: &deletedAt}}, nil)
+			},
+		},
+		{
+			name:   "Invalid recent boards limit",
+			method: http.MethodGet,
+			url:    "/v1/boards/recent?limit=500",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "limit")
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "Listing the recent boards",
+			method: http.MethodGet,
+			url:    "/v1/boards/recent?limit=5",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "last_opened_at")
+				require.Contains(t, recorder.Body.String(), "last_activity_at")
+			},
+			buildStub: func() {
+				openedAt := time.Now()
+				boardModel.EXPECT().
+					GetRecentBoards(int64(1), 5).
+					Return([]*data.BoardResult{{Id: 3, Name: "test board", LastOpenedAt: &openedAt, LastActivityAt: openedAt}}, nil)
+			},
+		},
+		{
+			name:   "Restoring a board that is not in the trash",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/untrash",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					Restore(int64(1), "valid-12-ch-").
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Successful restore",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/untrash",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					Restore(int64(1), "valid-12-ch-").
+					Return(&data.Board{Id: 3, SlugId: "valid-12-ch-"}, nil)
+			},
+		},
+		{
+			name:   "Duplicating a board of another user",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/duplicate",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Viewer cannot duplicate the board",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/duplicate",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(viewerBoard, nil)
+			},
+		},
+		{
+			name:   "Successful duplicate",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/duplicate",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editorBoard, nil)
+				boardModel.EXPECT().
+					Duplicate(int64(3), gomock.Any()).
+					DoAndReturn(func(_ int64, board *data.Board) (*data.Board, error) {
+						require.Equal(t, "Copy of test board", board.Name)
+						require.Equal(t, int64(1), board.OwnerId)
+						require.Len(t, board.SlugId, 12)
+						return board, nil
+					})
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId", app.boardCollectionGetHandler)
+			router.HandlerFunc(http.MethodPatch, "/v1/boards/:slugId", app.requireActivatedUser(app.renameBoardHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId", app.requireActivatedUser(app.deleteBoardHandler))
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/untrash", app.requireActivatedUser(app.restoreDeletedBoardHandler))
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/duplicate", app.requireActivatedUser(app.duplicateBoardHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
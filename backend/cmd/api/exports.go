@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/render"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	"mime"
+	"net/http"
+)
+
+// exportPageSVGHandler handles rendering the elements of a board page into an SVG document.
+// All the members of the board can export its pages.
+func (app *application) exportPageSVGHandler(w http.ResponseWriter, r *http.Request) {
+	pageId, err := app.readPageIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	board, ok := app.readMemberBoard(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := app.models.Pages.Get(board.Id, pageId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	err = render.SVG(&buf, page.Elements)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": page.Name + ".svg"}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// getBoardThumbnailHandler handles serving the thumbnail of the board that the worker rendered from its first page.
+// The thumbnail urls in the board list change with every new thumbnail, so the thumbnails can be cached.
+func (app *application) getBoardThumbnailHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readMemberBoard(w, r)
+	if !ok {
+		return
+	}
+
+	thumbnail, err := app.models.Thumbnails.Get(board.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+	w.Write(thumbnail.Content)
+}
+
+// createPageExportHandler handles requesting a raster export of a board page. The export is rendered by the worker,
+// its status can be followed and the image can be downloaded from the export endpoints once it is completed.
+func (app *application) createPageExportHandler(w http.ResponseWriter, r *http.Request) {
+	pageId, err := app.readPageIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	var input struct {
+		Format string             `json:"format"`
+		Scale  *float64           `json:"scale"`
+		Region *data.ExportRegion `json:"region"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	board, ok := app.readMemberBoard(w, r)
+	if !ok {
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	export := &data.Export{
+		BoardId:  board.Id,
+		PageId:   &pageId,
+		UserId:   int64(user.ID),
+		Format:   input.Format,
+		Scale:    1,
+		Region:   input.Region,
+		Delivery: data.ExportDeliveryLink,
+	}
+	if export.Format == "" {
+		export.Format = data.ExportFormatPNG
+	}
+	if input.Scale != nil {
+		export.Scale = *input.Scale
+	}
+
+	app.createExport(w, r, export, board.Name)
+}
+
+// createBoardExportHandler handles exporting all the pages of a board into a PDF document in the background.
+// The document can be downloaded once it is rendered, it is also sent to the user by email when the delivery
+// is email.
+func (app *application) createBoardExportHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Format   string `json:"format"`
+		Delivery string `json:"delivery"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	board, ok := app.readMemberBoard(w, r)
+	if !ok {
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	export := &data.Export{
+		BoardId:  board.Id,
+		UserId:   int64(user.ID),
+		Format:   input.Format,
+		Scale:    1,
+		Delivery: input.Delivery,
+	}
+	if export.Format == "" {
+		export.Format = data.ExportFormatPDF
+	}
+	if export.Delivery == "" {
+		export.Delivery = data.ExportDeliveryLink
+	}
+	if export.Delivery == data.ExportDeliveryEmail {
+		export.Email = user.Email
+	}
+
+	app.createExport(w, r, export, board.Name)
+}
+
+// createExport validates and creates the pending export, and enqueues the job that renders it
+func (app *application) createExport(w http.ResponseWriter, r *http.Request, export *data.Export, boardName string) {
+	v := validator.New()
+	if data.ValidateExport(v, export); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	export, err := app.models.Exports.New(export)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.jobPublisher.EnqueueJob(r.Context(), worker.Job{
+		Type: worker.JobTypeExport,
+		Data: worker.ExportJob{ExportId: export.Id, BoardName: boardName},
+	})
+	if err != nil {
+		// the export would stay pending forever without the job
+		_ = app.models.Exports.Fail(export.Id)
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/v1/exports/%d", export.Id))
+
+	err = app.writeJSON(w, http.StatusAccepted, envelope{"export": export}, headers)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// readUserExport reads the export from the id parameter, only the user who requested the export can access it.
+// It writes the error response and reports false if the export is not found.
+func (app *application) readUserExport(w http.ResponseWriter, r *http.Request) (*data.Export, bool) {
+	exportId, err := app.readIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	user := app.contextGetUser(r)
+
+	export, err := app.models.Exports.GetForUser(exportId, int64(user.ID))
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	return export, true
+}
+
+// getExportHandler handles showing the status of an export
+func (app *application) getExportHandler(w http.ResponseWriter, r *http.Request) {
+	export, ok := app.readUserExport(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.writeJSON(w, http.StatusOK, envelope{"export": export}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// downloadExportHandler handles downloading the rendered file of a completed export
+func (app *application) downloadExportHandler(w http.ResponseWriter, r *http.Request) {
+	export, ok := app.readUserExport(w, r)
+	if !ok {
+		return
+	}
+
+	switch export.Status {
+	case data.ExportStatusPending:
+		app.errorResponse(w, r, http.StatusConflict, "the export is not ready yet")
+		return
+	case data.ExportStatusFailed:
+		app.errorResponse(w, r, http.StatusConflict, "the export could not be rendered")
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.Filename()}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(export.Content)
+}
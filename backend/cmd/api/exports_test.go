@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	mockworker "github.com/umtdemr/wb-backend/internal/worker/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestExportHandlers tests exporting the boards and their pages
+func TestExportHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	pageModel := mockdata.NewMockPageModel(ctrl)
+	exportModel := mockdata.NewMockExportModel(ctrl)
+	thumbnailModel := mockdata.NewMockThumbnailModel(ctrl)
+	publisher := mockworker.NewMockPublisher(ctrl)
+
+	app.models = data.Models{
+		Boards:     boardModel,
+		Pages:      pageModel,
+		Exports:    exportModel,
+		Thumbnails: thumbnailModel,
+	}
+	app.jobPublisher = publisher
+
+	viewerBoard := &data.BoardResult{Id: 3, OwnerId: 5, Name: "Roadmap", SlugId: "valid-12-ch-", Role: data.BoardRoleViewer}
+	pageId := int64(8)
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "Invalid page id",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/pages/abc/export.svg",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "Non member",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/pages/8/export.svg",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Page of another board",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/pages/8/export.svg",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				pageModel.EXPECT().
+					Get(int64(3), int64(8)).
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Viewer exports the page",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/pages/8/export.svg",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/svg+xml", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `inline; filename="Page 1.svg"`, recorder.Header().Get("Content-Disposition"))
+				require.Contains(t, recorder.Body.String(), "<svg")
+				require.Contains(t, recorder.Body.String(), "<rect")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				pageModel.EXPECT().
+					Get(int64(3), int64(8)).
+					Return(&data.Page{Id: 8, BoardId: 3, Name: "Page 1", Elements: []data.Element{
+						{Type: data.ElementTypeRectangle, Width: 100, Height: 50, StrokeColor: data.ColorBlack, StrokeWidth: 2},
+					}}, nil)
+			},
+		},
+		{
+			name:   "Invalid export options",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages/8/exports",
+			body:   `{"format": "gif", "scale": 10, "region": {"x": 0, "y": 0, "width": 0, "height": 10}}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "format")
+				require.Contains(t, recorder.Body.String(), "scale")
+				require.Contains(t, recorder.Body.String(), "region")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+			},
+		},
+		{
+			name:   "Export page of another board",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages/8/exports",
+			body:   `{}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				exportModel.EXPECT().
+					New(gomock.Any()).
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Failed to enqueue the export",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages/8/exports",
+			body:   `{}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				exportModel.EXPECT().
+					New(gomock.Any()).
+					Return(&data.Export{Id: 5, Status: data.ExportStatusPending}, nil)
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), gomock.Any()).
+					Return(errors.New("nats down"))
+				exportModel.EXPECT().
+					Fail(int64(5)).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Viewer requests a scaled export of a region",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages/8/exports",
+			body:   `{"scale": 2, "region": {"x": -10, "y": 0, "width": 300, "height": 200}}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+				require.Equal(t, "/v1/exports/5", recorder.Header().Get("Location"))
+				require.Contains(t, recorder.Body.String(), `"status":"pending"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				exportModel.EXPECT().
+					New(gomock.Eq(&data.Export{
+						BoardId:  3,
+						PageId:   &pageId,
+						UserId:   1,
+						Format:   data.ExportFormatPNG,
+						Scale:    2,
+						Region:   &data.ExportRegion{X: -10, Width: 300, Height: 200},
+						Delivery: data.ExportDeliveryLink,
+					})).
+					Return(&data.Export{Id: 5, BoardId: 3, PageId: &pageId, Format: data.ExportFormatPNG, Scale: 2, Status: data.ExportStatusPending}, nil)
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job worker.Job) error {
+						require.Equal(t, worker.JobTypeExport, job.Type)
+						require.Equal(t, int64(5), job.Data.(worker.ExportJob).ExportId)
+						return nil
+					})
+			},
+		},
+		{
+			name:   "Pdf export of a page",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages/8/exports",
+			body:   `{"format": "pdf"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"format"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+			},
+		},
+		{
+			name:   "Invalid board export",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/exports",
+			body:   `{"format": "png", "delivery": "fax"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"format"`)
+				require.Contains(t, recorder.Body.String(), `"delivery"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+			},
+		},
+		{
+			name:   "Viewer requests a pdf export by email",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/exports",
+			body:   `{"delivery": "email"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+				require.Equal(t, "/v1/exports/6", recorder.Header().Get("Location"))
+				require.Contains(t, recorder.Body.String(), `"page_id":null`)
+				require.Contains(t, recorder.Body.String(), `"delivery":"email"`)
+				require.NotContains(t, recorder.Body.String(), "test@test.com")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				exportModel.EXPECT().
+					New(gomock.Eq(&data.Export{
+						BoardId:  3,
+						UserId:   1,
+						Format:   data.ExportFormatPDF,
+						Scale:    1,
+						Delivery: data.ExportDeliveryEmail,
+						Email:    "test@test.com",
+					})).
+					Return(&data.Export{
+						Id: 6, BoardId: 3, Format: data.ExportFormatPDF, Scale: 1, Status: data.ExportStatusPending,
+						Delivery: data.ExportDeliveryEmail, Email: "test@test.com",
+					}, nil)
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job worker.Job) error {
+						require.Equal(t, worker.JobTypeExport, job.Type)
+						require.Equal(t, worker.ExportJob{ExportId: 6, BoardName: "Roadmap"}, job.Data)
+						return nil
+					})
+			},
+		},
+		{
+			name:   "Export of someone else",
+			method: http.MethodGet,
+			url:    "/v1/exports/5",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				exportModel.EXPECT().
+					GetForUser(int64(5), int64(1)).
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Export status",
+			method: http.MethodGet,
+			url:    "/v1/exports/5",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"status":"completed"`)
+				require.NotContains(t, recorder.Body.String(), "content")
+			},
+			buildStub: func() {
+				exportModel.EXPECT().
+					GetForUser(int64(5), int64(1)).
+					Return(&data.Export{Id: 5, Status: data.ExportStatusCompleted, Content: []byte("image")}, nil)
+			},
+		},
+		{
+			name:   "Download pending export",
+			method: http.MethodGet,
+			url:    "/v1/exports/5/download",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+			buildStub: func() {
+				exportModel.EXPECT().
+					GetForUser(int64(5), int64(1)).
+					Return(&data.Export{Id: 5, Status: data.ExportStatusPending}, nil)
+			},
+		},
+		{
+			name:   "Download completed export",
+			method: http.MethodGet,
+			url:    "/v1/exports/5/download",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename=export-5.png`, recorder.Header().Get("Content-Disposition"))
+				require.Equal(t, "image", recorder.Body.String())
+			},
+			buildStub: func() {
+				exportModel.EXPECT().
+					GetForUser(int64(5), int64(1)).
+					Return(&data.Export{Id: 5, Format: data.ExportFormatPNG, Status: data.ExportStatusCompleted, Content: []byte("image")}, nil)
+			},
+		},
+		{
+			name:   "Download completed pdf export",
+			method: http.MethodGet,
+			url:    "/v1/exports/6/download",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename=export-6.pdf`, recorder.Header().Get("Content-Disposition"))
+			},
+			buildStub: func() {
+				exportModel.EXPECT().
+					GetForUser(int64(6), int64(1)).
+					Return(&data.Export{Id: 6, Format: data.ExportFormatPDF, Status: data.ExportStatusCompleted, Content: []byte("%PDF-1.4")}, nil)
+			},
+		},
+		{
+			name:   "Board without a thumbnail",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/thumbnail.png",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				thumbnailModel.EXPECT().
+					Get(int64(3)).
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Board thumbnail",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/thumbnail.png?v=1736600000",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+				require.NotEmpty(t, recorder.Header().Get("Cache-Control"))
+				require.Equal(t, "thumbnail", recorder.Body.String())
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				thumbnailModel.EXPECT().
+					Get(int64(3)).
+					Return(&data.Thumbnail{BoardId: 3, Revision: 12, Content: []byte("thumbnail")}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/thumbnail.png", app.requireActivatedUser(app.getBoardThumbnailHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/pages/:pageId/export.svg", app.requireActivatedUser(app.exportPageSVGHandler))
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/exports", app.requireActivatedUser(app.createBoardExportHandler))
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/pages/:pageId/exports", app.requireActivatedUser(app.createPageExportHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/exports/:id", app.requireActivatedUser(app.getExportHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/exports/:id/download", app.requireActivatedUser(app.downloadExportHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"net/http"
+	"strings"
+)
+
+// getFoldersHandler handles listing the folders of the user
+func (app *application) getFoldersHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	folders, err := app.models.Folders.GetAll(int64(user.ID))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"folders": folders}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// createFolderHandler handles creating a folder for the user
+func (app *application) createFolderHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string `json:"name"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	folder := &data.Folder{Name: strings.TrimSpace(input.Name)}
+
+	v := validator.New()
+	if data.ValidateFolder(v, folder); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	folder, err = app.models.Folders.New(int64(user.ID), folder.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrDuplicateFolderName):
+			v.AddError("name", "a folder with this name already exists")
+			app.fieldValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"folder": folder}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// renameFolderHandler handles renaming a folder of the user
+func (app *application) renameFolderHandler(w http.ResponseWriter, r *http.Request) {
+	folderId, err := app.readIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	var input struct {
+		Name string `json:"name"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	folder := &data.Folder{Name: strings.TrimSpace(input.Name)}
+
+	v := validator.New()
+	if data.ValidateFolder(v, folder); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	folder, err = app.models.Folders.Rename(int64(user.ID), folderId, folder.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrDuplicateFolderName):
+			v.AddError("name", "a folder with this name already exists")
+			app.fieldValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"folder": folder}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// deleteFolderHandler handles deleting a folder of the user. The boards in the folder are kept.
+func (app *application) deleteFolderHandler(w http.ResponseWriter, r *http.Request) {
+	folderId, err := app.readIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	err = app.models.Folders.Delete(int64(user.ID), folderId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "folder is deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// moveBoardToFolderHandler handles putting the board into one of the folders of the user.
+// A null folder id takes the board out of its folder.
+func (app *application) moveBoardToFolderHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		FolderId *int64 `json:"folder_id"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	board, ok := app.readMemberBoard(w, r)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	v.Check(input.FolderId == nil || *input.FolderId > 0, "folder_id", "must be a positive integer")
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	// the membership is checked above, so a missing record means that the folder is not found
+	err = app.models.Boards.MoveToFolder(board.Id, int64(user.ID), input.FolderId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("folder_id", "folder not found")
+			app.fieldValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"folder_id": input.FolderId}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestFolderHandlers tests managing the folders, moving the boards into them and starring the boards
+func TestFolderHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	folderModel := mockdata.NewMockFolderModel(ctrl)
+
+	app.models = data.Models{
+		Boards:  boardModel,
+		Folders: folderModel,
+	}
+
+	viewerBoard := &data.BoardResult{Id: 3, OwnerId: 5, SlugId: "valid-12-ch-", Role: data.BoardRoleViewer}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "List folders",
+			method: http.MethodGet,
+			url:    "/v1/folders",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"name":"work"`)
+			},
+			buildStub: func() {
+				folderModel.EXPECT().
+					GetAll(int64(1)).
+					Return([]data.Folder{{Id: 4, Name: "work"}}, nil)
+			},
+		},
+		{
+			name:   "Invalid folder name",
+			method: http.MethodPost,
+			url:    "/v1/folders",
+			body:   `{"name": "  "}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "name")
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "Duplicate folder name",
+			method: http.MethodPost,
+			url:    "/v1/folders",
+			body:   `{"name": "work"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "already exists")
+			},
+			buildStub: func() {
+				folderModel.EXPECT().
+					New(int64(1), "work").
+					Return(nil, data.ErrDuplicateFolderName)
+			},
+		},
+		{
+			name:   "Successful folder creation",
+			method: http.MethodPost,
+			url:    "/v1/folders",
+			body:   `{"name": " work "}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"id":4`)
+			},
+			buildStub: func() {
+				folderModel.EXPECT().
+					New(int64(1), "work").
+					Return(&data.Folder{Id: 4, Name: "work"}, nil)
+			},
+		},
+		{
+			name:   "Rename folder not found",
+			method: http.MethodPatch,
+			url:    "/v1/folders/4",
+			body:   `{"name": "projects"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				folderModel.EXPECT().
+					Rename(int64(1), int64(4), "projects").
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Successful folder deletion",
+			method: http.MethodDelete,
+			url:    "/v1/folders/4",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				folderModel.EXPECT().
+					Delete(int64(1), int64(4)).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Move board to a folder of someone else",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/folder",
+			body:   `{"folder_id": 9}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "folder_id")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				boardModel.EXPECT().
+					MoveToFolder(int64(3), int64(1), gomock.Any()).
+					Return(data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Take board out of its folder",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/folder",
+			body:   `{"folder_id": null}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"folder_id":null`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				boardModel.EXPECT().
+					MoveToFolder(int64(3), int64(1), gomock.Nil()).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Star board of a non member",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/star",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Viewer stars the board",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/star",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				boardModel.EXPECT().
+					Star(int64(3), int64(1)).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Unstar board",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/star",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(viewerBoard, nil)
+				boardModel.EXPECT().
+					Unstar(int64(3), int64(1)).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Invalid starred filter",
+			method: http.MethodGet,
+			url:    "/v1/boards?starred=maybe&folder=-1",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "starred")
+				require.Contains(t, recorder.Body.String(), "folder")
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "List starred boards of a folder",
+			method: http.MethodGet,
+			url:    "/v1/boards?starred=true&folder=4",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"is_starred":true`)
+				require.Contains(t, recorder.Body.String(), `"folder_id":4`)
+			},
+			buildStub: func() {
+				folderId := int64(4)
+				boardModel.EXPECT().
+					GetAllBoards(int64(1), gomock.Eq(data.BoardFilters{
+						Ownership: data.BoardOwnershipAll,
+						Sort:      "-created_at",
+						PageSize:  data.DefaultBoardPageSize,
+						FolderId:  4,
+						Starred:   true,
+					})).
+					Return([]*data.BoardResult{{Id: 3, IsStarred: true, FolderId: &folderId}}, data.Metadata{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodGet, "/v1/boards", app.requireActivatedUser(app.getAllBoardsHandler))
+			router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/star", app.requireActivatedUser(app.starBoardHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/star", app.requireActivatedUser(app.unstarBoardHandler))
+			router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/folder", app.requireActivatedUser(app.moveBoardToFolderHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/folders", app.requireActivatedUser(app.getFoldersHandler))
+			router.HandlerFunc(http.MethodPost, "/v1/folders", app.requireActivatedUser(app.createFolderHandler))
+			router.HandlerFunc(http.MethodPatch, "/v1/folders/:id", app.requireActivatedUser(app.renameFolderHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/folders/:id", app.requireActivatedUser(app.deleteFolderHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
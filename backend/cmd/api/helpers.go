@@ -7,7 +7,9 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/rs/zerolog/log"
 	"github.com/umtdemr/wb-backend/internal/jsonHelper"
+	"github.com/umtdemr/wb-backend/internal/validator"
 	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -26,6 +28,47 @@ func (app *application) readIdParam(r *http.Request) (int64, error) {
 	return id, nil
 }
 
+// readPageIdParam gets the page id parameter from context
+func (app *application) readPageIdParam(r *http.Request) (int64, error) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	pageId, err := strconv.ParseInt(params.ByName("pageId"), 10, 64)
+	if err != nil || pageId < 1 {
+		return 0, errors.New("invalid page id parameter")
+	}
+
+	return pageId, nil
+}
+
+// readWorkspaceIdParam gets the workspace id parameter from context
+func (app *application) readWorkspaceIdParam(r *http.Request) (int64, error) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	workspaceId, err := strconv.ParseInt(params.ByName("workspaceId"), 10, 64)
+	if err != nil || workspaceId < 1 {
+		return 0, errors.New("invalid workspace id parameter")
+	}
+
+	return workspaceId, nil
+}
+
+// readSlugIdParam gets the board slug id from context. Slug ids are always 12 characters long.
+func (app *application) readSlugIdParam(r *http.Request) (string, error) {
+	params := httprouter.ParamsFromContext(r.Context())
+
+	slugId := params.ByName("slugId")
+	if len(slugId) != 12 {
+		return "", errors.New("invalid slug id parameter")
+	}
+
+	return slugId, nil
+}
+
+// readShareToken gets the share link token of the board from the share_token query parameter
+func (app *application) readShareToken(r *http.Request) string {
+	return r.URL.Query().Get("share_token")
+}
+
 // writeJSON writes json data to http.ResponseWriter
 func (app *application) writeJSON(w http.ResponseWriter, status int, data envelope, headers http.Header) error {
 	js, err := json.Marshal(data)
@@ -49,8 +92,59 @@ func (app *application) writeJSON(w http.ResponseWriter, status int, data envelo
 
 func (app *application) readJSON(w http.ResponseWriter, r *http.Request, dst any) error {
 	// Use http.MaxBytesReader to limit the size of the request body to 1 MB
-	maxBytes := 1_048_576
-	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBytes))
+	return app.readJSONWithLimit(w, r, dst, 1_048_576)
+}
+
+// readString returns a string value from the query string, or the default value if no matching key is found
+func (app *application) readString(qs url.Values, key string, defaultValue string) string {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	return s
+}
+
+// readInt reads a string value from the query string and converts it to an integer. If the value cannot be
+// converted, it records an error message in the validator instance.
+func (app *application) readInt(qs url.Values, key string, defaultValue int, v *validator.Validator) int {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		v.AddError(key, "must be an integer value")
+		return defaultValue
+	}
+
+	return i
+}
+
+// readBool reads a string value from the query string and converts it to a boolean. If the value cannot be
+// converted, it records an error message in the validator instance.
+func (app *application) readBool(qs url.Values, key string, defaultValue bool, v *validator.Validator) bool {
+	s := qs.Get(key)
+
+	if s == "" {
+		return defaultValue
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		v.AddError(key, "must be a boolean value")
+		return defaultValue
+	}
+
+	return b
+}
+
+// readJSONWithLimit reads the JSON body like readJSON, for the requests that can be larger than 1 MB
+func (app *application) readJSONWithLimit(w http.ResponseWriter, r *http.Request, dst any, maxBytes int64) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
 
 	return jsonHelper.ReadJson(r.Body, dst)
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"github.com/umtdemr/wb-backend/internal/ws"
+	"net/http"
+	"strconv"
+)
+
+// getBoardHistoryHandler handles listing the snapshots and revisions of a board
+func (app *application) getBoardHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	slugId, err := app.readSlugIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	// revisions are paginated with the before query parameter
+	var before int64
+	if beforeParam := r.URL.Query().Get("before"); beforeParam != "" {
+		before, err = strconv.ParseInt(beforeParam, 10, 64)
+		if err != nil || before < 0 {
+			app.fieldValidationResponse(w, r, map[string]string{"before": "must be a valid revision"})
+			return
+		}
+	}
+
+	user := app.contextGetUser(r)
+
+	// only the members of the board can see its history
+	_, err = app.models.Boards.GetBoard(int64(user.ID), slugId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	history, err := app.models.History.Get(slugId, before)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"history": history}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// restoreBoardHandler handles restoring a page of the board to a previous revision
+func (app *application) restoreBoardHandler(w http.ResponseWriter, r *http.Request) {
+	slugId, err := app.readSlugIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	var input struct {
+		PageId   int64 `json:"page_id"`
+		Revision int64 `json:"revision"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(input.PageId > 0, "page_id", "must be set")
+	v.Check(input.Revision >= 0, "revision", "must not be negative")
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	board, err := app.models.Boards.GetBoard(int64(user.ID), slugId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	if !data.CanEditBoard(board.Role) {
+		app.notPermittedResponse(w, r)
+		return
+	}
+
+	revision, err := app.models.History.RestorePage(slugId, int64(user.ID), input.PageId, input.Revision)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrRevisionNotAvailable):
+			app.fieldValidationResponse(w, r, map[string]string{"revision": "is not available for this page"})
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	// let the connected clients know that they should reload the page
+	app.wsHub.BroadcastEvent(slugId, ws.EventPageRestored, envelope{
+		"page_id":  input.PageId,
+		"revision": revision,
+	})
+	// the restored page can be the first page that the thumbnail shows
+	app.enqueueThumbnail(r, slugId)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"page_id": input.PageId, "revision": revision}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	mockworker "github.com/umtdemr/wb-backend/internal/worker/mock"
+	"github.com/umtdemr/wb-backend/internal/ws"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetBoardHistoryHandler tests listing the history of a board
+func TestGetBoardHistoryHandler(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	historyModel := mockdata.NewMockHistoryModel(ctrl)
+
+	app.models = data.Models{
+		Boards:  boardModel,
+		History: historyModel,
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:  "Invalid before parameter",
+			query: "?before=abc",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+			buildStub: func() {},
+		},
+		{
+			name: "Not a member of the board",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "Unexpected error on history",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 1}, nil)
+				historyModel.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("testing err"))
+			},
+		},
+		{
+			name:  "Successful",
+			query: "?before=40",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"revision":42`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 1}, nil)
+				historyModel.EXPECT().
+					Get("valid-12-ch-", int64(40)).
+					Return(&data.History{Revision: 42}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/history", app.requireActivatedUser(app.getBoardHistoryHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/boards/valid-12-ch-/history%s", tc.query), nil)
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// TestRestoreBoardHandler tests restoring a page of a board to a revision
+func TestRestoreBoardHandler(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	historyModel := mockdata.NewMockHistoryModel(ctrl)
+	publisher := mockworker.NewMockPublisher(ctrl)
+
+	app.models = data.Models{
+		Boards:  boardModel,
+		History: historyModel,
+	}
+	app.wsHub = ws.NewHub(app.models, nil, nil)
+	app.jobPublisher = publisher
+
+	testCases := []struct {
+		name          string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name: "Invalid input",
+			body: `{"page_id": 0, "revision": -1}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "page_id")
+				require.Contains(t, recorder.Body.String(), "revision")
+			},
+			buildStub: func() {},
+		},
+		{
+			name: "Not a member of the board",
+			body: `{"page_id": 3, "revision": 10}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "Viewer of the board",
+			body: `{"page_id": 3, "revision": 10}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 1, Role: data.BoardRoleViewer}, nil)
+			},
+		},
+		{
+			name: "Revision is not available",
+			body: `{"page_id": 3, "revision": 10}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "revision")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 1, Role: data.BoardRoleEditor}, nil)
+				historyModel.EXPECT().
+					RestorePage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), data.ErrRevisionNotAvailable)
+			},
+		},
+		{
+			name: "Successful",
+			body: `{"page_id": 3, "revision": 10}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"revision":15`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 1, Role: data.BoardRoleEditor}, nil)
+				historyModel.EXPECT().
+					RestorePage("valid-12-ch-", int64(1), int64(3), int64(10)).
+					Return(int64(15), nil)
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), worker.Job{
+						Type: worker.JobTypeThumbnail,
+						Data: worker.ThumbnailJob{BoardSlugId: "valid-12-ch-"},
+					}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/restore", app.requireActivatedUser(app.restoreBoardHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/boards/valid-12-ch-/restore", bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
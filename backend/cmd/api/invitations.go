@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"net/http"
+)
+
+// acceptInvitationHandler handles joining a board with the token of the invitation email
+func (app *application) acceptInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TokenPlaintext string `json:"token"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+
+	if data.ValidateTokenPlaintext(v, input.TokenPlaintext); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	boardId, err := app.models.Invitations.Accept(user, input.TokenPlaintext)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("token", "invalid or expired invitation token")
+			app.fieldValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	board, err := app.models.Boards.GetBoardById(int64(user.ID), boardId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"board": board}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
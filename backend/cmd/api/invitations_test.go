@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestAcceptInvitationHandler tests joining a board with the token of the invitation email
+func TestAcceptInvitationHandler(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	invitationModel := mockdata.NewMockInvitationModel(ctrl)
+
+	app.models = data.Models{
+		Boards:      boardModel,
+		Invitations: invitationModel,
+	}
+
+	validToken := "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	testCases := []struct {
+		name          string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name: "Malformed JSON",
+			body: `{"token": `,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+			buildStub: func() {},
+		},
+		{
+			name: "Invalid token",
+			body: `{"token": "short"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "token")
+			},
+			buildStub: func() {},
+		},
+		{
+			name: "Used or expired invitation",
+			body: `{"token": "` + validToken + `"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "invalid or expired invitation token")
+			},
+			buildStub: func() {
+				invitationModel.EXPECT().
+					Accept(gomock.Any(), validToken).
+					Return(int64(0), data.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "Unexpected error on accepting",
+			body: `{"token": "` + validToken + `"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+			buildStub: func() {
+				invitationModel.EXPECT().
+					Accept(gomock.Any(), validToken).
+					Return(int64(0), errors.New("testing err"))
+			},
+		},
+		{
+			name: "Successful accept",
+			body: `{"token": "` + validToken + `"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"slug_id":"valid-12-ch-"`)
+			},
+			buildStub: func() {
+				invitationModel.EXPECT().
+					Accept(gomock.Any(), validToken).
+					Return(int64(3), nil)
+				boardModel.EXPECT().
+					GetBoardById(int64(1), int64(3)).
+					Return(&data.BoardResult{Id: 3, SlugId: "valid-12-ch-", Role: data.BoardRoleViewer}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodPut, "/v1/invitations/accepted", app.requireActivatedUser(app.acceptInvitationHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPut, "/v1/invitations/accepted", bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
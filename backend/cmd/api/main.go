@@ -100,7 +100,7 @@ func main() {
 		models:       models,
 		jobPublisher: jobPublisher,
 		router:       httprouter.New(),
-		wsHub:        ws.NewHub(models, nc),
+		wsHub:        ws.NewHub(models, nc, jobPublisher),
 	}
 
 	go app.wsHub.Run()
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"net/http"
+)
+
+// readOwnedBoard reads the board from the slug id parameter and checks that the user is its owner.
+// It writes the error response and reports false if the board cannot be managed by the user.
+func (app *application) readOwnedBoard(w http.ResponseWriter, r *http.Request) (*data.BoardResult, bool) {
+	slugId, err := app.readSlugIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	user := app.contextGetUser(r)
+
+	board, err := app.models.Boards.GetBoard(int64(user.ID), slugId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	if !board.IsOwner {
+		app.notPermittedResponse(w, r)
+		return nil, false
+	}
+
+	return board, true
+}
+
+// updateBoardMemberRoleHandler handles changing the role of a board member
+func (app *application) updateBoardMemberRoleHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	memberId, err := app.readIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	var input struct {
+		Role string `json:"role"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	data.ValidateMemberRole(v, input.Role)
+	v.Check(memberId != board.OwnerId, "user", "must not be the owner of the board")
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Boards.UpdateMemberRole(board.Id, memberId, input.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	// connected clients of the member should follow the new role right away
+	app.wsHub.ChangeUserRole(board.SlugId, int32(memberId), input.Role)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"member": envelope{"id": memberId, "role": input.Role}}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// removeBoardMemberHandler handles revoking the access of a member to the board
+func (app *application) removeBoardMemberHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	memberId, err := app.readIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	if memberId == board.OwnerId {
+		app.fieldValidationResponse(w, r, map[string]string{"user": "must not be the owner of the board"})
+		return
+	}
+
+	err = app.models.Boards.RemoveMember(board.Id, memberId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.RevokeUserAccess(board.SlugId, int32(memberId))
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "member is removed from the board"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// transferBoardOwnershipHandler handles making another member the owner of the board
+func (app *application) transferBoardOwnershipHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		UserId int64 `json:"user_id"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	v.Check(input.UserId > 0, "user_id", "must be set")
+	v.Check(input.UserId != board.OwnerId, "user_id", "must not be the owner of the board")
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Boards.TransferOwnership(board.Id, input.UserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	// previous owner stays as an editor
+	app.wsHub.ChangeUserRole(board.SlugId, int32(input.UserId), data.BoardRoleOwner)
+	app.wsHub.ChangeUserRole(board.SlugId, int32(board.OwnerId), data.BoardRoleEditor)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"owner_id": input.UserId}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
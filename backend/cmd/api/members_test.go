@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/ws"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBoardMemberHandlers tests changing roles, removing members and transferring the ownership of a board
+func TestBoardMemberHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+
+	app.models = data.Models{
+		Boards: boardModel,
+	}
+	app.wsHub = ws.NewHub(app.models, nil, nil)
+
+	ownedBoard := &data.BoardResult{Id: 3, OwnerId: 1, SlugId: "valid-12-ch-", IsOwner: true, Role: data.BoardRoleOwner}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "Not a member of the board",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/users/2",
+			body:   `{"role": "viewer"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Not the owner of the board",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/users/2",
+			body:   `{"role": "viewer"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 3, OwnerId: 5, Role: data.BoardRoleEditor}, nil)
+			},
+		},
+		{
+			name:   "Invalid role",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/users/1",
+			body:   `{"role": "owner"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "role")
+				require.Contains(t, recorder.Body.String(), "user")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+			},
+		},
+		{
+			name:   "Successful role change",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/users/2",
+			body:   `{"role": "viewer"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"role":"viewer"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				boardModel.EXPECT().
+					UpdateMemberRole(int64(3), int64(2), data.BoardRoleViewer).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Removing a user who is not a member",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/users/2",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				boardModel.EXPECT().
+					RemoveMember(int64(3), int64(2)).
+					Return(data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Removing the owner",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/users/1",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+			},
+		},
+		{
+			name:   "Successful removal",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/users/2",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				boardModel.EXPECT().
+					RemoveMember(int64(3), int64(2)).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Transferring to the owner",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/owner",
+			body:   `{"user_id": 1}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+			},
+		},
+		{
+			name:   "Unexpected error on transfer",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/owner",
+			body:   `{"user_id": 2}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				boardModel.EXPECT().
+					TransferOwnership(int64(3), int64(2)).
+					Return(errors.New("testing err"))
+			},
+		},
+		{
+			name:   "Successful transfer",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/owner",
+			body:   `{"user_id": 2}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"owner_id":2`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				boardModel.EXPECT().
+					TransferOwnership(int64(3), int64(2)).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/users/:id", app.requireActivatedUser(app.updateBoardMemberRoleHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/users/:id", app.requireActivatedUser(app.removeBoardMemberHandler))
+			router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/owner", app.requireActivatedUser(app.transferBoardOwnershipHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
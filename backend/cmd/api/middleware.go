@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"github.com/julienschmidt/httprouter"
 	"github.com/umtdemr/wb-backend/internal/data"
 	"github.com/umtdemr/wb-backend/internal/token"
 	"github.com/umtdemr/wb-backend/internal/validator"
@@ -113,7 +114,24 @@ func (app *application) requireActivatedUser(next http.HandlerFunc) http.Handler
 	return app.requireAuthenticatedUser(fn)
 }
 
-// requirePermission requires users to have specific permission to access to the handler
+// requireActivatedUserOrShareToken lets the requests with a share token in, even if there is no user.
+// Handlers are responsible for checking the share token, see readShareToken.
+func (app *application) requireActivatedUserOrShareToken(next http.HandlerFunc) http.HandlerFunc {
+	requireUser := app.requireActivatedUser(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if app.readShareToken(r) == "" {
+			requireUser.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requirePermission requires users to have specific permission to access to the handler.
+// On the workspace routes, the role of the user in the workspace gives its permissions as well.
+// Workspaces are not found for the users who are not a member of them.
 func (app *application) requirePermission(code string, next http.HandlerFunc) http.HandlerFunc {
 	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		user := app.contextGetUser(r)
@@ -124,6 +142,27 @@ func (app *application) requirePermission(code string, next http.HandlerFunc) ht
 			return
 		}
 
+		if httprouter.ParamsFromContext(r.Context()).ByName("workspaceId") != "" {
+			workspaceId, err := app.readWorkspaceIdParam(r)
+			if err != nil {
+				app.notFoundResponse(w, r)
+				return
+			}
+
+			role, err := app.models.Workspaces.GetMemberRole(workspaceId, int64(user.ID))
+			if err != nil {
+				switch {
+				case errors.Is(err, data.ErrRecordNotFound):
+					app.notFoundResponse(w, r)
+				default:
+					app.serverErrorResponse(w, r, err)
+				}
+				return
+			}
+
+			permissions = append(permissions, data.WorkspaceRolePermissions(role)...)
+		}
+
 		if !permissions.Include(code) {
 			app.notPermittedResponse(w, r)
 			return
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/require"
 	"github.com/umtdemr/wb-backend/internal/data"
 	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
@@ -298,8 +300,16 @@ func TestRequirePermission(t *testing.T) {
 	app := createTestApp()
 	ctrl := gomock.NewController(t)
 	permissionsModel := mockdata.NewMockPermissionModel(ctrl)
+	workspaceModel := mockdata.NewMockWorkspaceModel(ctrl)
 	app.models = data.Models{
 		Permissions: permissionsModel,
+		Workspaces:  workspaceModel,
+	}
+
+	// withWorkspace sets the workspace id route parameter as httprouter does
+	withWorkspace := func(r *http.Request, workspaceId string) *http.Request {
+		params := httprouter.Params{{Key: "workspaceId", Value: workspaceId}}
+		return r.WithContext(context.WithValue(r.Context(), httprouter.ParamsKey, params))
 	}
 
 	testCases := []struct {
@@ -354,6 +364,75 @@ func TestRequirePermission(t *testing.T) {
 			},
 			permission: "testing",
 		},
+		{
+			name: "Non member of the workspace",
+			setupReq: func(t *testing.T, r *http.Request) *http.Request {
+				return withWorkspace(app.contextSetUser(r, &data.User{ID: 1, IsVerified: true}), "3")
+			},
+			buildStub: func() {
+				permissionsModel.EXPECT().
+					GetAllForUser(gomock.Any()).
+					Return(data.Permissions{}, nil)
+				workspaceModel.EXPECT().
+					GetMemberRole(int64(3), int64(1)).
+					Return("", data.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			permission: data.PermissionWorkspaceRead,
+		},
+		{
+			name: "Invalid workspace id",
+			setupReq: func(t *testing.T, r *http.Request) *http.Request {
+				return withWorkspace(app.contextSetUser(r, &data.User{ID: 1, IsVerified: true}), "abc")
+			},
+			buildStub: func() {
+				permissionsModel.EXPECT().
+					GetAllForUser(gomock.Any()).
+					Return(data.Permissions{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			permission: data.PermissionWorkspaceRead,
+		},
+		{
+			name: "Workspace viewer cannot manage the workspace",
+			setupReq: func(t *testing.T, r *http.Request) *http.Request {
+				return withWorkspace(app.contextSetUser(r, &data.User{ID: 1, IsVerified: true}), "3")
+			},
+			buildStub: func() {
+				permissionsModel.EXPECT().
+					GetAllForUser(gomock.Any()).
+					Return(data.Permissions{}, nil)
+				workspaceModel.EXPECT().
+					GetMemberRole(int64(3), int64(1)).
+					Return(data.WorkspaceRoleViewer, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			permission: data.PermissionWorkspaceManage,
+		},
+		{
+			name: "Workspace admin manages the workspace",
+			setupReq: func(t *testing.T, r *http.Request) *http.Request {
+				return withWorkspace(app.contextSetUser(r, &data.User{ID: 1, IsVerified: true}), "3")
+			},
+			buildStub: func() {
+				permissionsModel.EXPECT().
+					GetAllForUser(gomock.Any()).
+					Return(data.Permissions{}, nil)
+				workspaceModel.EXPECT().
+					GetMemberRole(int64(3), int64(1)).
+					Return(data.WorkspaceRoleAdmin, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			permission: data.PermissionWorkspaceManage,
+		},
 	}
 
 	for _, tc := range testCases {
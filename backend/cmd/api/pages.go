@@ -0,0 +1,266 @@
+package main
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"github.com/umtdemr/wb-backend/internal/ws"
+	"net/http"
+)
+
+// readEditableBoard reads the board from the slug id parameter and checks that the user can edit it.
+// It writes the error response and reports false if the board cannot be edited by the user.
+func (app *application) readEditableBoard(w http.ResponseWriter, r *http.Request) (*data.BoardResult, bool) {
+	slugId, err := app.readSlugIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return nil, false
+	}
+
+	user := app.contextGetUser(r)
+
+	board, err := app.models.Boards.GetBoard(int64(user.ID), slugId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return nil, false
+	}
+
+	if !data.CanEditBoard(board.Role) {
+		app.notPermittedResponse(w, r)
+		return nil, false
+	}
+
+	return board, true
+}
+
+// createPageHandler handles adding a new page to the end of the board
+func (app *application) createPageHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string `json:"name"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	board, ok := app.readEditableBoard(w, r)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	data.ValidatePage(v, &data.Page{Name: input.Name})
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	page, revision, err := app.models.Pages.Create(board.Id, int64(user.ID), input.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.BroadcastEvent(board.SlugId, ws.EventPageCreated, envelope{"page": page, "revision": revision})
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"page": page}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// renamePageHandler handles renaming a page of the board
+func (app *application) renamePageHandler(w http.ResponseWriter, r *http.Request) {
+	pageId, err := app.readPageIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	var input struct {
+		Name string `json:"name"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	board, ok := app.readEditableBoard(w, r)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	data.ValidatePage(v, &data.Page{Name: input.Name})
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	page, revision, err := app.models.Pages.Rename(board.Id, int64(user.ID), pageId, input.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.BroadcastEvent(board.SlugId, ws.EventPageRenamed, envelope{
+		"page_id":  page.Id,
+		"name":     page.Name,
+		"revision": revision,
+	})
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"page": page}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// reorderPagesHandler handles changing the order of the pages. The new order must contain every page of the board.
+func (app *application) reorderPagesHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		PageIds []int64 `json:"page_ids"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	board, ok := app.readEditableBoard(w, r)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	v.Check(len(input.PageIds) > 0, "page_ids", "must be set")
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	pages, revision, err := app.models.Pages.Reorder(board.Id, int64(user.ID), input.PageIds)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrInvalidPageOrder):
+			app.fieldValidationResponse(w, r, map[string]string{"page_ids": "must contain every page of the board once"})
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.BroadcastEvent(board.SlugId, ws.EventPagesReordered, envelope{"page_ids": input.PageIds, "revision": revision})
+	// thumbnail shows the first page, which can be another page now
+	app.enqueueThumbnail(r, board.SlugId)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"pages": pages}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// deletePageHandler handles deleting a page of the board with its elements
+func (app *application) deletePageHandler(w http.ResponseWriter, r *http.Request) {
+	pageId, err := app.readPageIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	board, ok := app.readEditableBoard(w, r)
+	if !ok {
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	revision, err := app.models.Pages.Delete(board.Id, int64(user.ID), pageId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrLastPage):
+			app.errorResponse(w, r, http.StatusConflict, "the last page of the board cannot be deleted")
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.BroadcastEvent(board.SlugId, ws.EventPageDeleted, envelope{"page_id": pageId, "revision": revision})
+	// thumbnail shows the first page, which can be another page now
+	app.enqueueThumbnail(r, board.SlugId)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "page is deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// duplicatePageHandler handles copying a page of the board with its elements
+func (app *application) duplicatePageHandler(w http.ResponseWriter, r *http.Request) {
+	pageId, err := app.readPageIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	board, ok := app.readEditableBoard(w, r)
+	if !ok {
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	page, revision, err := app.models.Pages.Duplicate(board.Id, int64(user.ID), pageId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.wsHub.BroadcastEvent(board.SlugId, ws.EventPageCreated, envelope{"page": page, "revision": revision})
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"page": page}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
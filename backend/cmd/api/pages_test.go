@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	mockworker "github.com/umtdemr/wb-backend/internal/worker/mock"
+	"github.com/umtdemr/wb-backend/internal/ws"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestPageHandlers tests adding, renaming, reordering, deleting and duplicating the pages of a board
+func TestPageHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	pageModel := mockdata.NewMockPageModel(ctrl)
+	publisher := mockworker.NewMockPublisher(ctrl)
+
+	app.models = data.Models{
+		Boards: boardModel,
+		Pages:  pageModel,
+	}
+	app.jobPublisher = publisher
+	app.wsHub = ws.NewHub(app.models, nil, nil)
+
+	editableBoard := &data.BoardResult{Id: 3, OwnerId: 5, SlugId: "valid-12-ch-", Role: data.BoardRoleEditor}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "Viewer cannot add a page",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages",
+			body:   `{"name": "page 2"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 3, OwnerId: 5, Role: data.BoardRoleViewer}, nil)
+			},
+		},
+		{
+			name:   "Invalid page name",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages",
+			body:   `{"name": ""}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "name")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+			},
+		},
+		{
+			name:   "Successful page creation",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages",
+			body:   `{"name": "page 2"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"position":1`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+				pageModel.EXPECT().
+					Create(int64(3), int64(1), "page 2").
+					Return(&data.Page{Id: 8, BoardId: 3, Name: "page 2", Position: 1, Elements: []data.Element{}}, int64(4), nil)
+			},
+		},
+		{
+			name:   "Rename page not found",
+			method: http.MethodPatch,
+			url:    "/v1/boards/valid-12-ch-/pages/9",
+			body:   `{"name": "renamed"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+				pageModel.EXPECT().
+					Rename(int64(3), int64(1), int64(9), "renamed").
+					Return(nil, int64(0), data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Successful rename",
+			method: http.MethodPatch,
+			url:    "/v1/boards/valid-12-ch-/pages/8",
+			body:   `{"name": "renamed"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"name":"renamed"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+				pageModel.EXPECT().
+					Rename(int64(3), int64(1), int64(8), "renamed").
+					Return(&data.Page{Id: 8, BoardId: 3, Name: "renamed"}, int64(5), nil)
+			},
+		},
+		{
+			name:   "Invalid page order",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/pages",
+			body:   `{"page_ids": [8, 8]}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "page_ids")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+				pageModel.EXPECT().
+					Reorder(int64(3), int64(1), []int64{8, 8}).
+					Return(nil, int64(0), data.ErrInvalidPageOrder)
+			},
+		},
+		{
+			name:   "Successful reorder",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/pages",
+			body:   `{"page_ids": [8, 7]}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+				pageModel.EXPECT().
+					Reorder(int64(3), int64(1), []int64{8, 7}).
+					Return([]data.Page{{Id: 8, Position: 0}, {Id: 7, Position: 1}}, int64(7), nil)
+				// the first page can change, so the thumbnail is rendered again
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), worker.Job{
+						Type: worker.JobTypeThumbnail,
+						Data: worker.ThumbnailJob{BoardSlugId: "valid-12-ch-"},
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Last page cannot be deleted",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/pages/7",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+				pageModel.EXPECT().
+					Delete(int64(3), int64(1), int64(7)).
+					Return(int64(0), data.ErrLastPage)
+			},
+		},
+		{
+			name:   "Successful delete",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/pages/8",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+				pageModel.EXPECT().
+					Delete(int64(3), int64(1), int64(8)).
+					Return(int64(8), nil)
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), worker.Job{
+						Type: worker.JobTypeThumbnail,
+						Data: worker.ThumbnailJob{BoardSlugId: "valid-12-ch-"},
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Invalid page id",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages/abc/duplicate",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "Successful duplicate",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/pages/8/duplicate",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"name":"Copy of page 2"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(editableBoard, nil)
+				pageModel.EXPECT().
+					Duplicate(int64(3), int64(1), int64(8)).
+					Return(&data.Page{Id: 9, BoardId: 3, Name: "Copy of page 2", Position: 2, Elements: []data.Element{}}, int64(10), nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/pages", app.requireActivatedUser(app.createPageHandler))
+			router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/pages", app.requireActivatedUser(app.reorderPagesHandler))
+			router.HandlerFunc(http.MethodPatch, "/v1/boards/:slugId/pages/:pageId", app.requireActivatedUser(app.renamePageHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/pages/:pageId", app.requireActivatedUser(app.deletePageHandler))
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/pages/:pageId/duplicate", app.requireActivatedUser(app.duplicatePageHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
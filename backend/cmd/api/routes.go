@@ -2,6 +2,7 @@ package main
 
 import (
 	"expvar"
+	"github.com/umtdemr/wb-backend/internal/data"
 	"net/http"
 )
 
@@ -17,10 +18,63 @@ func (app *application) registerRoutes() {
 
 	router.HandlerFunc(http.MethodPost, "/v1/tokens/authentication", app.createAuthenticationTokenHandler)
 
-	router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId", app.requireActivatedUser(app.getBoardBySlugIdHandler))
+	// GET /v1/boards/trash shares the route with the slug id, see boardCollectionGetHandler
+	router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId", app.boardCollectionGetHandler)
+	router.HandlerFunc(http.MethodPatch, "/v1/boards/:slugId", app.requireActivatedUser(app.renameBoardHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId", app.requireActivatedUser(app.deleteBoardHandler))
 	router.HandlerFunc(http.MethodPost, "/v1/boards", app.requireActivatedUser(app.createBoardHandler))
 	router.HandlerFunc(http.MethodGet, "/v1/boards", app.requireActivatedUser(app.getAllBoardsHandler))
-	router.HandlerFunc(http.MethodPost, "/v1/boards/invite", app.requireActivatedUser(app.inviteUserToBoardHandler))
+	// POST /v1/boards/invite and POST /v1/boards/import share the route with the slug id, see boardCollectionActionHandler
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId", app.requireActivatedUser(app.boardCollectionActionHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/history", app.requireActivatedUser(app.getBoardHistoryHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/restore", app.requireActivatedUser(app.restoreBoardHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/untrash", app.requireActivatedUser(app.restoreDeletedBoardHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/duplicate", app.requireActivatedUser(app.duplicateBoardHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/pages", app.requireActivatedUser(app.createPageHandler))
+	router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/pages", app.requireActivatedUser(app.reorderPagesHandler))
+	router.HandlerFunc(http.MethodPatch, "/v1/boards/:slugId/pages/:pageId", app.requireActivatedUser(app.renamePageHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/pages/:pageId", app.requireActivatedUser(app.deletePageHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/pages/:pageId/duplicate", app.requireActivatedUser(app.duplicatePageHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/export", app.requireActivatedUser(app.exportBoardHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/exports", app.requireActivatedUser(app.createBoardExportHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/thumbnail.png", app.requireActivatedUser(app.getBoardThumbnailHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/pages/:pageId/export.svg", app.requireActivatedUser(app.exportPageSVGHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/pages/:pageId/exports", app.requireActivatedUser(app.createPageExportHandler))
+	router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/users/:id", app.requireActivatedUser(app.updateBoardMemberRoleHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/users/:id", app.requireActivatedUser(app.removeBoardMemberHandler))
+	router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/owner", app.requireActivatedUser(app.transferBoardOwnershipHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/share-links", app.requireActivatedUser(app.createShareLinkHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/share-links", app.requireActivatedUser(app.getShareLinksHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/share-links/:id", app.requireActivatedUser(app.revokeShareLinkHandler))
+	router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/star", app.requireActivatedUser(app.starBoardHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/star", app.requireActivatedUser(app.unstarBoardHandler))
+	router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/template", app.requireActivatedUser(app.markBoardAsTemplateHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/template", app.requireActivatedUser(app.unmarkBoardAsTemplateHandler))
+	router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/folder", app.requireActivatedUser(app.moveBoardToFolderHandler))
+
+	router.HandlerFunc(http.MethodGet, "/v1/templates", app.requireActivatedUser(app.getTemplatesHandler))
+
+	router.HandlerFunc(http.MethodPut, "/v1/invitations/accepted", app.requireActivatedUser(app.acceptInvitationHandler))
+
+	router.HandlerFunc(http.MethodGet, "/v1/exports/:id", app.requireActivatedUser(app.getExportHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/exports/:id/download", app.requireActivatedUser(app.downloadExportHandler))
+
+	router.HandlerFunc(http.MethodGet, "/v1/folders", app.requireActivatedUser(app.getFoldersHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/folders", app.requireActivatedUser(app.createFolderHandler))
+	router.HandlerFunc(http.MethodPatch, "/v1/folders/:id", app.requireActivatedUser(app.renameFolderHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/folders/:id", app.requireActivatedUser(app.deleteFolderHandler))
+
+	router.HandlerFunc(http.MethodGet, "/v1/workspaces", app.requireActivatedUser(app.getWorkspacesHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/workspaces", app.requireActivatedUser(app.createWorkspaceHandler))
+	router.HandlerFunc(http.MethodPatch, "/v1/workspaces/:workspaceId", app.requirePermission(data.PermissionWorkspaceManage, app.renameWorkspaceHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/workspaces/:workspaceId", app.requirePermission(data.PermissionWorkspaceManage, app.deleteWorkspaceHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/workspaces/:workspaceId/members", app.requirePermission(data.PermissionWorkspaceRead, app.getWorkspaceMembersHandler))
+	router.HandlerFunc(http.MethodPost, "/v1/workspaces/:workspaceId/members", app.requirePermission(data.PermissionWorkspaceManage, app.addWorkspaceMemberHandler))
+	router.HandlerFunc(http.MethodPut, "/v1/workspaces/:workspaceId/members/:id", app.requirePermission(data.PermissionWorkspaceManage, app.updateWorkspaceMemberRoleHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/workspaces/:workspaceId/members/:id", app.requirePermission(data.PermissionWorkspaceManage, app.removeWorkspaceMemberHandler))
+	router.HandlerFunc(http.MethodGet, "/v1/workspaces/:workspaceId/boards", app.requirePermission(data.PermissionWorkspaceRead, app.getWorkspaceBoardsHandler))
+	router.HandlerFunc(http.MethodPut, "/v1/workspaces/:workspaceId/boards/:slugId", app.requirePermission(data.PermissionWorkspaceWrite, app.addBoardToWorkspaceHandler))
+	router.HandlerFunc(http.MethodDelete, "/v1/workspaces/:workspaceId/boards/:slugId", app.requirePermission(data.PermissionWorkspaceWrite, app.removeBoardFromWorkspaceHandler))
 	router.HandlerFunc(http.MethodGet, "/ws", app.websocketHandler)
 
 	router.Handler(http.MethodGet, "/debug/vars", expvar.Handler())
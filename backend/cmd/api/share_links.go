@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"net/http"
+	"time"
+)
+
+// createShareLinkHandler handles creating a share link for the board. The token of the link is only
+// returned in this response.
+func (app *application) createShareLinkHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Role   string     `json:"role"`
+		Expiry *time.Time `json:"expiry"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	// links give view access unless another role is given
+	if input.Role == "" {
+		input.Role = data.BoardRoleViewer
+	}
+
+	v := validator.New()
+	data.ValidateShareLink(v, &data.ShareLink{Role: input.Role, Expiry: input.Expiry})
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	link, err := app.models.ShareLinks.New(board.Id, input.Role, input.Expiry, int64(user.ID))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"share_link": link}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// getShareLinksHandler handles listing the share links of the board
+func (app *application) getShareLinksHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	links, err := app.models.ShareLinks.GetAll(board.Id)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"share_links": links}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// revokeShareLinkHandler handles revoking a share link of the board
+func (app *application) revokeShareLinkHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	linkId, err := app.readIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.models.ShareLinks.Revoke(board.Id, linkId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	// people who joined with the link are disconnected
+	app.wsHub.RevokeShareLinkAccess(board.SlugId, linkId)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "share link is revoked"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/ws"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestShareLinkHandlers tests creating, listing and revoking the share links of a board
+func TestShareLinkHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	shareLinkModel := mockdata.NewMockShareLinkModel(ctrl)
+
+	app.models = data.Models{
+		Boards:     boardModel,
+		ShareLinks: shareLinkModel,
+	}
+	app.wsHub = ws.NewHub(app.models, nil, nil)
+
+	ownedBoard := &data.BoardResult{Id: 3, OwnerId: 1, SlugId: "valid-12-ch-", IsOwner: true, Role: data.BoardRoleOwner}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "Not the owner of the board",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/share-links",
+			body:   `{}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(&data.BoardResult{Id: 3, OwnerId: 5, Role: data.BoardRoleEditor}, nil)
+			},
+		},
+		{
+			name:   "Invalid role and expiry",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/share-links",
+			body:   `{"role": "owner", "expiry": "2020-01-01T00:00:00Z"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "role")
+				require.Contains(t, recorder.Body.String(), "expiry")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+			},
+		},
+		{
+			name:   "Successful creation",
+			method: http.MethodPost,
+			url:    "/v1/boards/valid-12-ch-/share-links",
+			body:   `{}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"token":"share-token"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				shareLinkModel.EXPECT().
+					New(int64(3), data.BoardRoleViewer, nil, int64(1)).
+					Return(&data.ShareLink{Id: 4, BoardId: 3, Role: data.BoardRoleViewer, Token: "share-token"}, nil)
+			},
+		},
+		{
+			name:   "Unexpected error on listing",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/share-links",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				shareLinkModel.EXPECT().
+					GetAll(int64(3)).
+					Return(nil, errors.New("testing err"))
+			},
+		},
+		{
+			name:   "Successful listing",
+			method: http.MethodGet,
+			url:    "/v1/boards/valid-12-ch-/share-links",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"is_revoked":true`)
+			},
+			buildStub: func() {
+				expiry := time.Now().Add(time.Hour)
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				shareLinkModel.EXPECT().
+					GetAll(int64(3)).
+					Return([]data.ShareLink{
+						{Id: 4, BoardId: 3, Role: data.BoardRoleViewer, Expiry: &expiry},
+						{Id: 5, BoardId: 3, Role: data.BoardRoleEditor, IsRevoked: true},
+					}, nil)
+			},
+		},
+		{
+			name:   "Revoking a link of another board",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/share-links/7",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				shareLinkModel.EXPECT().
+					Revoke(int64(3), int64(7)).
+					Return(data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Successful revoke",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/share-links/4",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(gomock.Any(), gomock.Any()).
+					Return(ownedBoard, nil)
+				shareLinkModel.EXPECT().
+					Revoke(int64(3), int64(4)).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodPost, "/v1/boards/:slugId/share-links", app.requireActivatedUser(app.createShareLinkHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/boards/:slugId/share-links", app.requireActivatedUser(app.getShareLinksHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/share-links/:id", app.requireActivatedUser(app.revokeShareLinkHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"net/http"
+)
+
+// getTemplatesHandler handles listing the templates that the user can create boards from
+func (app *application) getTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	templates, err := app.models.Templates.GetAll(int64(user.ID))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"templates": templates}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// markBoardAsTemplateHandler handles making the board a template. Only the owner can mark the board.
+func (app *application) markBoardAsTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Scope string `json:"scope"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	v := validator.New()
+	if data.ValidateTemplateScope(v, input.Scope, board); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	template, err := app.models.Templates.Mark(board.Id, input.Scope)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"template": template}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// unmarkBoardAsTemplateHandler handles stopping the board from being a template. Only the owner can unmark the board.
+func (app *application) unmarkBoardAsTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.models.Templates.Unmark(board.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "board is no longer a template"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	mockworker "github.com/umtdemr/wb-backend/internal/worker/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestTemplateHandlers tests listing the templates, marking the boards as templates and creating boards from them
+func TestTemplateHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	templateModel := mockdata.NewMockTemplateModel(ctrl)
+	publisher := mockworker.NewMockPublisher(ctrl)
+
+	app.models = data.Models{
+		Boards:    boardModel,
+		Templates: templateModel,
+	}
+	app.jobPublisher = publisher
+
+	ownedBoard := &data.BoardResult{Id: 3, OwnerId: 1, SlugId: "valid-12-ch-", IsOwner: true, Role: data.BoardRoleOwner}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "List templates",
+			method: http.MethodGet,
+			url:    "/v1/templates",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"scope":"system"`)
+			},
+			buildStub: func() {
+				templateModel.EXPECT().
+					GetAll(int64(1)).
+					Return([]data.Template{{Id: 1, Name: "Kanban", Scope: data.TemplateScopeSystem}}, nil)
+			},
+		},
+		{
+			name:   "Editor cannot mark the board",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/template",
+			body:   `{"scope": "personal"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(&data.BoardResult{Id: 3, OwnerId: 5, Role: data.BoardRoleEditor}, nil)
+			},
+		},
+		{
+			name:   "System scope cannot be given",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/template",
+			body:   `{"scope": "system"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "scope")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(ownedBoard, nil)
+			},
+		},
+		{
+			name:   "Successful mark",
+			method: http.MethodPut,
+			url:    "/v1/boards/valid-12-ch-/template",
+			body:   `{"scope": "personal"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"scope":"personal"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(ownedBoard, nil)
+				templateModel.EXPECT().
+					Mark(int64(3), data.TemplateScopePersonal).
+					Return(&data.Template{Id: 3, Scope: data.TemplateScopePersonal}, nil)
+			},
+		},
+		{
+			name:   "Successful unmark",
+			method: http.MethodDelete,
+			url:    "/v1/boards/valid-12-ch-/template",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(ownedBoard, nil)
+				templateModel.EXPECT().
+					Unmark(int64(3)).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Create from unknown template",
+			method: http.MethodPost,
+			url:    "/v1/boards",
+			body:   `{"template_id": 42}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "template_id")
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					CreateBoard(gomock.Any()).
+					Return(nil, data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Create from template",
+			method: http.MethodPost,
+			url:    "/v1/boards",
+			body:   `{"template_id": 1}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"name":"Kanban"`)
+			},
+			buildStub: func() {
+				boardModel.EXPECT().
+					CreateBoard(gomock.Any()).
+					DoAndReturn(func(board *data.Board) (*data.Board, error) {
+						require.Equal(t, int64(1), board.TemplateId)
+						require.Equal(t, int64(1), board.OwnerId)
+						require.Empty(t, board.Name)
+						return &data.Board{Id: 9, Name: "Kanban", SlugId: "new-12-ch-sl", OwnerId: 1}, nil
+					})
+				// the board has the elements of the template, so its thumbnail is rendered right away
+				publisher.EXPECT().
+					EnqueueJob(gomock.Any(), worker.Job{
+						Type: worker.JobTypeThumbnail,
+						Data: worker.ThumbnailJob{BoardSlugId: "new-12-ch-sl"},
+					}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodPost, "/v1/boards", app.requireActivatedUser(app.createBoardHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/templates", app.requireActivatedUser(app.getTemplatesHandler))
+			router.HandlerFunc(http.MethodPut, "/v1/boards/:slugId/template", app.requireActivatedUser(app.markBoardAsTemplateHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/boards/:slugId/template", app.requireActivatedUser(app.unmarkBoardAsTemplateHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -62,6 +62,11 @@ func (app *application) createAuthenticationTokenHandler(w http.ResponseWriter,
 		return
 	}
 
+	// the boards that the email is invited to after activating the account are joined on login
+	if _, err := app.models.Invitations.AcceptAll(user); err != nil {
+		app.logError(r, err)
+	}
+
 	err = app.writeJSON(w, http.StatusCreated, envelope{"authentication_token": createdToken}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
@@ -22,10 +22,12 @@ func TestCreateAuthenticationTokenHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userModel := mockdata.NewMockUserModel(ctrl)
 	tokenModel := mockdata.NewMockTokenModel(ctrl)
+	invitationModel := mockdata.NewMockInvitationModel(ctrl)
 
 	app.models = data.Models{
-		User:   userModel,
-		Tokens: tokenModel,
+		User:        userModel,
+		Tokens:      tokenModel,
+		Invitations: invitationModel,
 	}
 
 	type tokenInput struct {
@@ -143,6 +145,36 @@ func TestCreateAuthenticationTokenHandler(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "Unexpected error on accepting invitations",
+			body: tokenInput{
+				Email:    "test@test.com",
+				Password: "password",
+			},
+			buildStub: func() {
+				user := &data.User{
+					ID:         2,
+					Email:      "test@test.com",
+					IsVerified: true,
+				}
+				user.Password.Set("password")
+				userModel.EXPECT().
+					GetByEmail(gomock.Eq("test@test.com")).
+					Return(user, nil)
+
+				tokenModel.EXPECT().
+					New(int64(2), 24*time.Hour, token.ScopeAuthentication).
+					Return(&data.Token{Plaintext: "generated-token"}, nil)
+
+				// login should not fail because of the invitations
+				invitationModel.EXPECT().
+					AcceptAll(gomock.Any()).
+					Return(nil, errors.New("test error"))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
 		{
 			name: "Successful auth",
 			body: tokenInput{
@@ -168,6 +200,10 @@ func TestCreateAuthenticationTokenHandler(t *testing.T) {
 						},
 						nil,
 					)
+
+				invitationModel.EXPECT().
+					AcceptAll(user).
+					Return([]int64{3}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusCreated, recorder.Code)
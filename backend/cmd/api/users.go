@@ -129,6 +129,11 @@ func (app *application) activateUserHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// the boards that the email is invited to are joined once the user proves that they own the email
+	if _, err := app.models.Invitations.AcceptAll(result.User); err != nil {
+		app.logError(r, err)
+	}
+
 	err = app.writeJSON(w, http.StatusOK, envelope{"user": result.User}, nil)
 	if err != nil {
 		app.serverErrorResponse(w, r, err)
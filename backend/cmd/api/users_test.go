@@ -198,12 +198,16 @@ func TestActivateUserHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userModel := mockdata.NewMockUserModel(ctrl)
 	tokenModel := mockdata.NewMockTokenModel(ctrl)
+	invitationModel := mockdata.NewMockInvitationModel(ctrl)
 
 	app.models = data.Models{
-		User:   userModel,
-		Tokens: tokenModel,
+		User:        userModel,
+		Tokens:      tokenModel,
+		Invitations: invitationModel,
 	}
 
+	activatedUser := &data.User{ID: 2, Email: "test@test.com", IsVerified: true}
+
 	type activateUserInput struct {
 		Token string `json:"token"`
 	}
@@ -325,6 +329,26 @@ func TestActivateUserHandler(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "Unexpected error on accepting invitations",
+			body: activateUserInput{Token: strings.Repeat("0", 26)},
+			buildStub: func() {
+				userModel.EXPECT().
+					ActivateUser(gomock.Eq(strings.Repeat("0", 26))).
+					Return(
+						&data.ActivateUserResult{User: activatedUser},
+						nil,
+					)
+
+				// activation should not fail because of the invitations
+				invitationModel.EXPECT().
+					AcceptAll(activatedUser).
+					Return(nil, errors.New("testing"))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name: "Successful request",
 			body: activateUserInput{Token: strings.Repeat("0", 26)},
@@ -332,9 +356,13 @@ func TestActivateUserHandler(t *testing.T) {
 				userModel.EXPECT().
 					ActivateUser(gomock.Eq(strings.Repeat("0", 26))).
 					Return(
-						&data.ActivateUserResult{},
+						&data.ActivateUserResult{User: activatedUser},
 						nil,
 					)
+
+				invitationModel.EXPECT().
+					AcceptAll(activatedUser).
+					Return([]int64{3}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
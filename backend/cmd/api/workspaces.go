@@ -0,0 +1,387 @@
+package main
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"net/http"
+	"strings"
+)
+
+// getWorkspacesHandler handles listing the workspaces that the user is a member of
+func (app *application) getWorkspacesHandler(w http.ResponseWriter, r *http.Request) {
+	user := app.contextGetUser(r)
+
+	workspaces, err := app.models.Workspaces.GetAllForUser(int64(user.ID))
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"workspaces": workspaces}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// createWorkspaceHandler handles creating a workspace, the user becomes the admin of it
+func (app *application) createWorkspaceHandler(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name string `json:"name"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	workspace := &data.Workspace{Name: strings.TrimSpace(input.Name)}
+
+	v := validator.New()
+	if data.ValidateWorkspace(v, workspace); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user := app.contextGetUser(r)
+
+	workspace, err = app.models.Workspaces.New(int64(user.ID), workspace.Name)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"workspace": workspace}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// renameWorkspaceHandler handles renaming the workspace. Only admins can rename it.
+func (app *application) renameWorkspaceHandler(w http.ResponseWriter, r *http.Request) {
+	// the id is validated by requirePermission
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	var input struct {
+		Name string `json:"name"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	workspace := &data.Workspace{Name: strings.TrimSpace(input.Name)}
+
+	v := validator.New()
+	if data.ValidateWorkspace(v, workspace); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	workspace, err = app.models.Workspaces.Rename(workspaceId, workspace.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+	workspace.Role = data.WorkspaceRoleAdmin
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"workspace": workspace}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// deleteWorkspaceHandler handles deleting the workspace. Only admins can delete it, the boards are kept by their owners.
+func (app *application) deleteWorkspaceHandler(w http.ResponseWriter, r *http.Request) {
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	err := app.models.Workspaces.Delete(workspaceId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "workspace is deleted"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// getWorkspaceMembersHandler handles listing the members of the workspace
+func (app *application) getWorkspaceMembersHandler(w http.ResponseWriter, r *http.Request) {
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	members, err := app.models.Workspaces.GetMembers(workspaceId)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"members": members}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// addWorkspaceMemberHandler handles adding a registered user to the workspace by their email
+func (app *application) addWorkspaceMemberHandler(w http.ResponseWriter, r *http.Request) {
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	var input struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+
+	err := app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if input.Role == "" {
+		input.Role = data.WorkspaceRoleViewer
+	}
+
+	v := validator.New()
+	data.ValidateEmail(v, input.Email)
+	data.ValidateWorkspaceRole(v, input.Role)
+
+	if !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	user, err := app.models.User.GetByEmail(input.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			v.AddError("email", "user not found")
+			app.fieldValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.models.Workspaces.AddMember(workspaceId, int64(user.ID), input.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrAlreadyWorkspaceMember):
+			v.AddError("email", "user is already a member of the workspace")
+			app.fieldValidationResponse(w, r, v.Errors)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	member := data.WorkspaceMember{
+		Id:       int64(user.ID),
+		FullName: user.FullName,
+		Email:    user.Email,
+		Role:     input.Role,
+	}
+
+	err = app.writeJSON(w, http.StatusCreated, envelope{"member": member}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// updateWorkspaceMemberRoleHandler handles changing the role of a workspace member
+func (app *application) updateWorkspaceMemberRoleHandler(w http.ResponseWriter, r *http.Request) {
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	memberId, err := app.readIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	var input struct {
+		Role string `json:"role"`
+	}
+
+	err = app.readJSON(w, r, &input)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	v := validator.New()
+	if data.ValidateWorkspaceRole(v, input.Role); !v.Valid() {
+		app.fieldValidationResponse(w, r, v.Errors)
+		return
+	}
+
+	err = app.models.Workspaces.UpdateMemberRole(workspaceId, memberId, input.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrLastWorkspaceAdmin):
+			app.errorResponse(w, r, http.StatusConflict, "the workspace must have at least one admin")
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.syncWorkspaceBoardAccess(r, workspaceId, memberId)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"member": envelope{"id": memberId, "role": input.Role}}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// removeWorkspaceMemberHandler handles removing a member from the workspace
+func (app *application) removeWorkspaceMemberHandler(w http.ResponseWriter, r *http.Request) {
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	memberId, err := app.readIdParam(r)
+	if err != nil {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	err = app.models.Workspaces.RemoveMember(workspaceId, memberId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		case errors.Is(err, data.ErrLastWorkspaceAdmin):
+			app.errorResponse(w, r, http.StatusConflict, "the workspace must have at least one admin")
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	app.syncWorkspaceBoardAccess(r, workspaceId, memberId)
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"message": "member is removed"}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// syncWorkspaceBoardAccess applies the new workspace role of the member to their connections in the boards of
+// the workspace. The member can still be a member of a board by itself, so their role is read again for every board.
+func (app *application) syncWorkspaceBoardAccess(r *http.Request, workspaceId int64, memberId int64) {
+	boards, err := app.models.Workspaces.GetBoards(workspaceId)
+	if err != nil {
+		app.logError(r, err)
+		return
+	}
+
+	for _, board := range boards {
+		memberBoard, err := app.models.Boards.GetBoard(memberId, board.SlugId)
+		switch {
+		case err == nil:
+			app.wsHub.ChangeUserRole(board.SlugId, int32(memberId), memberBoard.Role)
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.wsHub.RevokeUserAccess(board.SlugId, int32(memberId))
+		default:
+			app.logError(r, err)
+		}
+	}
+}
+
+// getWorkspaceBoardsHandler handles listing the boards in the workspace
+func (app *application) getWorkspaceBoardsHandler(w http.ResponseWriter, r *http.Request) {
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	boards, err := app.models.Workspaces.GetBoards(workspaceId)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"boards": boards}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// addBoardToWorkspaceHandler handles moving the board into the workspace.
+// Only the owner of the board can move it and they must be able to write in the workspace.
+func (app *application) addBoardToWorkspaceHandler(w http.ResponseWriter, r *http.Request) {
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	movedBoard, err := app.models.Boards.MoveToWorkspace(board.Id, &workspaceId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"board": movedBoard}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
+
+// removeBoardFromWorkspaceHandler handles taking the board out of the workspace. Only the owner of the board can do it.
+func (app *application) removeBoardFromWorkspaceHandler(w http.ResponseWriter, r *http.Request) {
+	workspaceId, _ := app.readWorkspaceIdParam(r)
+
+	board, ok := app.readOwnedBoard(w, r)
+	if !ok {
+		return
+	}
+
+	if board.WorkspaceId == nil || *board.WorkspaceId != workspaceId {
+		app.notFoundResponse(w, r)
+		return
+	}
+
+	movedBoard, err := app.models.Boards.MoveToWorkspace(board.Id, nil)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			app.notFoundResponse(w, r)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	err = app.writeJSON(w, http.StatusOK, envelope{"board": movedBoard}, nil)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/ws"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestWorkspaceHandlers tests managing the workspaces, their members and their boards
+func TestWorkspaceHandlers(t *testing.T) {
+	app := createTestApp()
+
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	userModel := mockdata.NewMockUserModel(ctrl)
+	permissionModel := mockdata.NewMockPermissionModel(ctrl)
+	workspaceModel := mockdata.NewMockWorkspaceModel(ctrl)
+
+	app.models = data.Models{
+		Boards:      boardModel,
+		User:        userModel,
+		Permissions: permissionModel,
+		Workspaces:  workspaceModel,
+	}
+	app.wsHub = ws.NewHub(app.models, nil, nil)
+
+	// memberOf stubs the permission lookup of requirePermission for the workspace 3
+	memberOf := func(role string) {
+		permissionModel.EXPECT().
+			GetAllForUser(int32(1)).
+			Return(data.Permissions{}, nil)
+		workspaceModel.EXPECT().
+			GetMemberRole(int64(3), int64(1)).
+			Return(role, nil)
+	}
+
+	workspaceId := int64(3)
+	ownedBoard := &data.BoardResult{Id: 7, OwnerId: 1, SlugId: "valid-12-ch-", Role: data.BoardRoleEditor, IsOwner: true}
+	sharedBoard := &data.BoardResult{Id: 7, OwnerId: 5, SlugId: "valid-12-ch-", Role: data.BoardRoleEditor}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		buildStub     func()
+	}{
+		{
+			name:   "List workspaces",
+			method: http.MethodGet,
+			url:    "/v1/workspaces",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"role":"admin"`)
+			},
+			buildStub: func() {
+				workspaceModel.EXPECT().
+					GetAllForUser(int64(1)).
+					Return([]data.Workspace{{Id: 3, Name: "team", Role: data.WorkspaceRoleAdmin}}, nil)
+			},
+		},
+		{
+			name:   "Invalid workspace name",
+			method: http.MethodPost,
+			url:    "/v1/workspaces",
+			body:   `{"name": ""}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "name")
+			},
+			buildStub: func() {},
+		},
+		{
+			name:   "Successful workspace creation",
+			method: http.MethodPost,
+			url:    "/v1/workspaces",
+			body:   `{"name": " team "}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"id":3`)
+			},
+			buildStub: func() {
+				workspaceModel.EXPECT().
+					New(int64(1), "team").
+					Return(&data.Workspace{Id: 3, Name: "team", Role: data.WorkspaceRoleAdmin}, nil)
+			},
+		},
+		{
+			name:   "Editor cannot rename the workspace",
+			method: http.MethodPatch,
+			url:    "/v1/workspaces/3",
+			body:   `{"name": "new"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleEditor)
+			},
+		},
+		{
+			name:   "Admin renames the workspace",
+			method: http.MethodPatch,
+			url:    "/v1/workspaces/3",
+			body:   `{"name": "new"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"name":"new"`)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleAdmin)
+				workspaceModel.EXPECT().
+					Rename(int64(3), "new").
+					Return(&data.Workspace{Id: 3, Name: "new"}, nil)
+			},
+		},
+		{
+			name:   "Non member cannot see the workspace",
+			method: http.MethodDelete,
+			url:    "/v1/workspaces/3",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				permissionModel.EXPECT().
+					GetAllForUser(int32(1)).
+					Return(data.Permissions{}, nil)
+				workspaceModel.EXPECT().
+					GetMemberRole(int64(3), int64(1)).
+					Return("", data.ErrRecordNotFound)
+			},
+		},
+		{
+			name:   "Viewer lists the members",
+			method: http.MethodGet,
+			url:    "/v1/workspaces/3/members",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"email":"admin@test.com"`)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleViewer)
+				workspaceModel.EXPECT().
+					GetMembers(int64(3)).
+					Return([]data.WorkspaceMember{{Id: 5, Email: "admin@test.com", Role: data.WorkspaceRoleAdmin}}, nil)
+			},
+		},
+		{
+			name:   "Add member with invalid role",
+			method: http.MethodPost,
+			url:    "/v1/workspaces/3/members",
+			body:   `{"email": "member@test.com", "role": "owner"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "role")
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleAdmin)
+			},
+		},
+		{
+			name:   "Add already member",
+			method: http.MethodPost,
+			url:    "/v1/workspaces/3/members",
+			body:   `{"email": "member@test.com"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "already a member")
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleAdmin)
+				userModel.EXPECT().
+					GetByEmail("member@test.com").
+					Return(&data.User{ID: 8, Email: "member@test.com"}, nil)
+				workspaceModel.EXPECT().
+					AddMember(int64(3), int64(8), data.WorkspaceRoleViewer).
+					Return(data.ErrAlreadyWorkspaceMember)
+			},
+		},
+		{
+			name:   "Successful member addition",
+			method: http.MethodPost,
+			url:    "/v1/workspaces/3/members",
+			body:   `{"email": "member@test.com", "role": "editor"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"role":"editor"`)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleAdmin)
+				userModel.EXPECT().
+					GetByEmail("member@test.com").
+					Return(&data.User{ID: 8, Email: "member@test.com"}, nil)
+				workspaceModel.EXPECT().
+					AddMember(int64(3), int64(8), data.WorkspaceRoleEditor).
+					Return(nil)
+			},
+		},
+		{
+			name:   "Demote last admin",
+			method: http.MethodPut,
+			url:    "/v1/workspaces/3/members/1",
+			body:   `{"role": "viewer"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleAdmin)
+				workspaceModel.EXPECT().
+					UpdateMemberRole(int64(3), int64(1), data.WorkspaceRoleViewer).
+					Return(data.ErrLastWorkspaceAdmin)
+			},
+		},
+		{
+			name:   "Remove member",
+			method: http.MethodDelete,
+			url:    "/v1/workspaces/3/members/8",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleAdmin)
+				workspaceModel.EXPECT().
+					RemoveMember(int64(3), int64(8)).
+					Return(nil)
+				// the member keeps the board that they are a member of by themselves
+				workspaceModel.EXPECT().
+					GetBoards(int64(3)).
+					Return([]*data.BoardResult{ownedBoard, {Id: 9, SlugId: "other-12-ch-"}}, nil)
+				boardModel.EXPECT().
+					GetBoard(int64(8), "valid-12-ch-").
+					Return(nil, data.ErrRecordNotFound)
+				boardModel.EXPECT().
+					GetBoard(int64(8), "other-12-ch-").
+					Return(&data.BoardResult{Id: 9, SlugId: "other-12-ch-", Role: data.BoardRoleViewer}, nil)
+			},
+		},
+		{
+			name:   "Change member role",
+			method: http.MethodPut,
+			url:    "/v1/workspaces/3/members/8",
+			body:   `{"role": "viewer"}`,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"role":"viewer"`)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleAdmin)
+				workspaceModel.EXPECT().
+					UpdateMemberRole(int64(3), int64(8), data.WorkspaceRoleViewer).
+					Return(nil)
+				workspaceModel.EXPECT().
+					GetBoards(int64(3)).
+					Return([]*data.BoardResult{ownedBoard}, nil)
+				boardModel.EXPECT().
+					GetBoard(int64(8), "valid-12-ch-").
+					Return(&data.BoardResult{Id: 7, SlugId: "valid-12-ch-", Role: data.BoardRoleViewer}, nil)
+			},
+		},
+		{
+			name:   "List workspace boards",
+			method: http.MethodGet,
+			url:    "/v1/workspaces/3/boards",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"workspace_id":3`)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleViewer)
+				workspaceModel.EXPECT().
+					GetBoards(int64(3)).
+					Return([]*data.BoardResult{{Id: 7, WorkspaceId: &workspaceId}}, nil)
+			},
+		},
+		{
+			name:   "Viewer cannot add a board",
+			method: http.MethodPut,
+			url:    "/v1/workspaces/3/boards/valid-12-ch-",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleViewer)
+			},
+		},
+		{
+			name:   "Add board of someone else",
+			method: http.MethodPut,
+			url:    "/v1/workspaces/3/boards/valid-12-ch-",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleEditor)
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(sharedBoard, nil)
+			},
+		},
+		{
+			name:   "Successful board addition",
+			method: http.MethodPut,
+			url:    "/v1/workspaces/3/boards/valid-12-ch-",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"workspace_id":3`)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleEditor)
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(ownedBoard, nil)
+				boardModel.EXPECT().
+					MoveToWorkspace(int64(7), gomock.Eq(&workspaceId)).
+					Return(&data.Board{Id: 7, WorkspaceId: &workspaceId}, nil)
+			},
+		},
+		{
+			name:   "Remove board that is not in the workspace",
+			method: http.MethodDelete,
+			url:    "/v1/workspaces/3/boards/valid-12-ch-",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+			buildStub: func() {
+				memberOf(data.WorkspaceRoleEditor)
+				boardModel.EXPECT().
+					GetBoard(int64(1), "valid-12-ch-").
+					Return(ownedBoard, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+			router := httprouter.New()
+			router.HandlerFunc(http.MethodGet, "/v1/workspaces", app.requireActivatedUser(app.getWorkspacesHandler))
+			router.HandlerFunc(http.MethodPost, "/v1/workspaces", app.requireActivatedUser(app.createWorkspaceHandler))
+			router.HandlerFunc(http.MethodPatch, "/v1/workspaces/:workspaceId", app.requirePermission(data.PermissionWorkspaceManage, app.renameWorkspaceHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/workspaces/:workspaceId", app.requirePermission(data.PermissionWorkspaceManage, app.deleteWorkspaceHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/workspaces/:workspaceId/members", app.requirePermission(data.PermissionWorkspaceRead, app.getWorkspaceMembersHandler))
+			router.HandlerFunc(http.MethodPost, "/v1/workspaces/:workspaceId/members", app.requirePermission(data.PermissionWorkspaceManage, app.addWorkspaceMemberHandler))
+			router.HandlerFunc(http.MethodPut, "/v1/workspaces/:workspaceId/members/:id", app.requirePermission(data.PermissionWorkspaceManage, app.updateWorkspaceMemberRoleHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/workspaces/:workspaceId/members/:id", app.requirePermission(data.PermissionWorkspaceManage, app.removeWorkspaceMemberHandler))
+			router.HandlerFunc(http.MethodGet, "/v1/workspaces/:workspaceId/boards", app.requirePermission(data.PermissionWorkspaceRead, app.getWorkspaceBoardsHandler))
+			router.HandlerFunc(http.MethodPut, "/v1/workspaces/:workspaceId/boards/:slugId", app.requirePermission(data.PermissionWorkspaceWrite, app.addBoardToWorkspaceHandler))
+			router.HandlerFunc(http.MethodDelete, "/v1/workspaces/:workspaceId/boards/:slugId", app.requirePermission(data.PermissionWorkspaceWrite, app.removeBoardFromWorkspaceHandler))
+
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			req = addUserToContext(t, app, req)
+
+			router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
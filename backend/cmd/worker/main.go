@@ -1,12 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/umtdemr/wb-backend/internal/config"
+	"github.com/umtdemr/wb-backend/internal/data"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
 	"github.com/umtdemr/wb-backend/internal/mailer"
+	"github.com/umtdemr/wb-backend/internal/render"
 	"github.com/umtdemr/wb-backend/internal/worker"
 	"os"
 	"os/signal"
@@ -14,8 +20,12 @@ import (
 	"time"
 )
 
+// purgeInterval is how often the boards in the trash are checked to be purged
+const purgeInterval = time.Hour
+
 type backgroundWorker struct {
 	mailer mailer.Mailer
+	models data.Models
 }
 
 func main() {
@@ -29,6 +39,12 @@ func main() {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
 
+	conn, err := pgxpool.New(context.Background(), conf.DBSource)
+	if err != nil {
+		log.Fatal().Msgf("could not establish db connection %s", err)
+	}
+	defer conn.Close()
+
 	nc, err := worker.SetupNats(conf.NatsServerUrl)
 	if err != nil {
 		log.Fatal().Msgf("failed to setup NATS: %v", err)
@@ -46,6 +62,7 @@ func main() {
 
 	bgWorker := &backgroundWorker{
 		mailer: mailer.New(conf.SmtpHost, conf.SmtpPort, conf.SmtpUsername, conf.SmtpPassword, "wb <no-reply@wb.net>"),
+		models: data.NewModels(db.NewStore(conn)),
 	}
 
 	go func() {
@@ -54,6 +71,8 @@ func main() {
 		}
 	}()
 
+	go bgWorker.purgeDeletedBoards(ctx)
+
 	log.Info().Msg("worker is running and waiting for jobs")
 
 	// graceful shutdown
@@ -72,6 +91,12 @@ func (w *backgroundWorker) handleJob(job worker.Job) error {
 	switch job.Type {
 	case worker.JobTypeEmail:
 		return w.sendEmail(job.Data)
+	case worker.JobTypeSnapshot:
+		return w.createSnapshot(job.Data)
+	case worker.JobTypeExport:
+		return w.renderExport(job.Data)
+	case worker.JobTypeThumbnail:
+		return w.renderThumbnail(job.Data)
 	default:
 		log.Info().Msgf("unknown job type: %s", job.Type)
 	}
@@ -93,3 +118,248 @@ func (w *backgroundWorker) sendEmail(data interface{}) error {
 
 	return w.mailer.Send(to, tmplFile, tmplData)
 }
+
+// createSnapshot handles board snapshot job
+func (w *backgroundWorker) createSnapshot(jobData interface{}) error {
+	dataMap, ok := jobData.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid snapshot job data: %v", jobData)
+	}
+	boardSlugId, _ := dataMap["board_slug_id"].(string)
+
+	snapshots, err := w.models.Snapshots.Create(boardSlugId)
+	if err != nil {
+		// board might be deleted after the job is enqueued, there is nothing to retry
+		if errors.Is(err, data.ErrRecordNotFound) {
+			log.Info().Msgf("board %s not found for snapshot", boardSlugId)
+			return nil
+		}
+		return err
+	}
+
+	log.Info().Msgf("created %d page snapshots for board %s", len(snapshots), boardSlugId)
+
+	return nil
+}
+
+// errExportNotRendered is returned when an export cannot be rendered, such as when its page is deleted
+var errExportNotRendered = errors.New("export cannot be rendered")
+
+// renderExport handles rendering a board export. The exports that cannot be rendered are marked as failed
+// instead of being retried. The exports delivered by email are sent once they are rendered, a retried job only
+// sends the email again if it could not be sent before.
+func (w *backgroundWorker) renderExport(jobData interface{}) error {
+	dataMap, ok := jobData.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid export job data: %v", jobData)
+	}
+	// json numbers are decoded as float64
+	exportId, _ := dataMap["export_id"].(float64)
+	boardName, _ := dataMap["board_name"].(string)
+
+	export, err := w.models.Exports.Get(int64(exportId))
+	if err != nil {
+		// export might be purged after the job is enqueued, there is nothing to retry
+		if errors.Is(err, data.ErrRecordNotFound) {
+			log.Info().Msgf("export %d not found", int64(exportId))
+			return nil
+		}
+		return err
+	}
+
+	if export.Status == data.ExportStatusPending {
+		var content []byte
+		switch export.Format {
+		case data.ExportFormatPDF:
+			content, err = w.renderPdfExport(export, boardName)
+		default:
+			content, err = w.renderPngExport(export)
+		}
+		if err != nil {
+			if errors.Is(err, errExportNotRendered) {
+				return w.failExport(export.Id)
+			}
+			return err
+		}
+
+		err = w.models.Exports.Complete(export.Id, content)
+		if err != nil {
+			if errors.Is(err, data.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		log.Info().Msgf("rendered export %d of board %d", export.Id, export.BoardId)
+
+		export.Status = data.ExportStatusCompleted
+		export.Content = content
+	}
+
+	if export.Status != data.ExportStatusCompleted || export.Delivery != data.ExportDeliveryEmail || export.EmailedAt != nil {
+		return nil
+	}
+
+	return w.emailExport(export, boardName)
+}
+
+// renderPngExport renders the page of the export as a PNG image
+func (w *backgroundWorker) renderPngExport(export *data.Export) ([]byte, error) {
+	page, err := w.models.Pages.Get(export.BoardId, *export.PageId)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			log.Info().Msgf("page %d of export %d not found", *export.PageId, export.Id)
+			return nil, errExportNotRendered
+		}
+		return nil, err
+	}
+
+	var region *render.Rect
+	if export.Region != nil {
+		r := render.Rect(*export.Region)
+		region = &r
+	}
+
+	var buf bytes.Buffer
+	err = render.PNG(&buf, page.Elements, export.Scale, region)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to render export %d", export.Id)
+		return nil, errExportNotRendered
+	}
+
+	return buf.Bytes(), nil
+}
+
+// renderPdfExport renders all the pages of the board as a PDF document, a PDF page for each board page
+func (w *backgroundWorker) renderPdfExport(export *data.Export, boardName string) ([]byte, error) {
+	pages, err := w.models.Pages.GetAll(export.BoardId)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = render.PDF(&buf, boardName, pages)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to render export %d", export.Id)
+		return nil, errExportNotRendered
+	}
+
+	return buf.Bytes(), nil
+}
+
+// emailExport sends the rendered file of the export to the user who requested it
+func (w *backgroundWorker) emailExport(export *data.Export, boardName string) error {
+	err := w.mailer.Send(export.Email, "board_export.tmpl", map[string]any{
+		"boardName": boardName,
+		"exportID":  export.Id,
+	}, mailer.Attachment{Filename: export.Filename(), Content: export.Content})
+	if err != nil {
+		return err
+	}
+
+	err = w.models.Exports.MarkEmailed(export.Id)
+	if err != nil && !errors.Is(err, data.ErrRecordNotFound) {
+		return err
+	}
+
+	log.Info().Msgf("sent export %d by email", export.Id)
+
+	return nil
+}
+
+// failExport marks the export as failed, the export might be already completed by a redelivered job
+func (w *backgroundWorker) failExport(exportId int64) error {
+	err := w.models.Exports.Fail(exportId)
+	if err != nil && !errors.Is(err, data.ErrRecordNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// renderThumbnail handles rendering the thumbnail of a board from its first page. The thumbnail is not rendered
+// again if the board has not changed since the last one.
+func (w *backgroundWorker) renderThumbnail(jobData interface{}) error {
+	dataMap, ok := jobData.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid thumbnail job data: %v", jobData)
+	}
+	boardSlugId, _ := dataMap["board_slug_id"].(string)
+
+	source, err := w.models.Thumbnails.GetSource(boardSlugId)
+	if err != nil {
+		// board might be deleted after the job is enqueued, there is nothing to retry
+		if errors.Is(err, data.ErrRecordNotFound) {
+			log.Info().Msgf("board %s not found for thumbnail", boardSlugId)
+			return nil
+		}
+		return err
+	}
+
+	if source.IsUpToDate() {
+		return nil
+	}
+
+	page, err := w.models.Pages.Get(source.BoardId, source.PageId)
+	if err != nil {
+		if errors.Is(err, data.ErrRecordNotFound) {
+			log.Info().Msgf("page %d of board %s not found for thumbnail", source.PageId, boardSlugId)
+			return nil
+		}
+		return err
+	}
+
+	var buf bytes.Buffer
+	err = render.Thumbnail(&buf, page.Elements, data.ThumbnailWidth, data.ThumbnailHeight)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to render thumbnail of board %s", boardSlugId)
+		return nil
+	}
+
+	err = w.models.Thumbnails.Save(&data.Thumbnail{
+		BoardId:  source.BoardId,
+		Revision: source.Revision,
+		Content:  buf.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msgf("rendered thumbnail of board %s at revision %d", boardSlugId, source.Revision)
+
+	return nil
+}
+
+// purgeDeletedBoards periodically deletes the boards that have been in the trash longer than data.BoardRetention
+// and the exports that are older than data.ExportRetention
+func (w *backgroundWorker) purgeDeletedBoards(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := w.models.Boards.PurgeDeleted(data.BoardRetention)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to purge deleted boards")
+				continue
+			}
+
+			if purged > 0 {
+				log.Info().Msgf("purged %d deleted boards", purged)
+			}
+
+			purged, err = w.models.Exports.Purge(data.ExportRetention)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to purge exports")
+				continue
+			}
+
+			if purged > 0 {
+				log.Info().Msgf("purged %d exports", purged)
+			}
+		}
+	}
+}
@@ -13,6 +13,7 @@ type Config struct {
 	SmtpUsername  string `mapstructure:"SMTP_USERNAME"`
 	SmtpPassword  string `mapstructure:"SMTP_PASSWORD"`
 	NatsServerUrl string `mapstructure:"NATS_SERVER_URL"`
+	ClientUrl     string `mapstructure:"CLIENT_URL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
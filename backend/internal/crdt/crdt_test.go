@@ -0,0 +1,101 @@
+package crdt
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func zIndex(value int32) *int32 {
+	return &value
+}
+
+// TestDocument_Apply tests that later operations win for every field separately
+func TestDocument_Apply(t *testing.T) {
+	doc := NewDocument()
+
+	require.True(t, doc.Apply(Operation{
+		ElementId: 1,
+		Timestamp: Timestamp{Counter: 1, Node: "a"},
+		Fields:    map[string]json.RawMessage{"x": json.RawMessage("10"), "y": json.RawMessage("20")},
+		ZIndex:    zIndex(0),
+	}))
+
+	// stale operation does not change the document
+	require.False(t, doc.Apply(Operation{
+		ElementId: 1,
+		Timestamp: Timestamp{Counter: 0, Node: "b"},
+		Fields:    map[string]json.RawMessage{"x": json.RawMessage("99")},
+	}))
+
+	// applying the same operation twice does not change the document
+	require.False(t, doc.Apply(Operation{
+		ElementId: 1,
+		Timestamp: Timestamp{Counter: 1, Node: "a"},
+		Fields:    map[string]json.RawMessage{"x": json.RawMessage("10")},
+	}))
+
+	require.True(t, doc.Apply(Operation{
+		ElementId: 1,
+		Timestamp: Timestamp{Counter: 2, Node: "b"},
+		Fields:    map[string]json.RawMessage{"y": json.RawMessage("30")},
+	}))
+
+	fields, ok := doc.Element(1)
+	require.True(t, ok)
+	require.JSONEq(t, "10", string(fields["x"]))
+	require.JSONEq(t, "30", string(fields["y"]))
+
+	require.True(t, doc.Apply(Operation{ElementId: 1, Timestamp: Timestamp{Counter: 3}, Deleted: true}))
+	_, ok = doc.Element(1)
+	require.False(t, ok)
+
+	// older moves cannot bring deleted elements back
+	require.False(t, doc.Apply(Operation{ElementId: 1, Timestamp: Timestamp{Counter: 2, Node: "c"}, ZIndex: zIndex(4)}))
+	require.Empty(t, doc.Order())
+}
+
+// TestDocument_Convergence tests that documents reach the same state whatever order the operations arrive in
+func TestDocument_Convergence(t *testing.T) {
+	operations := []Operation{
+		{ElementId: 1, Timestamp: Timestamp{Counter: 1, Node: "a"}, Fields: map[string]json.RawMessage{"x": json.RawMessage("1")}, ZIndex: zIndex(0)},
+		{ElementId: 2, Timestamp: Timestamp{Counter: 1, Node: "b"}, Fields: map[string]json.RawMessage{"x": json.RawMessage("2")}, ZIndex: zIndex(1)},
+		{ElementId: 1, Timestamp: Timestamp{Counter: 2, Node: "a"}, ZIndex: zIndex(2)},
+		{ElementId: 1, Timestamp: Timestamp{Counter: 2, Node: "b"}, Fields: map[string]json.RawMessage{"x": json.RawMessage("5")}},
+		{ElementId: 3, Timestamp: Timestamp{Counter: 3, Node: "a"}, ZIndex: zIndex(1)},
+		{ElementId: 2, Timestamp: Timestamp{Counter: 4, Node: "b"}, Deleted: true},
+	}
+
+	forward := NewDocument()
+	for _, operation := range operations {
+		forward.Apply(operation)
+	}
+
+	backward := NewDocument()
+	for i := len(operations) - 1; i >= 0; i-- {
+		backward.Apply(operations[i])
+	}
+
+	require.Equal(t, []int64{3, 1}, forward.Order())
+	require.Equal(t, forward.Order(), backward.Order())
+
+	forwardElement, _ := forward.Element(1)
+	backwardElement, _ := backward.Element(1)
+	require.Equal(t, forwardElement, backwardElement)
+	require.JSONEq(t, "5", string(forwardElement["x"]))
+
+	// merging partial replicas gives the same state as well
+	first, second := NewDocument(), NewDocument()
+	for i, operation := range operations {
+		if i%2 == 0 {
+			first.Apply(operation)
+		} else {
+			second.Apply(operation)
+		}
+	}
+	first.Merge(second)
+
+	require.Equal(t, forward.Order(), first.Order())
+	mergedElement, _ := first.Element(1)
+	require.Equal(t, forwardElement, mergedElement)
+}
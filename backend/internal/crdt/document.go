@@ -0,0 +1,117 @@
+package crdt
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Operation is a change to a single element of a document
+type Operation struct {
+	ElementId int64     `json:"element_id"`
+	Timestamp Timestamp `json:"timestamp"`
+	// Fields are the changed properties of the element, except the z order
+	Fields  map[string]json.RawMessage `json:"fields,omitempty"`
+	ZIndex  *int32                     `json:"z_index,omitempty"`
+	Deleted bool                       `json:"deleted,omitempty"`
+}
+
+// Document is the conflict-free replicated state of a board. Properties of the elements are kept in
+// last-writer-wins maps and the z order of the elements is kept in a Sequence, so every replica that
+// has applied the same operations has the same state, regardless of the order they are applied in.
+//
+// Documents are only kept in memory by the ws hub to order the element messages of the NATS fan-out. They are
+// not persisted: the database stays the source of truth, it serializes the element changes with their versions
+// and gives the revisions that the timestamps are built from. Offline edits are not merged, a change made on a
+// stale version is still rejected as an edit conflict.
+type Document struct {
+	mu       sync.Mutex
+	elements map[int64]*LWWMap
+	order    *Sequence
+}
+
+func NewDocument() *Document {
+	return &Document{
+		elements: make(map[int64]*LWWMap),
+		order:    NewSequence(),
+	}
+}
+
+// Apply applies the operation to the document. It reports whether the operation has changed the document,
+// operations that are already applied or overridden by later ones do not change it.
+func (d *Document) Apply(operation Operation) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	changed := false
+
+	fields, exists := d.elements[operation.ElementId]
+	if !exists {
+		fields = NewLWWMap()
+		d.elements[operation.ElementId] = fields
+	}
+
+	for key, value := range operation.Fields {
+		if fields.Set(key, value, operation.Timestamp) {
+			changed = true
+		}
+	}
+
+	switch {
+	case operation.Deleted:
+		changed = d.order.Remove(operation.ElementId, operation.Timestamp) || changed
+	case operation.ZIndex != nil:
+		changed = d.order.Move(operation.ElementId, *operation.ZIndex, operation.Timestamp) || changed
+	}
+
+	return changed
+}
+
+// Merge applies the whole state of other document
+func (d *Document) Merge(other *Document) {
+	// copy the other state first, so that both documents are never locked at the same time
+	other.mu.Lock()
+	otherElements := make(map[int64]*LWWMap, len(other.elements))
+	for id, fields := range other.elements {
+		copied := NewLWWMap()
+		copied.Merge(fields)
+		otherElements[id] = copied
+	}
+	otherOrder := NewSequence()
+	otherOrder.Merge(other.order)
+	other.mu.Unlock()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, otherFields := range otherElements {
+		fields, exists := d.elements[id]
+		if !exists {
+			fields = NewLWWMap()
+			d.elements[id] = fields
+		}
+		fields.Merge(otherFields)
+	}
+
+	d.order.Merge(otherOrder)
+}
+
+// Element returns the current properties of the element if it is not deleted
+func (d *Document) Element(id int64) (map[string]json.RawMessage, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fields, exists := d.elements[id]
+	if !exists || !d.order.Contains(id) {
+		return nil, false
+	}
+
+	return fields.Values(), true
+}
+
+// Order returns the ids of the elements that are not deleted, from back to front
+func (d *Document) Order() []int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.order.Ids()
+}
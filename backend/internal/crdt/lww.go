@@ -0,0 +1,53 @@
+package crdt
+
+import "encoding/json"
+
+// Register holds a value with the timestamp of its last write
+type Register struct {
+	Value     json.RawMessage `json:"value"`
+	Timestamp Timestamp       `json:"timestamp"`
+}
+
+// LWWMap is a last-writer-wins element map. Every key is an independent register,
+// so concurrent writes to different keys are all kept and the latest write wins for the same key.
+type LWWMap struct {
+	registers map[string]Register
+}
+
+func NewLWWMap() *LWWMap {
+	return &LWWMap{registers: make(map[string]Register)}
+}
+
+// Set writes the value if the timestamp is later than the last write of the key.
+// It reports whether the value has been written.
+func (m *LWWMap) Set(key string, value json.RawMessage, timestamp Timestamp) bool {
+	if current, exists := m.registers[key]; exists && !timestamp.After(current.Timestamp) {
+		return false
+	}
+
+	m.registers[key] = Register{Value: value, Timestamp: timestamp}
+	return true
+}
+
+// Get returns the current value of the key
+func (m *LWWMap) Get(key string) (json.RawMessage, bool) {
+	register, exists := m.registers[key]
+	return register.Value, exists
+}
+
+// Values returns the current values of all keys
+func (m *LWWMap) Values() map[string]json.RawMessage {
+	values := make(map[string]json.RawMessage, len(m.registers))
+	for key, register := range m.registers {
+		values[key] = register.Value
+	}
+
+	return values
+}
+
+// Merge applies all writes of other map
+func (m *LWWMap) Merge(other *LWWMap) {
+	for key, register := range other.registers {
+		m.Set(key, register.Value, register.Timestamp)
+	}
+}
@@ -0,0 +1,74 @@
+package crdt
+
+import "sort"
+
+type position struct {
+	value     int32
+	removed   bool
+	timestamp Timestamp
+}
+
+// Sequence is an ordered set of ids. The position of every id is a last-writer-wins register, and ids with
+// the same position are ordered by the id itself. So replicas end up with the same order whatever
+// order the moves arrive in.
+type Sequence struct {
+	positions map[int64]position
+}
+
+func NewSequence() *Sequence {
+	return &Sequence{positions: make(map[int64]position)}
+}
+
+// Move puts the id to the given position. It reports whether the move has been applied.
+func (s *Sequence) Move(id int64, value int32, timestamp Timestamp) bool {
+	return s.set(id, position{value: value, timestamp: timestamp})
+}
+
+// Remove removes the id from the sequence. It reports whether the removal has been applied.
+func (s *Sequence) Remove(id int64, timestamp Timestamp) bool {
+	// removed ids are kept as tombstones, so that older moves cannot bring them back
+	current := s.positions[id]
+	return s.set(id, position{value: current.value, removed: true, timestamp: timestamp})
+}
+
+// Contains reports whether the id is in the sequence and not removed
+func (s *Sequence) Contains(id int64) bool {
+	p, exists := s.positions[id]
+	return exists && !p.removed
+}
+
+// Ids returns the ids that are not removed in order
+func (s *Sequence) Ids() []int64 {
+	ids := make([]int64, 0, len(s.positions))
+	for id, p := range s.positions {
+		if !p.removed {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		pi, pj := s.positions[ids[i]], s.positions[ids[j]]
+		if pi.value != pj.value {
+			return pi.value < pj.value
+		}
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+// Merge applies all moves and removals of other sequence
+func (s *Sequence) Merge(other *Sequence) {
+	for id, p := range other.positions {
+		s.set(id, p)
+	}
+}
+
+func (s *Sequence) set(id int64, p position) bool {
+	if current, exists := s.positions[id]; exists && !p.timestamp.After(current.timestamp) {
+		return false
+	}
+
+	s.positions[id] = p
+	return true
+}
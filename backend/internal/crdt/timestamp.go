@@ -0,0 +1,17 @@
+package crdt
+
+// Timestamp orders the changes of a document. Counter is a lamport counter, and Node breaks the ties
+// between the changes of different nodes that have the same counter.
+type Timestamp struct {
+	Counter int64  `json:"counter"`
+	Node    string `json:"node"`
+}
+
+// After reports whether t is a later change than other
+func (t Timestamp) After(other Timestamp) bool {
+	if t.Counter != other.Counter {
+		return t.Counter > other.Counter
+	}
+
+	return t.Node > other.Node
+}
@@ -3,15 +3,31 @@ package data
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
 	"github.com/umtdemr/wb-backend/internal/validator"
+	"strings"
 	"time"
 )
 
 const DefaultBoardName = "My Whiteboard"
 
+// BoardRetention is how long the deleted boards are kept in the trash before they are purged
+const BoardRetention = 30 * 24 * time.Hour
+
+// duplicateNamePrefix is prepended to the names of the board copies
+const duplicateNamePrefix = "Copy of "
+
+// Roles of the users in a board. Owners are not stored with a role, they are editors of their boards.
+const (
+	BoardRoleOwner  = "owner"
+	BoardRoleEditor = db.BoardRoleEditor
+	BoardRoleViewer = db.BoardRoleViewer
+)
+
 // Board represents db.Board
 type Board struct {
 	Id        int64     `json:"id"`
@@ -19,7 +35,15 @@ type Board struct {
 	Name      string    `json:"name"`
 	SlugId    string    `json:"slug_id"`
 	CreatedAt time.Time `json:"created_at"`
-	Pages     []Page    `json:"pages"`
+	// Role is the role of the user who retrieves the board, either as a member or with a share link
+	Role  string `json:"role"`
+	Pages []Page `json:"pages"`
+	// TemplateScope is set when the board is a template
+	TemplateScope string `json:"template_scope,omitempty"`
+	// WorkspaceId is set when the board belongs to a workspace
+	WorkspaceId *int64 `json:"workspace_id,omitempty"`
+	// TemplateId is the template to create the board from, it is only used while creating the board
+	TemplateId int64 `json:"-"`
 }
 
 type BoardUser struct {
@@ -36,14 +60,8 @@ func (b *Board) copyFromDbBoard(dbBoard *db.Board) {
 	b.OwnerId = dbBoard.OwnerID
 	b.Name = dbBoard.Name
 	b.CreatedAt = dbBoard.CreatedAt.Time
-}
-
-// Page represents db.BoardPage
-type Page struct {
-	Id        int64     `json:"id"`
-	BoardId   int64     `json:"board_id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
+	b.TemplateScope = dbBoard.TemplateScope.String
+	b.WorkspaceId = optionalId(dbBoard.WorkspaceID)
 }
 
 // GenerateSlugId generates a 12 bytes long slug
@@ -60,6 +78,22 @@ func GenerateSlugId() (string, error) {
 	return encoded[:12], nil
 }
 
+// ValidateMemberRole checks if the role can be given to a board member. Owner is not a role to be given,
+// ownership is transferred instead.
+func ValidateMemberRole(v *validator.Validator, role string) {
+	v.Check(validator.PermittedValue(role, BoardRoleEditor, BoardRoleViewer), "role", "must be one of editor or viewer")
+}
+
+// DuplicateBoardName returns the name of a copy of the board, shortened to fit the board name rules
+func DuplicateBoardName(name string) string {
+	copyName := []rune(duplicateNamePrefix + name)
+	for len(string(copyName)) > 25 {
+		copyName = copyName[:len(copyName)-1]
+	}
+
+	return strings.TrimSpace(string(copyName))
+}
+
 func ValidateBoard(v *validator.Validator, board *Board) {
 	boardNameLen := len(board.Name)
 	v.Check(board.OwnerId >= 0, "owner_id", "must be set")
@@ -69,10 +103,31 @@ func ValidateBoard(v *validator.Validator, board *Board) {
 
 type BoardModel interface {
 	CreateBoard(board *Board) (*Board, error)
-	GetAllBoards(ownerId int64) ([]*BoardResult, error)
+	GetAllBoards(userId int64, filters BoardFilters) ([]*BoardResult, Metadata, error)
+	GetRecentBoards(userId int64, limit int) ([]*BoardResult, error)
+	MarkOpened(boardId int64, userId int64) error
 	RetrieveBoard(userId int64, slugId string) (*Board, error)
-	InviteUser(user *User, boardId int64) error
+	RetrieveSharedBoard(slugId string, shareToken string) (*Board, error)
+	GetBoard(userId int64, slugId string) (*BoardResult, error)
+	GetBoardById(userId int64, boardId int64) (*BoardResult, error)
+	GetSharedBoard(slugId string, shareToken string) (*BoardResult, error)
+	InviteUser(user *User, boardId int64, role string) error
 	GetBoardUsers(boardId int64) ([]BoardUser, error)
+	UpdateMemberRole(boardId int64, userId int64, role string) error
+	RemoveMember(boardId int64, userId int64) error
+	TransferOwnership(boardId int64, newOwnerId int64) error
+	Rename(boardId int64, name string) (*Board, error)
+	Delete(boardId int64) error
+	GetDeletedBoards(ownerId int64) ([]*BoardResult, error)
+	Restore(ownerId int64, slugId string) (*Board, error)
+	PurgeDeleted(retention time.Duration) (int64, error)
+	Duplicate(sourceBoardId int64, board *Board) (*Board, error)
+	Star(boardId int64, userId int64) error
+	Unstar(boardId int64, userId int64) error
+	MoveToFolder(boardId int64, userId int64, folderId *int64) error
+	MoveToWorkspace(boardId int64, workspaceId *int64) (*Board, error)
+	Export(board *BoardResult) (*BoardFile, error)
+	Import(ownerId int64, slugId string, file *BoardFile) (*Board, error)
 }
 
 type DbBoardModel struct {
@@ -84,19 +139,26 @@ var _ BoardModel = (*DbBoardModel)(nil)
 
 // CreateBoard creates board using transaction
 // This inserts all the necessary data to db. Creates 3 record in
-// db.Board, db.BoardPage, db.BoardUser
+// db.Board, db.BoardPage, db.BoardUser. If the board has a template id, the pages and elements
+// of the template are cloned instead, ErrRecordNotFound is returned if the user cannot use the template.
 func (m *DbBoardModel) CreateBoard(board *Board) (*Board, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
 	result, err := m.store.CreateBoardTx(ctx, db.CreateBoardTxParams{
-		OwnerId: board.OwnerId,
-		Name:    board.Name,
-		SlugId:  board.SlugId,
+		OwnerId:    board.OwnerId,
+		Name:       board.Name,
+		SlugId:     board.SlugId,
+		TemplateId: board.TemplateId,
 	})
 
 	if err != nil {
-		return nil, err
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
 	}
 
 	var createdBoard Board
@@ -111,35 +173,250 @@ type BoardResult struct {
 	SlugId    string    `json:"slug_id"`
 	CreatedAt time.Time `json:"created_at"`
 	IsOwner   bool      `json:"is_owner"`
+	Role      string    `json:"role"`
+	// ShareLinkId is the id of the share link that gives the access to the board, it is zero for the members
+	ShareLinkId int64 `json:"-"`
+	// DeletedAt is only set for the boards in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// LastActivityAt is the last time the board is changed by anyone
+	LastActivityAt time.Time `json:"last_activity_at"`
+	// LastOpenedAt is the last time the user opened the board, it is not set for the boards that are never opened
+	LastOpenedAt *time.Time `json:"last_opened_at,omitempty"`
+	// IsStarred and FolderId are personal to the user who lists the boards
+	IsStarred bool   `json:"is_starred"`
+	FolderId  *int64 `json:"folder_id"`
+	// WorkspaceId is set when the board belongs to a workspace
+	WorkspaceId *int64 `json:"workspace_id,omitempty"`
+	// ThumbnailUrl is set in the board list once the worker renders the thumbnail of the board
+	ThumbnailUrl string `json:"thumbnail_url,omitempty"`
 }
 
-// GetAllBoards returns all the board for the given user
-func (m *DbBoardModel) GetAllBoards(userId int64) ([]*BoardResult, error) {
+// optionalTime returns a pointer to the time if it is set
+func optionalTime(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+// optionalId returns a pointer to the id if it is set
+func optionalId(id pgtype.Int8) *int64 {
+	if !id.Valid {
+		return nil
+	}
+
+	return &id.Int64
+}
+
+// boardRole returns the role of a board member
+func boardRole(isOwner bool, role string) string {
+	if isOwner {
+		return BoardRoleOwner
+	}
+
+	return role
+}
+
+// CanEditBoard checks if the role allows changing the content of the board
+func CanEditBoard(role string) bool {
+	return role == BoardRoleOwner || role == BoardRoleEditor
+}
+
+// GetAllBoards returns a page of the boards of the given user, filtered and sorted with the filters.
+// The metadata contains the cursor of the next page if there are more boards.
+func (m *DbBoardModel) GetAllBoards(userId int64, filters BoardFilters) ([]*BoardResult, Metadata, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	boardRows, err := m.store.GetAllBoardsForUser(ctx, userId)
+	params := db.GetAllBoardsForUserParams{
+		UserID:    userId,
+		Ownership: filters.Ownership,
+		Search:    escapeLikePattern(filters.Search),
+		Sort:      filters.Sort,
+		FolderID:  filters.FolderId,
+		Starred:   filters.Starred,
+		// one more board is fetched to know whether there is a next page
+		PageSize: int32(filters.PageSize + 1),
+	}
+
+	if filters.Cursor != "" {
+		cursor, err := decodeBoardCursor(filters.Cursor)
+		if err != nil {
+			return nil, Metadata{}, err
+		}
+
+		params.HasCursor = true
+		params.CursorID = int32(cursor.Id)
+		params.CursorName = cursor.Name
+		params.CursorTime = pgtype.Timestamptz{Time: cursor.Time, Valid: true}
+	}
+
+	boardRows, err := m.store.GetAllBoardsForUser(ctx, params)
 
 	if err != nil {
-		return nil, err
+		return nil, Metadata{}, err
+	}
+
+	metadata := Metadata{PageSize: filters.PageSize}
+
+	if len(boardRows) > filters.PageSize {
+		boardRows = boardRows[:filters.PageSize]
+
+		last := boardRows[len(boardRows)-1]
+		cursor := boardCursor{Sort: filters.Sort, Id: int64(last.ID)}
+		switch sortColumn(filters.Sort) {
+		case "name":
+			cursor.Name = strings.ToLower(last.Name)
+		case "created_at":
+			cursor.Time = last.CreatedAt.Time
+		case "last_activity":
+			cursor.Time = last.LastActivityAt.Time
+		}
+		metadata.NextCursor = cursor.encode()
 	}
 
 	results := make([]*BoardResult, len(boardRows))
 
 	for i, board := range boardRows {
 		results[i] = &BoardResult{
-			Id:        int64(board.ID),
-			OwnerId:   board.OwnerID,
-			Name:      board.Name,
-			SlugId:    board.SlugID,
-			CreatedAt: board.CreatedAt.Time,
-			IsOwner:   board.IsOwner,
+			Id:             int64(board.ID),
+			OwnerId:        board.OwnerID,
+			Name:           board.Name,
+			SlugId:         board.SlugID,
+			CreatedAt:      board.CreatedAt.Time,
+			IsOwner:        board.IsOwner,
+			Role:           boardRole(board.IsOwner, board.Role),
+			LastActivityAt: board.LastActivityAt.Time,
+			LastOpenedAt:   optionalTime(board.LastOpenedAt),
+			IsStarred:      board.IsStarred,
+			FolderId:       optionalId(board.FolderID),
+			ThumbnailUrl:   thumbnailUrl(board.SlugID, board.ThumbnailUpdatedAt),
+		}
+	}
+
+	return results, metadata, nil
+}
+
+// GetRecentBoards returns the boards that the user opened lately, the most recent first
+func (m *DbBoardModel) GetRecentBoards(userId int64, limit int) ([]*BoardResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	boardRows, err := m.store.GetRecentBoardsForUser(ctx, db.GetRecentBoardsForUserParams{
+		OwnerID: userId,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*BoardResult, len(boardRows))
+	for i, board := range boardRows {
+		results[i] = &BoardResult{
+			Id:             int64(board.ID),
+			OwnerId:        board.OwnerID,
+			Name:           board.Name,
+			SlugId:         board.SlugID,
+			CreatedAt:      board.CreatedAt.Time,
+			IsOwner:        board.IsOwner,
+			Role:           boardRole(board.IsOwner, board.Role),
+			LastActivityAt: board.LastActivityAt.Time,
+			LastOpenedAt:   optionalTime(board.LastOpenedAt),
+			IsStarred:      board.IsStarred,
+			FolderId:       optionalId(board.FolderID),
 		}
 	}
 
 	return results, nil
 }
 
+// MarkOpened records that the member opened the board now
+func (m *DbBoardModel) MarkOpened(boardId int64, userId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.store.UpdateBoardUserLastOpened(ctx, db.UpdateBoardUserLastOpenedParams{
+		BoardID: boardId,
+		UserID:  userId,
+	})
+}
+
+// Star adds the board to the starred boards of the user. Starring a starred board again is not an error.
+func (m *DbBoardModel) Star(boardId int64, userId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.store.StarBoard(ctx, db.StarBoardParams{
+		UserID:  userId,
+		BoardID: boardId,
+	})
+}
+
+// Unstar removes the board from the starred boards of the user
+func (m *DbBoardModel) Unstar(boardId int64, userId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.store.UnstarBoard(ctx, db.UnstarBoardParams{
+		UserID:  userId,
+		BoardID: boardId,
+	})
+}
+
+// MoveToFolder puts the board into the folder of the member, a nil folder id takes the board out of its folder.
+// ErrRecordNotFound is returned if the user is not a member of the board or the folder is not one of their folders.
+func (m *DbBoardModel) MoveToFolder(boardId int64, userId int64, folderId *int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	params := db.UpdateBoardUserFolderParams{
+		BoardID: boardId,
+		UserID:  userId,
+	}
+	if folderId != nil {
+		params.FolderID = pgtype.Int8{Int64: *folderId, Valid: true}
+	}
+
+	rowsAffected, err := m.store.UpdateBoardUserFolder(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// MoveToWorkspace puts the board into the workspace so that the workspace members can access it,
+// a nil workspace id takes the board out of its workspace.
+func (m *DbBoardModel) MoveToWorkspace(boardId int64, workspaceId *int64) (*Board, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	params := db.UpdateBoardWorkspaceParams{ID: int32(boardId)}
+	if workspaceId != nil {
+		params.WorkspaceID = pgtype.Int8{Int64: *workspaceId, Valid: true}
+	}
+
+	dbBoard, err := m.store.UpdateBoardWorkspace(ctx, params)
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var board Board
+	board.copyFromDbBoard(&dbBoard)
+
+	return &board, nil
+}
+
 // RetrieveBoard retrieves a board with given user id and slug id
 func (m *DbBoardModel) RetrieveBoard(userId int64, slugId string) (*Board, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -161,18 +438,39 @@ func (m *DbBoardModel) RetrieveBoard(userId int64, slugId string) (*Board, error
 		}
 	}
 
-	// get board pages
-	ctx2, cancel2 := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel2()
+	board := &Board{}
+	board.Id = int64(boardData.ID)
+	board.OwnerId = boardData.OwnerID
+	board.Name = boardData.Name
+	board.SlugId = boardData.SlugID
+	board.CreatedAt = boardData.CreatedAt.Time
+	board.Role = boardRole(boardData.IsOwner, boardData.Role)
+	board.WorkspaceId = optionalId(boardData.WorkspaceID)
 
-	pagesData, err := m.store.GetBoardPageByBoardId(ctx2, int64(boardData.ID))
-	if err != nil {
+	if err := m.loadPages(board); err != nil {
 		return nil, err
 	}
 
-	// if there is no pages, that board cannot be used.
-	if len(pagesData) == 0 {
-		return nil, errors.New("there is no page for this board")
+	return board, nil
+}
+
+// RetrieveSharedBoard retrieves a board with given slug id for the holders of a valid share link of it
+func (m *DbBoardModel) RetrieveSharedBoard(slugId string, shareToken string) (*Board, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	shareHash := sha256.Sum256([]byte(shareToken))
+	boardData, err := m.store.GetBoardByShareLink(ctx, db.GetBoardByShareLinkParams{
+		SlugID: slugId,
+		Hash:   shareHash[:],
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
 	}
 
 	board := &Board{}
@@ -181,25 +479,174 @@ func (m *DbBoardModel) RetrieveBoard(userId int64, slugId string) (*Board, error
 	board.Name = boardData.Name
 	board.SlugId = boardData.SlugID
 	board.CreatedAt = boardData.CreatedAt.Time
+	board.Role = boardData.Role
+
+	if err := m.loadPages(board); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+// loadPages loads the pages of the board with their elements
+func (m *DbBoardModel) loadPages(board *Board) error {
+	pages, err := loadBoardPages(m.store, board.Id)
+	if err != nil {
+		return err
+	}
+
+	board.Pages = pages
+
+	return nil
+}
+
+// loadBoardPages returns the pages of the board with their elements, in the order of the page tabs
+func loadBoardPages(store db.Store, boardId int64) ([]Page, error) {
+	// get board pages
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	pagesData, err := store.GetBoardPageByBoardId(ctx, boardId)
+	if err != nil {
+		return nil, err
+	}
+
+	// if there is no pages, that board cannot be used.
+	if len(pagesData) == 0 {
+		return nil, errors.New("there is no page for this board")
+	}
+
+	// get elements of all pages
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel2()
+
+	elementsData, err := store.GetBoardElementsByBoardId(ctx2, boardId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageElements := make(map[int64][]Element, len(pagesData))
+	for i := range elementsData {
+		var element Element
+		element.copyFromDbElement(&elementsData[i])
+		pageElements[element.PageId] = append(pageElements[element.PageId], element)
+	}
 
 	// add pages
 	pages := make([]Page, len(pagesData))
 	for i, dbPage := range pagesData {
+		elements, ok := pageElements[int64(dbPage.ID)]
+		if !ok {
+			elements = []Element{}
+		}
+
 		pages[i] = Page{
 			Id:        int64(dbPage.ID),
 			Name:      dbPage.Name,
 			CreatedAt: dbPage.CreatedAt.Time,
-			BoardId:   board.Id,
+			Position:  dbPage.Position,
+			BoardId:   boardId,
+			Elements:  elements,
 		}
 	}
 
-	board.Pages = pages
+	return pages, nil
+}
 
-	return board, nil
+// GetBoard returns the board with given slug id and the role of the user if the user is a member of it.
+// Unlike RetrieveBoard, it does not load the pages and elements of the board.
+func (m *DbBoardModel) GetBoard(userId int64, slugId string) (*BoardResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	board, err := m.store.GetBoardBySlugId(ctx, db.GetBoardBySlugIdParams{
+		OwnerID: userId,
+		SlugID:  slugId,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &BoardResult{
+		Id:          int64(board.ID),
+		OwnerId:     board.OwnerID,
+		Name:        board.Name,
+		SlugId:      board.SlugID,
+		CreatedAt:   board.CreatedAt.Time,
+		IsOwner:     board.IsOwner,
+		Role:        boardRole(board.IsOwner, board.Role),
+		WorkspaceId: optionalId(board.WorkspaceID),
+	}, nil
+}
+
+// GetBoardById is the same as GetBoard for the endpoints that refer to the board by its id
+func (m *DbBoardModel) GetBoardById(userId int64, boardId int64) (*BoardResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	board, err := m.store.GetBoardByIdForUser(ctx, db.GetBoardByIdForUserParams{
+		OwnerID: userId,
+		ID:      int32(boardId),
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &BoardResult{
+		Id:          int64(board.ID),
+		OwnerId:     board.OwnerID,
+		Name:        board.Name,
+		SlugId:      board.SlugID,
+		CreatedAt:   board.CreatedAt.Time,
+		IsOwner:     board.IsOwner,
+		Role:        boardRole(board.IsOwner, board.Role),
+		WorkspaceId: optionalId(board.WorkspaceID),
+	}, nil
+}
+
+// GetSharedBoard returns the board with given slug id and the role of the share link if the link is valid.
+// Revoked and expired links do not give access to the board.
+func (m *DbBoardModel) GetSharedBoard(slugId string, shareToken string) (*BoardResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	shareHash := sha256.Sum256([]byte(shareToken))
+	board, err := m.store.GetBoardByShareLink(ctx, db.GetBoardByShareLinkParams{
+		SlugID: slugId,
+		Hash:   shareHash[:],
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &BoardResult{
+		Id:          int64(board.ID),
+		OwnerId:     board.OwnerID,
+		Name:        board.Name,
+		SlugId:      board.SlugID,
+		CreatedAt:   board.CreatedAt.Time,
+		Role:        board.Role,
+		ShareLinkId: int64(board.ShareLinkID),
+	}, nil
 }
 
-// InviteUser invites given user to given board. As of now, it does not handle user roles.
-func (m *DbBoardModel) InviteUser(user *User, boardId int64) error {
+// InviteUser invites given user to given board with the given role
+func (m *DbBoardModel) InviteUser(user *User, boardId int64, role string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
@@ -219,7 +666,7 @@ func (m *DbBoardModel) InviteUser(user *User, boardId int64) error {
 	_, err = m.store.AddToBoardUsers(ctx2, db.AddToBoardUsersParams{
 		UserID:  int64(user.ID),
 		BoardID: int64(board.ID),
-		Role:    "editor",
+		Role:    role,
 	})
 
 	if err != nil {
@@ -252,3 +699,195 @@ func (m *DbBoardModel) GetBoardUsers(boardId int64) ([]BoardUser, error) {
 
 	return boardUsers, nil
 }
+
+// UpdateMemberRole changes the role of a board member
+func (m *DbBoardModel) UpdateMemberRole(boardId int64, userId int64, role string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.store.UpdateBoardUserRole(ctx, db.UpdateBoardUserRoleParams{
+		BoardID: boardId,
+		UserID:  userId,
+		Role:    role,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveMember revokes the access of the user to the board
+func (m *DbBoardModel) RemoveMember(boardId int64, userId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rowsAffected, err := m.store.DeleteBoardUser(ctx, db.DeleteBoardUserParams{
+		BoardID: boardId,
+		UserID:  userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// TransferOwnership makes the member the owner of the board. Previous owner stays as an editor.
+func (m *DbBoardModel) TransferOwnership(boardId int64, newOwnerId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.store.TransferBoardOwnershipTx(ctx, db.TransferBoardOwnershipTxParams{
+		BoardId:    boardId,
+		NewOwnerId: newOwnerId,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rename changes the name of the board
+func (m *DbBoardModel) Rename(boardId int64, name string) (*Board, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbBoard, err := m.store.RenameBoard(ctx, db.RenameBoardParams{
+		ID:   int32(boardId),
+		Name: name,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var board Board
+	board.copyFromDbBoard(&dbBoard)
+	return &board, nil
+}
+
+// Delete moves the board to the trash. It can be restored until it is purged after BoardRetention.
+func (m *DbBoardModel) Delete(boardId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.store.SoftDeleteBoard(ctx, int32(boardId))
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetDeletedBoards returns the boards of the owner in the trash
+func (m *DbBoardModel) GetDeletedBoards(ownerId int64) ([]*BoardResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbBoards, err := m.store.GetDeletedBoardsForOwner(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*BoardResult, len(dbBoards))
+	for i, board := range dbBoards {
+		results[i] = &BoardResult{
+			Id:             int64(board.ID),
+			OwnerId:        board.OwnerID,
+			Name:           board.Name,
+			SlugId:         board.SlugID,
+			CreatedAt:      board.CreatedAt.Time,
+			IsOwner:        true,
+			Role:           BoardRoleOwner,
+			DeletedAt:      optionalTime(board.DeletedAt),
+			LastActivityAt: board.LastActivityAt.Time,
+		}
+	}
+
+	return results, nil
+}
+
+// Restore takes the board of the owner back from the trash
+func (m *DbBoardModel) Restore(ownerId int64, slugId string) (*Board, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbBoard, err := m.store.RestoreDeletedBoard(ctx, db.RestoreDeletedBoardParams{
+		SlugID:  slugId,
+		OwnerID: ownerId,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var board Board
+	board.copyFromDbBoard(&dbBoard)
+	return &board, nil
+}
+
+// PurgeDeleted permanently deletes the boards that have been in the trash longer than the retention
+// and returns the number of purged boards
+func (m *DbBoardModel) PurgeDeleted(retention time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.store.PurgeDeletedBoards(ctx, pgtype.Timestamptz{Time: time.Now().Add(-retention), Valid: true})
+}
+
+// Duplicate creates a copy of the source board with the name, slug id and owner of the given board.
+// Pages and elements are copied, members and history are not.
+func (m *DbBoardModel) Duplicate(sourceBoardId int64, board *Board) (*Board, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.store.DuplicateBoardTx(ctx, db.DuplicateBoardTxParams{
+		SourceBoardId: sourceBoardId,
+		Name:          board.Name,
+		SlugId:        board.SlugId,
+		OwnerId:       board.OwnerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicated Board
+	duplicated.copyFromDbBoard(&result.Board)
+	duplicated.Role = BoardRoleOwner
+
+	duplicated.Pages = make([]Page, len(result.Pages))
+	for i := range result.Pages {
+		duplicated.Pages[i].copyFromDbPage(&result.Pages[i])
+	}
+
+	return &duplicated, nil
+}
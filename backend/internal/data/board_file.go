@@ -0,0 +1,242 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"time"
+)
+
+// BoardFileType identifies the JSON documents that are board files
+const BoardFileType = "wb.board"
+
+// BoardFileVersion is the version of the board files written by this version of the app. It is increased whenever
+// the format changes in a way that the files cannot be read the same way as before.
+const BoardFileVersion = 1
+
+const (
+	// MaxBoardFileSize is the largest board file that can be imported in bytes
+	MaxBoardFileSize = 10 << 20
+	// MaxBoardFilePages is the largest number of pages that an imported board can have
+	MaxBoardFilePages = 100
+	// MaxBoardFileElements is the largest number of elements that an imported board can have in all the pages
+	MaxBoardFileElements = 20_000
+)
+
+// BoardFile is the JSON interchange format of a whole board. It is used to back up the boards and to move them
+// between environments, so it does not contain the ids, the members or anything else that belongs to an environment.
+// Boards have no comments yet, a later version of the format adds them when they exist.
+//
+// A version 1 board file looks like:
+//
+//	{
+//	  "type": "wb.board",
+//	  "version": 1,
+//	  "exported_at": "2025-01-25T10:00:00Z",
+//	  "board": {"name": "Roadmap", "created_at": "2025-01-02T09:30:00Z"},
+//	  "pages": [
+//	    {
+//	      "name": "Page 1",
+//	      "elements": [
+//	        {"type": "rectangle", "x": 0, "y": 0, "width": 120, "height": 80, "z_index": 0,
+//	         "stroke_color": 255, "fill_color": 0, "stroke_width": 2, "border_radius": 4, "text": "", "font_size": 14}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// Pages are in the order they are shown in the board and the elements of a page are in the order they are drawn.
+// Elements have the same fields as in the API, except for the ids, the versions and the timestamps.
+type BoardFile struct {
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Board      BoardFileBoard  `json:"board"`
+	Pages      []BoardFilePage `json:"pages"`
+}
+
+// BoardFileBoard is the metadata of the board in a BoardFile
+type BoardFileBoard struct {
+	Name string `json:"name"`
+	// CreatedAt is when the original board is created, the imported boards are created at the time of the import
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BoardFilePage is a page of the board in a BoardFile
+type BoardFilePage struct {
+	Name     string             `json:"name"`
+	Elements []BoardFileElement `json:"elements"`
+}
+
+// BoardFileElement is an element of a page in a BoardFile
+type BoardFileElement struct {
+	Type         string  `json:"type"`
+	X            float64 `json:"x"`
+	Y            float64 `json:"y"`
+	Width        float64 `json:"width"`
+	Height       float64 `json:"height"`
+	ZIndex       int32   `json:"z_index"`
+	StrokeColor  int64   `json:"stroke_color"`
+	FillColor    int64   `json:"fill_color"`
+	StrokeWidth  float64 `json:"stroke_width"`
+	BorderRadius float64 `json:"border_radius"`
+	Text         string  `json:"text"`
+	FontSize     int32   `json:"font_size"`
+}
+
+// newBoardFileElement copies the element into the board file format
+func newBoardFileElement(element *Element) BoardFileElement {
+	return BoardFileElement{
+		Type:         element.Type,
+		X:            element.X,
+		Y:            element.Y,
+		Width:        element.Width,
+		Height:       element.Height,
+		ZIndex:       element.ZIndex,
+		StrokeColor:  element.StrokeColor,
+		FillColor:    element.FillColor,
+		StrokeWidth:  element.StrokeWidth,
+		BorderRadius: element.BorderRadius,
+		Text:         element.Text,
+		FontSize:     element.FontSize,
+	}
+}
+
+// toElement returns the element that the board file element describes
+func (e *BoardFileElement) toElement() *Element {
+	return &Element{
+		Type:         e.Type,
+		X:            e.X,
+		Y:            e.Y,
+		Width:        e.Width,
+		Height:       e.Height,
+		ZIndex:       e.ZIndex,
+		StrokeColor:  e.StrokeColor,
+		FillColor:    e.FillColor,
+		StrokeWidth:  e.StrokeWidth,
+		BorderRadius: e.BorderRadius,
+		Text:         e.Text,
+		FontSize:     e.FontSize,
+	}
+}
+
+// ValidateBoardFile checks if the board file can be imported. The errors of the pages and the elements are keyed
+// with their path in the file, such as pages[0].elements[3].font_size.
+func ValidateBoardFile(v *validator.Validator, file *BoardFile) {
+	v.Check(file.Type == BoardFileType, "type", fmt.Sprintf("must be %s", BoardFileType))
+	v.Check(file.Version >= 1 && file.Version <= BoardFileVersion, "version", fmt.Sprintf("must be between 1 and %d", BoardFileVersion))
+
+	// same as the rule of ValidateBoard
+	boardNameLen := len(file.Board.Name)
+	v.Check(boardNameLen >= 3 && boardNameLen <= 25, "board.name", "must be between 3 and 25")
+
+	v.Check(len(file.Pages) >= 1 && len(file.Pages) <= MaxBoardFilePages, "pages", fmt.Sprintf("must have between 1 and %d pages", MaxBoardFilePages))
+
+	elementCount := 0
+	for i := range file.Pages {
+		page := &file.Pages[i]
+		elementCount += len(page.Elements)
+
+		pageValidator := validator.New()
+		ValidatePage(pageValidator, &Page{Name: page.Name})
+		addPrefixedErrors(v, fmt.Sprintf("pages[%d].", i), pageValidator.Errors)
+
+		for j := range page.Elements {
+			element := page.Elements[j].toElement()
+			// the page is not created yet, any page id passes the validation
+			element.PageId = 1
+
+			elementValidator := validator.New()
+			ValidateElement(elementValidator, element)
+			addPrefixedErrors(v, fmt.Sprintf("pages[%d].elements[%d].", i, j), elementValidator.Errors)
+		}
+	}
+
+	v.Check(elementCount <= MaxBoardFileElements, "pages", fmt.Sprintf("must not have more than %d elements", MaxBoardFileElements))
+}
+
+// addPrefixedErrors adds the errors of a part of a document with the path of the part
+func addPrefixedErrors(v *validator.Validator, prefix string, errors map[string]string) {
+	for key, message := range errors {
+		v.AddError(prefix+key, message)
+	}
+}
+
+// Export returns the board file of the board with its pages and elements
+func (m *DbBoardModel) Export(board *BoardResult) (*BoardFile, error) {
+	exported := &Board{Id: board.Id}
+	err := m.loadPages(exported)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &BoardFile{
+		Type:       BoardFileType,
+		Version:    BoardFileVersion,
+		ExportedAt: time.Now().UTC(),
+		Board: BoardFileBoard{
+			Name:      board.Name,
+			CreatedAt: board.CreatedAt,
+		},
+		Pages: make([]BoardFilePage, len(exported.Pages)),
+	}
+
+	for i, page := range exported.Pages {
+		file.Pages[i] = BoardFilePage{
+			Name:     page.Name,
+			Elements: make([]BoardFileElement, len(page.Elements)),
+		}
+		for j := range page.Elements {
+			file.Pages[i].Elements[j] = newBoardFileElement(&page.Elements[j])
+		}
+	}
+
+	return file, nil
+}
+
+// Import creates a new board of the owner from the board file, the file must be validated with ValidateBoardFile
+func (m *DbBoardModel) Import(ownerId int64, slugId string, file *BoardFile) (*Board, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	params := db.ImportBoardTxParams{
+		Name:    file.Board.Name,
+		SlugId:  slugId,
+		OwnerId: ownerId,
+		Pages:   make([]db.ImportBoardPage, len(file.Pages)),
+	}
+
+	for i, page := range file.Pages {
+		params.Pages[i] = db.ImportBoardPage{
+			Name:     page.Name,
+			Elements: make([]db.CreateBoardElementParams, len(page.Elements)),
+		}
+		for j, element := range page.Elements {
+			params.Pages[i].Elements[j] = db.CreateBoardElementParams{
+				Type:         element.Type,
+				X:            element.X,
+				Y:            element.Y,
+				Width:        element.Width,
+				Height:       element.Height,
+				ZIndex:       element.ZIndex,
+				StrokeColor:  element.StrokeColor,
+				FillColor:    element.FillColor,
+				StrokeWidth:  element.StrokeWidth,
+				BorderRadius: element.BorderRadius,
+				Text:         element.Text,
+				FontSize:     element.FontSize,
+			}
+		}
+	}
+
+	result, err := m.store.ImportBoardTx(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var board Board
+	board.copyFromDbBoard(&result.Board)
+
+	return &board, nil
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"testing"
+	"time"
+)
+
+// TestValidateBoardFile tests the validation rules of the board files
+func TestValidateBoardFile(t *testing.T) {
+	v := validator.New()
+	ValidateBoardFile(v, &BoardFile{Type: "excalidraw", Version: 3, Board: BoardFileBoard{Name: "a"}})
+	require.Contains(t, v.Errors, "type")
+	require.Contains(t, v.Errors, "version")
+	require.Contains(t, v.Errors, "board.name")
+	require.Contains(t, v.Errors, "pages")
+
+	v = validator.New()
+	ValidateBoardFile(v, &BoardFile{
+		Type:    BoardFileType,
+		Version: BoardFileVersion,
+		Board:   BoardFileBoard{Name: "Roadmap"},
+		Pages: []BoardFilePage{
+			{Name: "Page 1", Elements: []BoardFileElement{{Type: ElementTypeEllipse, FontSize: 14}}},
+			{Name: "", Elements: []BoardFileElement{
+				{Type: ElementTypeRectangle, FontSize: 14},
+				{Type: ElementTypeTextBox, Width: -1, StrokeColor: -1, FontSize: 0},
+			}},
+		},
+	})
+	require.Equal(t, map[string]string{
+		"pages[1].name":                     "must be between 1 and 100",
+		"pages[1].elements[1].width":        "must not be negative",
+		"pages[1].elements[1].stroke_color": "must be a valid color",
+		"pages[1].elements[1].font_size":    "must be between 1 and 200",
+	}, v.Errors)
+
+	v = validator.New()
+	ValidateBoardFile(v, &BoardFile{
+		Type:    BoardFileType,
+		Version: BoardFileVersion,
+		Board:   BoardFileBoard{Name: "Roadmap"},
+		Pages:   []BoardFilePage{{Name: "Page 1", Elements: make([]BoardFileElement, MaxBoardFileElements+1)}},
+	})
+	require.Contains(t, v.Errors, "pages")
+}
+
+// TestBoardModel_ExportImport tests that an exported board is imported with the same pages and elements
+func TestBoardModel_ExportImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbBoardModel{store: store}
+
+	createdAt := time.Now().Add(-time.Hour)
+	rectangle := db.BoardElement{
+		ID: 20, PageID: 8, Type: ElementTypeRectangle, X: 10, Y: 20, Width: 100, Height: 50,
+		StrokeColor: ColorBlack, StrokeWidth: 2, BorderRadius: 4, FontSize: 14, Version: 3,
+	}
+	text := db.BoardElement{
+		ID: 21, PageID: 9, Type: ElementTypeTextBox, Width: 100, Height: 20, ZIndex: 1,
+		StrokeColor: ColorBlack, Text: "hello", FontSize: 18, Version: 1,
+	}
+
+	store.EXPECT().
+		GetBoardPageByBoardId(gomock.Any(), int64(3)).
+		Return([]db.GetBoardPageByBoardIdRow{{ID: 8, Name: "Ideas"}, {ID: 9, Name: "Notes", Position: 1}, {ID: 10, Name: "Empty", Position: 2}}, nil)
+	store.EXPECT().
+		GetBoardElementsByBoardId(gomock.Any(), int64(3)).
+		Return([]db.BoardElement{rectangle, text}, nil)
+
+	file, err := model.Export(&BoardResult{Id: 3, Name: "Roadmap", CreatedAt: createdAt})
+	require.NoError(t, err)
+	require.Equal(t, BoardFileType, file.Type)
+	require.Equal(t, BoardFileVersion, file.Version)
+	require.Equal(t, BoardFileBoard{Name: "Roadmap", CreatedAt: createdAt}, file.Board)
+	require.Len(t, file.Pages, 3)
+	require.Equal(t, "Notes", file.Pages[1].Name)
+	require.Equal(t, "hello", file.Pages[1].Elements[0].Text)
+	require.NotNil(t, file.Pages[2].Elements)
+	require.Empty(t, file.Pages[2].Elements)
+
+	v := validator.New()
+	ValidateBoardFile(v, file)
+	require.True(t, v.Valid())
+
+	store.EXPECT().
+		ImportBoardTx(gomock.Any(), gomock.Eq(db.ImportBoardTxParams{
+			Name:    "Roadmap",
+			SlugId:  "new-12-chars",
+			OwnerId: 1,
+			Pages: []db.ImportBoardPage{
+				{Name: "Ideas", Elements: []db.CreateBoardElementParams{{
+					Type: ElementTypeRectangle, X: 10, Y: 20, Width: 100, Height: 50,
+					StrokeColor: ColorBlack, StrokeWidth: 2, BorderRadius: 4, FontSize: 14,
+				}}},
+				{Name: "Notes", Elements: []db.CreateBoardElementParams{{
+					Type: ElementTypeTextBox, Width: 100, Height: 20, ZIndex: 1,
+					StrokeColor: ColorBlack, Text: "hello", FontSize: 18,
+				}}},
+				{Name: "Empty", Elements: []db.CreateBoardElementParams{}},
+			},
+		})).
+		Return(db.ImportBoardTxResult{Board: db.Board{ID: 11, Name: "Roadmap", SlugID: "new-12-chars", OwnerID: 1}}, nil)
+
+	board, err := model.Import(1, "new-12-chars", file)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), board.Id)
+	require.Equal(t, "new-12-chars", board.SlugId)
+}
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"crypto/sha256"
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/jackc/pgx/v5"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
 	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
 	"testing"
 	"time"
 )
@@ -105,48 +107,140 @@ func TestBoardModel_GetAllBoards(t *testing.T) {
 	store := mockdb.NewMockStore(ctrl)
 	model := DbBoardModel{store: store}
 
+	defaultFilters := BoardFilters{Ownership: BoardOwnershipAll, Sort: "-created_at", PageSize: DefaultBoardPageSize}
+	createdAt := time.Now().Add(-time.Hour)
+
 	testCases := []struct {
 		name          string
 		inputId       int64
+		filters       BoardFilters
 		buildStub     func()
-		checkResponse func(t *testing.T, results []*BoardResult, err error)
+		checkResponse func(t *testing.T, results []*BoardResult, metadata Metadata, err error)
 	}{
 		{
 			name:    "successful call",
 			inputId: 1,
+			filters: defaultFilters,
 			buildStub: func() {
 				store.EXPECT().
-					GetAllBoardsForUser(gomock.Any(), int64(1)).
+					GetAllBoardsForUser(gomock.Any(), gomock.Eq(db.GetAllBoardsForUserParams{
+						UserID:    1,
+						Ownership: BoardOwnershipAll,
+						Sort:      "-created_at",
+						PageSize:  DefaultBoardPageSize + 1,
+					})).
 					Return(
 						[]db.GetAllBoardsForUserRow{
 							db.GetAllBoardsForUserRow{
-								ID: 1, OwnerID: 1, Name: DefaultBoardName,
+								ID: 1, OwnerID: 1, Name: DefaultBoardName, SlugID: "valid-12-ch-", IsOwner: true, Role: BoardRoleEditor,
+								ThumbnailUpdatedAt: pgtype.Timestamptz{Time: time.Unix(1736600000, 0), Valid: true},
 							},
 							db.GetAllBoardsForUserRow{
-								ID: 2, OwnerID: 1, Name: DefaultBoardName,
+								ID: 2, OwnerID: 2, Name: DefaultBoardName, Role: BoardRoleViewer,
 							},
 						},
 						nil,
 					)
 			},
-			checkResponse: func(t *testing.T, results []*BoardResult, err error) {
+			checkResponse: func(t *testing.T, results []*BoardResult, metadata Metadata, err error) {
 				require.NoError(t, err)
 				require.NotEmpty(t, results)
 				require.Equal(t, len(results), 2)
+				require.Equal(t, BoardRoleOwner, results[0].Role)
+				require.Equal(t, BoardRoleViewer, results[1].Role)
+				require.False(t, CanEditBoard(results[1].Role))
+				require.Empty(t, metadata.NextCursor)
+				// only the boards that have been rendered have a thumbnail
+				require.Equal(t, "/v1/boards/valid-12-ch-/thumbnail.png?v=1736600000", results[0].ThumbnailUrl)
+				require.Empty(t, results[1].ThumbnailUrl)
+			},
+		},
+		{
+			name:    "next page cursor",
+			inputId: 1,
+			filters: BoardFilters{Ownership: BoardOwnershipShared, Search: "50%_off", Sort: "created_at", PageSize: 1},
+			buildStub: func() {
+				store.EXPECT().
+					GetAllBoardsForUser(gomock.Any(), gomock.Eq(db.GetAllBoardsForUserParams{
+						UserID:    1,
+						Ownership: BoardOwnershipShared,
+						Search:    `50\%\_off`,
+						Sort:      "created_at",
+						PageSize:  2,
+					})).
+					Return(
+						[]db.GetAllBoardsForUserRow{
+							{ID: 3, OwnerID: 2, Name: "50%_off", Role: BoardRoleViewer, CreatedAt: pgtype.Timestamptz{Time: createdAt, Valid: true}},
+							{ID: 4, OwnerID: 2, Name: "50%_off", Role: BoardRoleViewer},
+						},
+						nil,
+					)
+			},
+			checkResponse: func(t *testing.T, results []*BoardResult, metadata Metadata, err error) {
+				require.NoError(t, err)
+				require.Len(t, results, 1)
+				require.Equal(t, int64(3), results[0].Id)
+				require.NotEmpty(t, metadata.NextCursor)
+
+				cursor, err := decodeBoardCursor(metadata.NextCursor)
+				require.NoError(t, err)
+				require.Equal(t, int64(3), cursor.Id)
+				require.Equal(t, "created_at", cursor.Sort)
+				require.WithinDuration(t, createdAt, cursor.Time, time.Microsecond)
+			},
+		},
+		{
+			name:    "with cursor",
+			inputId: 1,
+			filters: BoardFilters{
+				Ownership: BoardOwnershipAll,
+				Sort:      "-name",
+				PageSize:  10,
+				Cursor:    (&boardCursor{Sort: "-name", Id: 5, Name: "board"}).encode(),
+			},
+			buildStub: func() {
+				store.EXPECT().
+					GetAllBoardsForUser(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, params db.GetAllBoardsForUserParams) ([]db.GetAllBoardsForUserRow, error) {
+						require.True(t, params.HasCursor)
+						require.Equal(t, int32(5), params.CursorID)
+						require.Equal(t, "board", params.CursorName)
+						return []db.GetAllBoardsForUserRow{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, results []*BoardResult, metadata Metadata, err error) {
+				require.NoError(t, err)
+				require.Empty(t, results)
+				require.Empty(t, metadata.NextCursor)
+			},
+		},
+		{
+			name:    "invalid cursor",
+			inputId: 1,
+			filters: BoardFilters{Ownership: BoardOwnershipAll, Sort: "name", PageSize: 10, Cursor: "invalid"},
+			buildStub: func() {
+				store.EXPECT().
+					GetAllBoardsForUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, results []*BoardResult, metadata Metadata, err error) {
+				require.ErrorIs(t, err, ErrInvalidCursor)
+				require.Empty(t, results)
 			},
 		},
 		{
 			name:    "unexpected error",
 			inputId: 1,
+			filters: defaultFilters,
 			buildStub: func() {
 				store.EXPECT().
-					GetAllBoardsForUser(gomock.Any(), int64(1)).
+					GetAllBoardsForUser(gomock.Any(), gomock.Any()).
 					Return(
 						[]db.GetAllBoardsForUserRow{},
 						errors.New("unexpected error"),
 					)
 			},
-			checkResponse: func(t *testing.T, results []*BoardResult, err error) {
+			checkResponse: func(t *testing.T, results []*BoardResult, metadata Metadata, err error) {
 				require.Error(t, err)
 				require.Empty(t, results)
 			},
@@ -156,13 +250,33 @@ func TestBoardModel_GetAllBoards(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStub()
-			resp, err := model.GetAllBoards(tc.inputId)
+			resp, metadata, err := model.GetAllBoards(tc.inputId, tc.filters)
 
-			tc.checkResponse(t, resp, err)
+			tc.checkResponse(t, resp, metadata, err)
 		})
 	}
 }
 
+// TestValidateBoardFilters tests the validation rules of the board list options
+func TestValidateBoardFilters(t *testing.T) {
+	v := validator.New()
+	ValidateBoardFilters(v, BoardFilters{Ownership: "others", Sort: "owner", PageSize: 101, Cursor: "invalid"})
+	require.Contains(t, v.Errors, "filter")
+	require.Contains(t, v.Errors, "sort")
+	require.Contains(t, v.Errors, "page_size")
+	require.Contains(t, v.Errors, "cursor")
+
+	// cursors cannot be used with another sort
+	v = validator.New()
+	cursor := (&boardCursor{Sort: "name", Id: 3, Name: "board"}).encode()
+	ValidateBoardFilters(v, BoardFilters{Ownership: BoardOwnershipOwned, Sort: "-name", PageSize: 10, Cursor: cursor})
+	require.Contains(t, v.Errors, "cursor")
+
+	v = validator.New()
+	ValidateBoardFilters(v, BoardFilters{Ownership: BoardOwnershipOwned, Sort: "name", PageSize: 10, Cursor: cursor})
+	require.True(t, v.Valid())
+}
+
 // TestBoardModel_RetrieveBoard tests retrieving a board with given slug and user id
 func TestBoardModel_RetrieveBoard(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -241,25 +355,59 @@ func TestBoardModel_RetrieveBoard(t *testing.T) {
 			},
 		},
 		{
-			name: "Successful retrieve",
+			name: "Unexpected error on fetching board elements",
 			buildStub: func() {
 				store.EXPECT().
 					GetBoardBySlugId(gomock.Any(), gomock.Eq(db.GetBoardBySlugIdParams{OwnerID: 1, SlugID: "test"})).
 					Return(db.GetBoardBySlugIdRow{ID: int32(1)}, nil)
 
+				store.EXPECT().
+					GetBoardPageByBoardId(gomock.Any(), int64(1)).
+					Return([]db.GetBoardPageByBoardIdRow{{ID: 1, Name: "page 1"}}, nil)
+
+				store.EXPECT().
+					GetBoardElementsByBoardId(gomock.Any(), int64(1)).
+					Return([]db.BoardElement{}, unexpectedErr)
+			},
+			userId: 1,
+			slugId: "test",
+			checkResponse: func(t *testing.T, board *Board, err error) {
+				require.EqualError(t, unexpectedErr, err.Error())
+				require.Empty(t, board)
+			},
+		},
+		{
+			name: "Successful retrieve",
+			buildStub: func() {
+				store.EXPECT().
+					GetBoardBySlugId(gomock.Any(), gomock.Eq(db.GetBoardBySlugIdParams{OwnerID: 1, SlugID: "test"})).
+					Return(db.GetBoardBySlugIdRow{ID: int32(1), Role: BoardRoleViewer}, nil)
+
 				store.EXPECT().
 					GetBoardPageByBoardId(gomock.Any(), int64(1)).
 					Return(
 						[]db.GetBoardPageByBoardIdRow{
 							{
+								ID:   1,
 								Name: "page 1",
 							},
 							{
+								ID:   2,
 								Name: "page 2",
 							},
 						},
 						nil,
 					)
+
+				store.EXPECT().
+					GetBoardElementsByBoardId(gomock.Any(), int64(1)).
+					Return(
+						[]db.BoardElement{
+							{ID: 1, PageID: 1, Type: ElementTypeRectangle},
+							{ID: 2, PageID: 1, Type: ElementTypeEllipse},
+						},
+						nil,
+					)
 			},
 			userId: 1,
 			slugId: "test",
@@ -267,7 +415,11 @@ func TestBoardModel_RetrieveBoard(t *testing.T) {
 				require.NoError(t, err)
 				require.NotEmpty(t, board)
 				require.Equal(t, board.Id, int64(1))
+				require.Equal(t, BoardRoleViewer, board.Role)
 				require.Equal(t, len(board.Pages), 2)
+				require.Equal(t, len(board.Pages[0].Elements), 2)
+				require.NotNil(t, board.Pages[1].Elements)
+				require.Empty(t, board.Pages[1].Elements)
 			},
 		},
 	}
@@ -283,6 +435,51 @@ func TestBoardModel_RetrieveBoard(t *testing.T) {
 	}
 }
 
+// TestBoardModel_SharedBoard tests accessing a board with a share token
+func TestBoardModel_SharedBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbBoardModel{store: store}
+
+	shareHash := sha256.Sum256([]byte("share-token"))
+	params := db.GetBoardByShareLinkParams{SlugID: "test", Hash: shareHash[:]}
+
+	// revoked, expired or unknown links are not found
+	store.EXPECT().
+		GetBoardByShareLink(gomock.Any(), params).
+		Return(db.GetBoardByShareLinkRow{}, pgx.ErrNoRows)
+
+	result, err := model.GetSharedBoard("test", "share-token")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+	require.Nil(t, result)
+
+	store.EXPECT().
+		GetBoardByShareLink(gomock.Any(), params).
+		Return(db.GetBoardByShareLinkRow{ID: 1, SlugID: "test", Role: BoardRoleEditor, ShareLinkID: 4}, nil)
+
+	result, err = model.GetSharedBoard("test", "share-token")
+	require.NoError(t, err)
+	require.Equal(t, BoardRoleEditor, result.Role)
+	require.Equal(t, int64(4), result.ShareLinkId)
+	require.False(t, result.IsOwner)
+
+	store.EXPECT().
+		GetBoardByShareLink(gomock.Any(), params).
+		Return(db.GetBoardByShareLinkRow{ID: 1, SlugID: "test", Role: BoardRoleViewer, ShareLinkID: 4}, nil)
+	store.EXPECT().
+		GetBoardPageByBoardId(gomock.Any(), int64(1)).
+		Return([]db.GetBoardPageByBoardIdRow{{ID: 1, Name: "page 1"}}, nil)
+	store.EXPECT().
+		GetBoardElementsByBoardId(gomock.Any(), int64(1)).
+		Return([]db.BoardElement{{ID: 1, PageID: 1, Type: ElementTypeRectangle}}, nil)
+
+	board, err := model.RetrieveSharedBoard("test", "share-token")
+	require.NoError(t, err)
+	require.Equal(t, BoardRoleViewer, board.Role)
+	require.Len(t, board.Pages, 1)
+	require.Len(t, board.Pages[0].Elements, 1)
+}
+
 // TestBoardModel_InviteUser tests inviting user
 func TestBoardModel_InviteUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -350,8 +547,8 @@ func TestBoardModel_InviteUser(t *testing.T) {
 					Return(db.Board{ID: 12}, nil)
 
 				store.EXPECT().
-					AddToBoardUsers(gomock.Any(), gomock.Any()).
-					Return(db.BoardUser{BoardID: 12, UserID: 12, Role: "editor"}, nil)
+					AddToBoardUsers(gomock.Any(), gomock.Eq(db.AddToBoardUsersParams{BoardID: 12, UserID: 12, Role: BoardRoleViewer})).
+					Return(db.BoardUser{BoardID: 12, UserID: 12, Role: BoardRoleViewer}, nil)
 			},
 			checkResponse: func(t *testing.T, err error) {
 				require.NoError(t, err)
@@ -365,7 +562,7 @@ func TestBoardModel_InviteUser(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.buildStub()
 
-			err := boardModel.InviteUser(tc.user, tc.boardId)
+			err := boardModel.InviteUser(tc.user, tc.boardId, BoardRoleViewer)
 			tc.checkResponse(t, err)
 		})
 	}
@@ -419,3 +616,281 @@ func TestBoardModel_GetBoardUsers(t *testing.T) {
 		})
 	}
 }
+
+// TestBoardModel_ManageMembers tests changing the roles of the members, removing them and transferring the ownership
+func TestBoardModel_ManageMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	boardModel := DbBoardModel{store: store}
+
+	store.EXPECT().
+		UpdateBoardUserRole(gomock.Any(), gomock.Eq(db.UpdateBoardUserRoleParams{BoardID: 3, UserID: 2, Role: BoardRoleViewer})).
+		Return(db.BoardUser{}, pgx.ErrNoRows)
+	require.EqualError(t, boardModel.UpdateMemberRole(3, 2, BoardRoleViewer), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		UpdateBoardUserRole(gomock.Any(), gomock.Any()).
+		Return(db.BoardUser{BoardID: 3, UserID: 2, Role: BoardRoleViewer}, nil)
+	require.NoError(t, boardModel.UpdateMemberRole(3, 2, BoardRoleViewer))
+
+	store.EXPECT().
+		DeleteBoardUser(gomock.Any(), gomock.Eq(db.DeleteBoardUserParams{BoardID: 3, UserID: 2})).
+		Return(int64(0), nil)
+	require.EqualError(t, boardModel.RemoveMember(3, 2), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		DeleteBoardUser(gomock.Any(), gomock.Any()).
+		Return(int64(1), nil)
+	require.NoError(t, boardModel.RemoveMember(3, 2))
+
+	store.EXPECT().
+		TransferBoardOwnershipTx(gomock.Any(), gomock.Eq(db.TransferBoardOwnershipTxParams{BoardId: 3, NewOwnerId: 2})).
+		Return(db.Board{}, pgx.ErrNoRows)
+	require.EqualError(t, boardModel.TransferOwnership(3, 2), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		TransferBoardOwnershipTx(gomock.Any(), gomock.Any()).
+		Return(db.Board{}, unexpectedErr)
+	require.EqualError(t, boardModel.TransferOwnership(3, 2), unexpectedErr.Error())
+}
+
+// TestBoardModel_RenameAndDelete tests renaming boards and moving them to the trash
+func TestBoardModel_RenameAndDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	boardModel := DbBoardModel{store: store}
+
+	store.EXPECT().
+		RenameBoard(gomock.Any(), gomock.Eq(db.RenameBoardParams{ID: 3, Name: "new name"})).
+		Return(db.Board{}, pgx.ErrNoRows)
+	board, err := boardModel.Rename(3, "new name")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+	require.Nil(t, board)
+
+	store.EXPECT().
+		RenameBoard(gomock.Any(), gomock.Any()).
+		Return(db.Board{ID: 3, Name: "new name"}, nil)
+	board, err = boardModel.Rename(3, "new name")
+	require.NoError(t, err)
+	require.Equal(t, "new name", board.Name)
+
+	store.EXPECT().
+		SoftDeleteBoard(gomock.Any(), int32(3)).
+		Return(db.Board{}, pgx.ErrNoRows)
+	require.EqualError(t, boardModel.Delete(3), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		SoftDeleteBoard(gomock.Any(), int32(3)).
+		Return(db.Board{ID: 3, IsDeleted: true}, nil)
+	require.NoError(t, boardModel.Delete(3))
+}
+
+// TestBoardModel_Trash tests listing, restoring and purging the boards in the trash
+func TestBoardModel_Trash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	boardModel := DbBoardModel{store: store}
+
+	deletedAt := time.Now().Add(-time.Hour)
+	store.EXPECT().
+		GetDeletedBoardsForOwner(gomock.Any(), int64(1)).
+		Return([]db.Board{{ID: 3, OwnerID: 1, IsDeleted: true, DeletedAt: pgtype.Timestamptz{Time: deletedAt, Valid: true}}}, nil)
+	results, err := boardModel.GetDeletedBoards(1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, BoardRoleOwner, results[0].Role)
+	require.WithinDuration(t, deletedAt, *results[0].DeletedAt, time.Second)
+
+	store.EXPECT().
+		RestoreDeletedBoard(gomock.Any(), gomock.Eq(db.RestoreDeletedBoardParams{SlugID: "test", OwnerID: 1})).
+		Return(db.Board{}, pgx.ErrNoRows)
+	board, err := boardModel.Restore(1, "test")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+	require.Nil(t, board)
+
+	store.EXPECT().
+		RestoreDeletedBoard(gomock.Any(), gomock.Any()).
+		Return(db.Board{ID: 3, SlugID: "test"}, nil)
+	board, err = boardModel.Restore(1, "test")
+	require.NoError(t, err)
+	require.Equal(t, "test", board.SlugId)
+
+	store.EXPECT().
+		PurgeDeletedBoards(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, deletedBefore pgtype.Timestamptz) (int64, error) {
+			require.WithinDuration(t, time.Now().Add(-BoardRetention), deletedBefore.Time, time.Second)
+			return 2, nil
+		})
+	purged, err := boardModel.PurgeDeleted(BoardRetention)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+}
+
+// TestBoardModel_Duplicate tests copying a board
+func TestBoardModel_Duplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	boardModel := DbBoardModel{store: store}
+
+	input := &Board{Name: "Copy of test", SlugId: "valid-12-ch-", OwnerId: 2}
+	params := db.DuplicateBoardTxParams{SourceBoardId: 3, Name: "Copy of test", SlugId: "valid-12-ch-", OwnerId: 2}
+
+	store.EXPECT().
+		DuplicateBoardTx(gomock.Any(), gomock.Eq(params)).
+		Return(db.DuplicateBoardTxResult{}, unexpectedErr)
+	board, err := boardModel.Duplicate(3, input)
+	require.EqualError(t, err, unexpectedErr.Error())
+	require.Nil(t, board)
+
+	store.EXPECT().
+		DuplicateBoardTx(gomock.Any(), gomock.Eq(params)).
+		Return(db.DuplicateBoardTxResult{
+			Board: db.Board{ID: 4, Name: "Copy of test", SlugID: "valid-12-ch-", OwnerID: 2},
+			Pages: []db.BoardPage{{ID: 7, BoardID: 4, Name: "page 1"}},
+		}, nil)
+	board, err = boardModel.Duplicate(3, input)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), board.Id)
+	require.Equal(t, BoardRoleOwner, board.Role)
+	require.Len(t, board.Pages, 1)
+	require.Equal(t, int64(7), board.Pages[0].Id)
+}
+
+// TestDuplicateBoardName tests that the names of the copies fit the board name rules
+func TestDuplicateBoardName(t *testing.T) {
+	require.Equal(t, "Copy of test", DuplicateBoardName("test"))
+	require.Equal(t, "Copy of My Whiteboard", DuplicateBoardName(DefaultBoardName))
+
+	name := DuplicateBoardName("a very long board name")
+	require.Len(t, name, 25)
+
+	v := validator.New()
+	ValidateBoard(v, &Board{Name: DuplicateBoardName("çok uzun bir tahta adı"), SlugId: "valid-12-ch-"})
+	require.True(t, v.Valid())
+}
+
+// TestBoardModel_RecentBoards tests recording the opened boards and listing them
+func TestBoardModel_RecentBoards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	boardModel := DbBoardModel{store: store}
+
+	store.EXPECT().
+		UpdateBoardUserLastOpened(gomock.Any(), gomock.Eq(db.UpdateBoardUserLastOpenedParams{BoardID: 3, UserID: 1})).
+		Return(nil)
+	require.NoError(t, boardModel.MarkOpened(3, 1))
+
+	store.EXPECT().
+		GetRecentBoardsForUser(gomock.Any(), gomock.Eq(db.GetRecentBoardsForUserParams{OwnerID: 1, Limit: 5})).
+		Return(nil, unexpectedErr)
+	results, err := boardModel.GetRecentBoards(1, 5)
+	require.EqualError(t, err, unexpectedErr.Error())
+	require.Nil(t, results)
+
+	openedAt := time.Now().Add(-time.Minute)
+	activityAt := time.Now().Add(-time.Hour)
+	store.EXPECT().
+		GetRecentBoardsForUser(gomock.Any(), gomock.Any()).
+		Return([]db.GetRecentBoardsForUserRow{
+			{
+				ID: 3, OwnerID: 2, Name: "test", Role: BoardRoleEditor,
+				LastOpenedAt:   pgtype.Timestamptz{Time: openedAt, Valid: true},
+				LastActivityAt: pgtype.Timestamptz{Time: activityAt, Valid: true},
+			},
+		}, nil)
+	results, err = boardModel.GetRecentBoards(1, 5)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, BoardRoleEditor, results[0].Role)
+	require.WithinDuration(t, openedAt, *results[0].LastOpenedAt, time.Second)
+	require.WithinDuration(t, activityAt, results[0].LastActivityAt, time.Second)
+}
+
+// TestBoardModel_StarsAndFolders tests starring the boards and moving them into the folders
+func TestBoardModel_StarsAndFolders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	boardModel := DbBoardModel{store: store}
+
+	store.EXPECT().
+		StarBoard(gomock.Any(), gomock.Eq(db.StarBoardParams{UserID: 1, BoardID: 3})).
+		Return(nil)
+	require.NoError(t, boardModel.Star(3, 1))
+
+	store.EXPECT().
+		UnstarBoard(gomock.Any(), gomock.Eq(db.UnstarBoardParams{UserID: 1, BoardID: 3})).
+		Return(unexpectedErr)
+	require.EqualError(t, boardModel.Unstar(3, 1), unexpectedErr.Error())
+
+	folderId := int64(5)
+	store.EXPECT().
+		UpdateBoardUserFolder(gomock.Any(), gomock.Eq(db.UpdateBoardUserFolderParams{
+			FolderID: pgtype.Int8{Int64: 5, Valid: true},
+			BoardID:  3,
+			UserID:   1,
+		})).
+		Return(int64(0), nil)
+	require.EqualError(t, boardModel.MoveToFolder(3, 1, &folderId), ErrRecordNotFound.Error())
+
+	// nil folder takes the board out of its folder
+	store.EXPECT().
+		UpdateBoardUserFolder(gomock.Any(), gomock.Eq(db.UpdateBoardUserFolderParams{BoardID: 3, UserID: 1})).
+		Return(int64(1), nil)
+	require.NoError(t, boardModel.MoveToFolder(3, 1, nil))
+
+	store.EXPECT().
+		GetAllBoardsForUser(gomock.Any(), gomock.Eq(db.GetAllBoardsForUserParams{
+			UserID:    1,
+			Ownership: BoardOwnershipAll,
+			Sort:      "-created_at",
+			PageSize:  11,
+			FolderID:  5,
+			Starred:   true,
+		})).
+		Return([]db.GetAllBoardsForUserRow{
+			{ID: 3, OwnerID: 1, IsOwner: true, IsStarred: true, FolderID: pgtype.Int8{Int64: 5, Valid: true}},
+		}, nil)
+	results, _, err := boardModel.GetAllBoards(1, BoardFilters{
+		Ownership: BoardOwnershipAll,
+		Sort:      "-created_at",
+		PageSize:  10,
+		FolderId:  5,
+		Starred:   true,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.True(t, results[0].IsStarred)
+	require.Equal(t, int64(5), *results[0].FolderId)
+}
+
+// TestBoardModel_MoveToWorkspace tests moving the boards into and out of the workspaces
+func TestBoardModel_MoveToWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	boardModel := DbBoardModel{store: store}
+
+	workspaceId := int64(4)
+	store.EXPECT().
+		UpdateBoardWorkspace(gomock.Any(), gomock.Eq(db.UpdateBoardWorkspaceParams{
+			WorkspaceID: pgtype.Int8{Int64: 4, Valid: true},
+			ID:          3,
+		})).
+		Return(db.Board{ID: 3, WorkspaceID: pgtype.Int8{Int64: 4, Valid: true}}, nil)
+	board, err := boardModel.MoveToWorkspace(3, &workspaceId)
+	require.NoError(t, err)
+	require.Equal(t, workspaceId, *board.WorkspaceId)
+
+	// nil workspace takes the board out of its workspace
+	store.EXPECT().
+		UpdateBoardWorkspace(gomock.Any(), gomock.Eq(db.UpdateBoardWorkspaceParams{ID: 3})).
+		Return(db.Board{ID: 3}, nil)
+	board, err = boardModel.MoveToWorkspace(3, nil)
+	require.NoError(t, err)
+	require.Nil(t, board.WorkspaceId)
+
+	store.EXPECT().
+		UpdateBoardWorkspace(gomock.Any(), gomock.Any()).
+		Return(db.Board{}, pgx.ErrNoRows)
+	_, err = boardModel.MoveToWorkspace(3, nil)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+}
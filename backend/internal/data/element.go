@@ -0,0 +1,386 @@
+package data
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"time"
+)
+
+const (
+	ElementTypeRectangle = "rectangle"
+	ElementTypeEllipse   = "ellipse"
+	ElementTypeTriangle  = "triangle"
+	ElementTypeTextBox   = "text_box"
+)
+
+// Colors are stored as packed 0xRRGGBBAA integers, the same way the client stores them
+const (
+	ColorBlack       int64 = 0x000000FF
+	ColorTransparent int64 = 0x00000000
+	maxColorValue    int64 = 0xFFFFFFFF
+)
+
+// Element represents db.BoardElement. It is a single shape drawn on a board page
+type Element struct {
+	Id           int64     `json:"id"`
+	PageId       int64     `json:"page_id"`
+	Type         string    `json:"type"`
+	X            float64   `json:"x"`
+	Y            float64   `json:"y"`
+	Width        float64   `json:"width"`
+	Height       float64   `json:"height"`
+	ZIndex       int32     `json:"z_index"`
+	StrokeColor  int64     `json:"stroke_color"`
+	FillColor    int64     `json:"fill_color"`
+	StrokeWidth  float64   `json:"stroke_width"`
+	BorderRadius float64   `json:"border_radius"`
+	Text         string    `json:"text"`
+	FontSize     int32     `json:"font_size"`
+	Version      int32     `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// copyFromDbElement copies data from db package to repository
+func (e *Element) copyFromDbElement(dbElement *db.BoardElement) {
+	e.Id = int64(dbElement.ID)
+	e.PageId = dbElement.PageID
+	e.Type = dbElement.Type
+	e.X = dbElement.X
+	e.Y = dbElement.Y
+	e.Width = dbElement.Width
+	e.Height = dbElement.Height
+	e.ZIndex = dbElement.ZIndex
+	e.StrokeColor = dbElement.StrokeColor
+	e.FillColor = dbElement.FillColor
+	e.StrokeWidth = dbElement.StrokeWidth
+	e.BorderRadius = dbElement.BorderRadius
+	e.Text = dbElement.Text
+	e.FontSize = dbElement.FontSize
+	e.Version = dbElement.Version
+	e.CreatedAt = dbElement.CreatedAt.Time
+	e.UpdatedAt = dbElement.UpdatedAt.Time
+}
+
+// ElementConflictError is returned when an element is changed with a stale version.
+// It carries the current state of the element, so that the client can rebase its changes on it.
+type ElementConflictError struct {
+	Element *Element
+}
+
+func (e *ElementConflictError) Error() string {
+	return ErrEditConflict.Error()
+}
+
+func (e *ElementConflictError) Unwrap() error {
+	return ErrEditConflict
+}
+
+// ElementPatch holds the fields of an Element to be updated. Nil fields are left as they are.
+type ElementPatch struct {
+	X            *float64 `json:"x"`
+	Y            *float64 `json:"y"`
+	Width        *float64 `json:"width"`
+	Height       *float64 `json:"height"`
+	ZIndex       *int32   `json:"z_index"`
+	StrokeColor  *int64   `json:"stroke_color"`
+	FillColor    *int64   `json:"fill_color"`
+	StrokeWidth  *float64 `json:"stroke_width"`
+	BorderRadius *float64 `json:"border_radius"`
+	Text         *string  `json:"text"`
+	FontSize     *int32   `json:"font_size"`
+}
+
+// IsEmpty checks if the patch does not change any field
+func (p *ElementPatch) IsEmpty() bool {
+	return p.X == nil && p.Y == nil && p.Width == nil && p.Height == nil && p.ZIndex == nil &&
+		p.StrokeColor == nil && p.FillColor == nil && p.StrokeWidth == nil && p.BorderRadius == nil &&
+		p.Text == nil && p.FontSize == nil
+}
+
+func ValidateElementType(v *validator.Validator, elementType string) {
+	v.Check(
+		validator.PermittedValue(elementType, ElementTypeRectangle, ElementTypeEllipse, ElementTypeTriangle, ElementTypeTextBox),
+		"type",
+		"must be one of rectangle, ellipse, triangle or text_box",
+	)
+}
+
+func ValidateElement(v *validator.Validator, element *Element) {
+	v.Check(element.PageId > 0, "page_id", "must be set")
+	ValidateElementType(v, element.Type)
+	v.Check(element.Width >= 0, "width", "must not be negative")
+	v.Check(element.Height >= 0, "height", "must not be negative")
+	v.Check(element.StrokeColor >= 0 && element.StrokeColor <= maxColorValue, "stroke_color", "must be a valid color")
+	v.Check(element.FillColor >= 0 && element.FillColor <= maxColorValue, "fill_color", "must be a valid color")
+	v.Check(element.StrokeWidth >= 0 && element.StrokeWidth <= 20, "stroke_width", "must be between 0 and 20")
+	v.Check(element.BorderRadius >= 0 && element.BorderRadius <= 20, "border_radius", "must be between 0 and 20")
+	v.Check(len(element.Text) <= 2000, "text", "must not be more than 2000 bytes long")
+	v.Check(element.FontSize >= 1 && element.FontSize <= 200, "font_size", "must be between 1 and 200")
+}
+
+func ValidateElementPatch(v *validator.Validator, patch *ElementPatch) {
+	v.Check(!patch.IsEmpty(), "patch", "must change at least one field")
+
+	if patch.Width != nil {
+		v.Check(*patch.Width >= 0, "width", "must not be negative")
+	}
+	if patch.Height != nil {
+		v.Check(*patch.Height >= 0, "height", "must not be negative")
+	}
+	if patch.StrokeColor != nil {
+		v.Check(*patch.StrokeColor >= 0 && *patch.StrokeColor <= maxColorValue, "stroke_color", "must be a valid color")
+	}
+	if patch.FillColor != nil {
+		v.Check(*patch.FillColor >= 0 && *patch.FillColor <= maxColorValue, "fill_color", "must be a valid color")
+	}
+	if patch.StrokeWidth != nil {
+		v.Check(*patch.StrokeWidth >= 0 && *patch.StrokeWidth <= 20, "stroke_width", "must be between 0 and 20")
+	}
+	if patch.BorderRadius != nil {
+		v.Check(*patch.BorderRadius >= 0 && *patch.BorderRadius <= 20, "border_radius", "must be between 0 and 20")
+	}
+	if patch.Text != nil {
+		v.Check(len(*patch.Text) <= 2000, "text", "must not be more than 2000 bytes long")
+	}
+	if patch.FontSize != nil {
+		v.Check(*patch.FontSize >= 1 && *patch.FontSize <= 200, "font_size", "must be between 1 and 200")
+	}
+}
+
+type ElementModel interface {
+	Create(boardSlugId string, userId int64, element *Element) (*Operation, error)
+	Update(boardSlugId string, userId int64, id int64, version int32, patch *ElementPatch) (*Operation, error)
+	Reorder(boardSlugId string, userId int64, id int64, version int32, zIndex int32) (*Operation, error)
+	Delete(boardSlugId string, userId int64, id int64, version *int32) (*Operation, error)
+	Restore(boardSlugId string, userId int64, element *Element) (*Operation, error)
+	GetAllForPage(pageId int64) ([]Element, error)
+}
+
+type DbElementModel struct {
+	store db.Store
+}
+
+// Ensure DbElementModel implements ElementModel interface
+var _ ElementModel = (*DbElementModel)(nil)
+
+// Create inserts the element to the given page and logs the operation.
+// Page has to belong to the board with given slug id.
+func (m *DbElementModel) Create(boardSlugId string, userId int64, element *Element) (*Operation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.store.CreateElementTx(ctx, db.CreateElementTxParams{
+		BoardSlugId: boardSlugId,
+		UserId:      userId,
+		Element: db.CreateBoardElementParams{
+			PageID:       element.PageId,
+			Type:         element.Type,
+			X:            element.X,
+			Y:            element.Y,
+			Width:        element.Width,
+			Height:       element.Height,
+			ZIndex:       element.ZIndex,
+			StrokeColor:  element.StrokeColor,
+			FillColor:    element.FillColor,
+			StrokeWidth:  element.StrokeWidth,
+			BorderRadius: element.BorderRadius,
+			Text:         element.Text,
+			FontSize:     element.FontSize,
+		},
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return newOperationFromTx(&result), nil
+}
+
+// Update updates the fields that are set in patch for the element in the board with given slug id.
+// The version must be the current version of the element, otherwise ElementConflictError is returned.
+func (m *DbElementModel) Update(boardSlugId string, userId int64, id int64, version int32, patch *ElementPatch) (*Operation, error) {
+	return m.update(db.OperationElementUpdate, boardSlugId, userId, id, version, patch)
+}
+
+// Reorder moves the element to the given z index
+func (m *DbElementModel) Reorder(boardSlugId string, userId int64, id int64, version int32, zIndex int32) (*Operation, error) {
+	return m.update(db.OperationElementReorder, boardSlugId, userId, id, version, &ElementPatch{ZIndex: &zIndex})
+}
+
+// update applies the patch to the element and logs it with given operation type
+func (m *DbElementModel) update(operationType string, boardSlugId string, userId int64, id int64, version int32, patch *ElementPatch) (*Operation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	params := db.UpdateBoardElementParams{
+		ID:          int32(id),
+		Version:     version,
+		BoardSlugID: boardSlugId,
+	}
+
+	if patch.X != nil {
+		params.X = pgtype.Float8{Float64: *patch.X, Valid: true}
+	}
+	if patch.Y != nil {
+		params.Y = pgtype.Float8{Float64: *patch.Y, Valid: true}
+	}
+	if patch.Width != nil {
+		params.Width = pgtype.Float8{Float64: *patch.Width, Valid: true}
+	}
+	if patch.Height != nil {
+		params.Height = pgtype.Float8{Float64: *patch.Height, Valid: true}
+	}
+	if patch.ZIndex != nil {
+		params.ZIndex = pgtype.Int4{Int32: *patch.ZIndex, Valid: true}
+	}
+	if patch.StrokeColor != nil {
+		params.StrokeColor = pgtype.Int8{Int64: *patch.StrokeColor, Valid: true}
+	}
+	if patch.FillColor != nil {
+		params.FillColor = pgtype.Int8{Int64: *patch.FillColor, Valid: true}
+	}
+	if patch.StrokeWidth != nil {
+		params.StrokeWidth = pgtype.Float8{Float64: *patch.StrokeWidth, Valid: true}
+	}
+	if patch.BorderRadius != nil {
+		params.BorderRadius = pgtype.Float8{Float64: *patch.BorderRadius, Valid: true}
+	}
+	if patch.Text != nil {
+		params.Text = pgtype.Text{String: *patch.Text, Valid: true}
+	}
+	if patch.FontSize != nil {
+		params.FontSize = pgtype.Int4{Int32: *patch.FontSize, Valid: true}
+	}
+
+	result, err := m.store.UpdateElementTx(ctx, db.UpdateElementTxParams{
+		UserId:        userId,
+		OperationType: operationType,
+		Element:       params,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			// either the element does not exist or its version has changed in the meantime
+			return nil, m.conflictOrNotFound(ctx, boardSlugId, id)
+		default:
+			return nil, err
+		}
+	}
+
+	return newOperationFromTx(&result), nil
+}
+
+// conflictOrNotFound returns ElementConflictError with the current state of the element if it still exists
+func (m *DbElementModel) conflictOrNotFound(ctx context.Context, boardSlugId string, id int64) error {
+	dbElement, err := m.store.GetBoardElementBySlugId(ctx, db.GetBoardElementBySlugIdParams{
+		ID:     int32(id),
+		SlugID: boardSlugId,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	element := &Element{}
+	element.copyFromDbElement(&dbElement)
+
+	return &ElementConflictError{Element: element}
+}
+
+// Delete soft deletes the element in the board with given slug id. When the version is given, the element is
+// only deleted if it has not been changed since that version.
+func (m *DbElementModel) Delete(boardSlugId string, userId int64, id int64, version *int32) (*Operation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	params := db.DeleteBoardElementParams{
+		ID:          int32(id),
+		BoardSlugID: boardSlugId,
+	}
+	if version != nil {
+		params.Version = pgtype.Int4{Int32: *version, Valid: true}
+	}
+
+	result, err := m.store.DeleteElementTx(ctx, db.DeleteElementTxParams{
+		UserId:  userId,
+		Element: params,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err) && version != nil:
+			return nil, m.conflictOrNotFound(ctx, boardSlugId, id)
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return newOperationFromTx(&result), nil
+}
+
+// Restore brings back a deleted element in the board with given slug id. Fields of the element are set to
+// the given ones, and the operation is logged as a create.
+func (m *DbElementModel) Restore(boardSlugId string, userId int64, element *Element) (*Operation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.store.RestoreElementTx(ctx, db.RestoreElementTxParams{
+		BoardSlugId: boardSlugId,
+		UserId:      userId,
+		Element: db.RestoreBoardElementParams{
+			ID:           int32(element.Id),
+			PageID:       element.PageId,
+			X:            element.X,
+			Y:            element.Y,
+			Width:        element.Width,
+			Height:       element.Height,
+			ZIndex:       element.ZIndex,
+			StrokeColor:  element.StrokeColor,
+			FillColor:    element.FillColor,
+			StrokeWidth:  element.StrokeWidth,
+			BorderRadius: element.BorderRadius,
+			Text:         element.Text,
+			FontSize:     element.FontSize,
+		},
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return newOperationFromTx(&result), nil
+}
+
+// GetAllForPage returns all the elements that are not deleted in the given page, ordered by z index
+func (m *DbElementModel) GetAllForPage(pageId int64) ([]Element, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbElements, err := m.store.GetBoardElementsByPageId(ctx, pageId)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]Element, len(dbElements))
+	for i := range dbElements {
+		elements[i].copyFromDbElement(&dbElements[i])
+	}
+
+	return elements, nil
+}
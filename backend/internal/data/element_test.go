@@ -0,0 +1,412 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"testing"
+)
+
+// TestValidateElement tests element validation rules
+func TestValidateElement(t *testing.T) {
+	testCases := []struct {
+		name        string
+		element     *Element
+		invalidKeys []string
+	}{
+		{
+			name: "Valid rectangle",
+			element: &Element{
+				PageId:      1,
+				Type:        ElementTypeRectangle,
+				Width:       100,
+				Height:      50,
+				StrokeColor: ColorBlack,
+				StrokeWidth: 2,
+				FontSize:    14,
+			},
+		},
+		{
+			name: "Invalid type and sizes",
+			element: &Element{
+				PageId:   1,
+				Type:     "circle",
+				Width:    -1,
+				Height:   -1,
+				FontSize: 14,
+			},
+			invalidKeys: []string{"type", "width", "height"},
+		},
+		{
+			name: "Invalid colors",
+			element: &Element{
+				PageId:      1,
+				Type:        ElementTypeTextBox,
+				StrokeColor: -1,
+				FillColor:   maxColorValue + 1,
+				FontSize:    0,
+			},
+			invalidKeys: []string{"stroke_color", "fill_color", "font_size"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := validator.New()
+			ValidateElement(v, tc.element)
+
+			require.Equal(t, len(tc.invalidKeys), len(v.Errors))
+			for _, key := range tc.invalidKeys {
+				require.Contains(t, v.Errors, key)
+			}
+		})
+	}
+}
+
+// TestElementModel_Create tests creating an element in a board page
+func TestElementModel_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbElementModel{store: store}
+
+	testCases := []struct {
+		name          string
+		buildStub     func()
+		checkResponse func(t *testing.T, operation *Operation, err error)
+	}{
+		{
+			name: "Page not found in board",
+			buildStub: func() {
+				store.EXPECT().
+					CreateElementTx(gomock.Any(), gomock.Any()).
+					Return(db.ElementOperationTxResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				require.EqualError(t, err, ErrRecordNotFound.Error())
+				require.Nil(t, operation)
+			},
+		},
+		{
+			name: "Unexpected error on create",
+			buildStub: func() {
+				store.EXPECT().
+					CreateElementTx(gomock.Any(), gomock.Any()).
+					Return(db.ElementOperationTxResult{}, unexpectedErr)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				require.EqualError(t, err, unexpectedErr.Error())
+				require.Nil(t, operation)
+			},
+		},
+		{
+			name: "Successful create",
+			buildStub: func() {
+				store.EXPECT().
+					CreateElementTx(gomock.Any(), gomock.Eq(db.CreateElementTxParams{
+						BoardSlugId: "test",
+						UserId:      1,
+						Element: db.CreateBoardElementParams{
+							PageID:   3,
+							Type:     ElementTypeEllipse,
+							X:        10,
+							Y:        20,
+							Width:    30,
+							Height:   40,
+							FontSize: 14,
+						},
+					})).
+					Return(db.ElementOperationTxResult{
+						Element: db.BoardElement{ID: 7, PageID: 3, Type: ElementTypeEllipse, X: 10, Y: 20, Width: 30, Height: 40},
+						Operation: db.BoardOperation{
+							Revision:  4,
+							UserID:    pgtype.Int8{Int64: 1, Valid: true},
+							Type:      db.OperationElementCreate,
+							PageID:    3,
+							ElementID: 7,
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, operation)
+				require.Equal(t, int64(4), operation.Revision)
+				require.Equal(t, int64(1), operation.UserId)
+				require.Equal(t, db.OperationElementCreate, operation.Type)
+				require.Equal(t, int64(7), operation.Element.Id)
+				require.Equal(t, int64(3), operation.Element.PageId)
+				require.Equal(t, ElementTypeEllipse, operation.Element.Type)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+
+			operation, err := model.Create("test", 1, &Element{
+				PageId:   3,
+				Type:     ElementTypeEllipse,
+				X:        10,
+				Y:        20,
+				Width:    30,
+				Height:   40,
+				FontSize: 14,
+			})
+			tc.checkResponse(t, operation, err)
+		})
+	}
+}
+
+// TestElementModel_Update tests updating an element with a patch
+func TestElementModel_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbElementModel{store: store}
+
+	x := 12.5
+	text := "hello"
+
+	testCases := []struct {
+		name          string
+		buildStub     func()
+		checkResponse func(t *testing.T, operation *Operation, err error)
+	}{
+		{
+			name: "Element not found",
+			buildStub: func() {
+				store.EXPECT().
+					UpdateElementTx(gomock.Any(), gomock.Any()).
+					Return(db.ElementOperationTxResult{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetBoardElementBySlugId(gomock.Any(), gomock.Eq(db.GetBoardElementBySlugIdParams{ID: 5, SlugID: "test"})).
+					Return(db.BoardElement{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				require.EqualError(t, err, ErrRecordNotFound.Error())
+				require.Nil(t, operation)
+			},
+		},
+		{
+			name: "Stale version",
+			buildStub: func() {
+				store.EXPECT().
+					UpdateElementTx(gomock.Any(), gomock.Any()).
+					Return(db.ElementOperationTxResult{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetBoardElementBySlugId(gomock.Any(), gomock.Any()).
+					Return(db.BoardElement{ID: 5, X: 99, Version: 3}, nil)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				require.ErrorIs(t, err, ErrEditConflict)
+				require.Nil(t, operation)
+
+				var conflictErr *ElementConflictError
+				require.ErrorAs(t, err, &conflictErr)
+				require.Equal(t, int32(3), conflictErr.Element.Version)
+				require.Equal(t, float64(99), conflictErr.Element.X)
+			},
+		},
+		{
+			name: "Successful update",
+			buildStub: func() {
+				store.EXPECT().
+					UpdateElementTx(gomock.Any(), gomock.Eq(db.UpdateElementTxParams{
+						UserId:        1,
+						OperationType: db.OperationElementUpdate,
+						Element: db.UpdateBoardElementParams{
+							ID:          5,
+							Version:     2,
+							BoardSlugID: "test",
+							X:           pgtype.Float8{Float64: x, Valid: true},
+							Text:        pgtype.Text{String: text, Valid: true},
+						},
+					})).
+					Return(db.ElementOperationTxResult{
+						Element:   db.BoardElement{ID: 5, X: x, Text: text, Version: 3},
+						Operation: db.BoardOperation{Revision: 2, Type: db.OperationElementUpdate, ElementID: 5},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(2), operation.Revision)
+				require.Equal(t, x, operation.Element.X)
+				require.Equal(t, text, operation.Element.Text)
+				require.Equal(t, int32(3), operation.Element.Version)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+
+			operation, err := model.Update("test", 1, 5, 2, &ElementPatch{X: &x, Text: &text})
+			tc.checkResponse(t, operation, err)
+		})
+	}
+}
+
+// TestElementModel_Reorder tests that reordering only changes the z index and is logged as a reorder
+func TestElementModel_Reorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbElementModel{store: store}
+
+	store.EXPECT().
+		UpdateElementTx(gomock.Any(), gomock.Eq(db.UpdateElementTxParams{
+			UserId:        1,
+			OperationType: db.OperationElementReorder,
+			Element: db.UpdateBoardElementParams{
+				ID:          5,
+				Version:     1,
+				BoardSlugID: "test",
+				ZIndex:      pgtype.Int4{Int32: 3, Valid: true},
+			},
+		})).
+		Return(db.ElementOperationTxResult{
+			Element:   db.BoardElement{ID: 5, ZIndex: 3},
+			Operation: db.BoardOperation{Revision: 8, Type: db.OperationElementReorder, ElementID: 5},
+		}, nil)
+
+	operation, err := model.Reorder("test", 1, 5, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, db.OperationElementReorder, operation.Type)
+	require.Equal(t, int32(3), operation.Element.ZIndex)
+}
+
+// TestElementModel_Delete tests soft deleting an element
+func TestElementModel_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbElementModel{store: store}
+
+	version := int32(2)
+
+	testCases := []struct {
+		name          string
+		version       *int32
+		buildStub     func()
+		checkResponse func(t *testing.T, operation *Operation, err error)
+	}{
+		{
+			name: "Element not found",
+			buildStub: func() {
+				store.EXPECT().
+					DeleteElementTx(gomock.Any(), gomock.Any()).
+					Return(db.ElementOperationTxResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				require.EqualError(t, err, ErrRecordNotFound.Error())
+			},
+		},
+		{
+			name:    "Element changed since the version",
+			version: &version,
+			buildStub: func() {
+				store.EXPECT().
+					DeleteElementTx(gomock.Any(), gomock.Eq(db.DeleteElementTxParams{
+						UserId:  1,
+						Element: db.DeleteBoardElementParams{ID: 5, Version: pgtype.Int4{Int32: 2, Valid: true}, BoardSlugID: "test"},
+					})).
+					Return(db.ElementOperationTxResult{}, pgx.ErrNoRows)
+				store.EXPECT().
+					GetBoardElementBySlugId(gomock.Any(), gomock.Eq(db.GetBoardElementBySlugIdParams{ID: 5, SlugID: "test"})).
+					Return(db.BoardElement{ID: 5, Version: 3}, nil)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				var conflictErr *ElementConflictError
+				require.ErrorAs(t, err, &conflictErr)
+				require.Equal(t, int32(3), conflictErr.Element.Version)
+			},
+		},
+		{
+			name: "Successful delete",
+			buildStub: func() {
+				store.EXPECT().
+					DeleteElementTx(gomock.Any(), gomock.Eq(db.DeleteElementTxParams{
+						UserId:  1,
+						Element: db.DeleteBoardElementParams{ID: 5, BoardSlugID: "test"},
+					})).
+					Return(db.ElementOperationTxResult{
+						Element:   db.BoardElement{ID: 5, IsDeleted: true},
+						Operation: db.BoardOperation{Revision: 3, Type: db.OperationElementDelete, ElementID: 5},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, operation *Operation, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(5), operation.ElementId)
+				require.Equal(t, int64(5), operation.Element.Id)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+
+			operation, err := model.Delete("test", 1, 5, tc.version)
+			tc.checkResponse(t, operation, err)
+		})
+	}
+}
+
+// TestElementModel_Restore tests bringing back a deleted element
+func TestElementModel_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbElementModel{store: store}
+
+	element := &Element{Id: 5, PageId: 3, Type: ElementTypeRectangle, X: 10, FontSize: 14}
+
+	store.EXPECT().
+		RestoreElementTx(gomock.Any(), gomock.Any()).
+		Return(db.ElementOperationTxResult{}, pgx.ErrNoRows)
+
+	operation, err := model.Restore("test", 1, element)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+	require.Nil(t, operation)
+
+	store.EXPECT().
+		RestoreElementTx(gomock.Any(), gomock.Eq(db.RestoreElementTxParams{
+			BoardSlugId: "test",
+			UserId:      1,
+			Element:     db.RestoreBoardElementParams{ID: 5, PageID: 3, X: 10, FontSize: 14},
+		})).
+		Return(db.ElementOperationTxResult{
+			Element:   db.BoardElement{ID: 5, PageID: 3, X: 10, Version: 3},
+			Operation: db.BoardOperation{Revision: 9, Type: db.OperationElementCreate, ElementID: 5},
+		}, nil)
+
+	operation, err = model.Restore("test", 1, element)
+	require.NoError(t, err)
+	require.Equal(t, db.OperationElementCreate, operation.Type)
+	require.Equal(t, int32(3), operation.Element.Version)
+	require.Nil(t, operation.Previous)
+}
+
+// TestElementModel_GetAllForPage tests retrieving elements of a page
+func TestElementModel_GetAllForPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbElementModel{store: store}
+
+	store.EXPECT().
+		GetBoardElementsByPageId(gomock.Any(), int64(3)).
+		Return([]db.BoardElement{{ID: 1, PageID: 3}, {ID: 2, PageID: 3}}, nil)
+
+	elements, err := model.GetAllForPage(3)
+	require.NoError(t, err)
+	require.Len(t, elements, 2)
+
+	store.EXPECT().
+		GetBoardElementsByPageId(gomock.Any(), int64(3)).
+		Return(nil, unexpectedErr)
+
+	elements, err = model.GetAllForPage(3)
+	require.EqualError(t, err, unexpectedErr.Error())
+	require.Empty(t, elements)
+}
@@ -0,0 +1,343 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"time"
+)
+
+const (
+	ExportFormatPNG = "png"
+	ExportFormatPDF = "pdf"
+)
+
+// Deliveries of the exports. All the exports can be downloaded, the exports delivered by email are also sent
+// to the user who requested them once they are rendered.
+const (
+	ExportDeliveryLink  = "link"
+	ExportDeliveryEmail = "email"
+)
+
+// Statuses of the exports. The exports are pending until the worker renders them.
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+const (
+	// MaxExportSize is the largest width and height of the exported images in pixels
+	MaxExportSize = 8192
+	// MaxExportScale is the largest number of pixels for a unit of the page
+	MaxExportScale = 4.0
+	// MinExportScale is the smallest number of pixels for a unit of the page
+	MinExportScale = 0.1
+	// ExportRetention is how long the exports are kept to be downloaded
+	ExportRetention = 24 * time.Hour
+)
+
+// ExportRegion is the area of the page to be exported in page coordinates
+type ExportRegion struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Export represents db.BoardExport. It is a page of a board, or all the pages of a board for the pdf exports,
+// rendered into a file by the worker.
+type Export struct {
+	Id      int64 `json:"id"`
+	BoardId int64 `json:"board_id"`
+	// PageId is nil for the pdf exports since they contain all the pages of the board
+	PageId *int64  `json:"page_id"`
+	UserId int64   `json:"-"`
+	Format string  `json:"format"`
+	Scale  float64 `json:"scale"`
+	// Region is nil when the export covers all the elements of the page
+	Region      *ExportRegion `json:"region"`
+	Status      string        `json:"status"`
+	Content     []byte        `json:"-"`
+	CreatedAt   time.Time     `json:"created_at"`
+	CompletedAt *time.Time    `json:"completed_at"`
+	Delivery    string        `json:"delivery"`
+	// Email is the address that the export is sent to when it is delivered by email
+	Email     string     `json:"-"`
+	EmailedAt *time.Time `json:"emailed_at"`
+}
+
+// copyFromDbExport copies data from db package to repository
+func (e *Export) copyFromDbExport(dbExport *db.BoardExport) {
+	e.Id = int64(dbExport.ID)
+	e.BoardId = dbExport.BoardID
+	e.PageId = nil
+	if dbExport.PageID.Valid {
+		e.PageId = &dbExport.PageID.Int64
+	}
+	e.UserId = dbExport.UserID
+	e.Format = dbExport.Format
+	e.Scale = dbExport.Scale
+	e.Region = nil
+	if dbExport.RegionX.Valid {
+		e.Region = &ExportRegion{
+			X:      dbExport.RegionX.Float64,
+			Y:      dbExport.RegionY.Float64,
+			Width:  dbExport.RegionWidth.Float64,
+			Height: dbExport.RegionHeight.Float64,
+		}
+	}
+	e.Status = dbExport.Status
+	e.Content = dbExport.Content
+	e.CreatedAt = dbExport.CreatedAt.Time
+	e.CompletedAt = nil
+	if dbExport.CompletedAt.Valid {
+		e.CompletedAt = &dbExport.CompletedAt.Time
+	}
+	e.Delivery = ExportDeliveryLink
+	e.Email = ""
+	if dbExport.Email.Valid {
+		e.Delivery = ExportDeliveryEmail
+		e.Email = dbExport.Email.String
+	}
+	e.EmailedAt = nil
+	if dbExport.EmailedAt.Valid {
+		e.EmailedAt = &dbExport.EmailedAt.Time
+	}
+}
+
+// Filename returns the name of the rendered file of the export
+func (e *Export) Filename() string {
+	return fmt.Sprintf("export-%d.%s", e.Id, e.Format)
+}
+
+// ContentType returns the media type of the rendered file of the export
+func (e *Export) ContentType() string {
+	if e.Format == ExportFormatPDF {
+		return "application/pdf"
+	}
+
+	return "image/png"
+}
+
+func ValidateExport(v *validator.Validator, export *Export) {
+	v.Check(validator.PermittedValue(export.Format, ExportFormatPNG, ExportFormatPDF), "format", "must be png or pdf")
+	v.Check(validator.PermittedValue(export.Delivery, ExportDeliveryLink, ExportDeliveryEmail), "delivery", "must be link or email")
+
+	// pdf exports contain all the pages of the board as vectors, they cannot be scaled or cropped
+	if export.Format == ExportFormatPDF {
+		v.Check(export.PageId == nil, "format", "must be png for the exports of a page")
+		v.Check(export.Scale == 1, "scale", "must not be set for pdf exports")
+		v.Check(export.Region == nil, "region", "must not be set for pdf exports")
+		return
+	}
+
+	v.Check(export.PageId != nil, "format", "must be pdf for the exports of a board")
+	v.Check(export.Delivery == ExportDeliveryLink, "delivery", "must be link for png exports")
+	v.Check(export.Scale >= MinExportScale && export.Scale <= MaxExportScale, "scale", "must be between 0.1 and 4")
+
+	if export.Region != nil {
+		v.Check(export.Region.Width > 0 && export.Region.Height > 0, "region", "must have a positive width and height")
+		v.Check(
+			export.Region.Width*export.Scale <= MaxExportSize && export.Region.Height*export.Scale <= MaxExportSize,
+			"region",
+			"must not be larger than 8192 pixels after scaling",
+		)
+	}
+}
+
+type ExportModel interface {
+	New(export *Export) (*Export, error)
+	Get(id int64) (*Export, error)
+	GetForUser(id int64, userId int64) (*Export, error)
+	Complete(id int64, content []byte) error
+	Fail(id int64) error
+	MarkEmailed(id int64) error
+	Purge(retention time.Duration) (int64, error)
+}
+
+type DbExportModel struct {
+	store db.Store
+}
+
+// Ensure DbExportModel implements ExportModel interface
+var _ ExportModel = (*DbExportModel)(nil)
+
+// New creates a pending export of the page, or of all the pages of the board when the page is not set.
+// ErrRecordNotFound is returned if the page is not a page of the board.
+func (m *DbExportModel) New(export *Export) (*Export, error) {
+	if export.PageId == nil {
+		return m.newPdf(export)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	params := db.CreateBoardExportParams{
+		UserID:  export.UserId,
+		Format:  export.Format,
+		Scale:   export.Scale,
+		PageID:  int32(*export.PageId),
+		BoardID: export.BoardId,
+	}
+	if export.Region != nil {
+		params.RegionX = pgtype.Float8{Float64: export.Region.X, Valid: true}
+		params.RegionY = pgtype.Float8{Float64: export.Region.Y, Valid: true}
+		params.RegionWidth = pgtype.Float8{Float64: export.Region.Width, Valid: true}
+		params.RegionHeight = pgtype.Float8{Float64: export.Region.Height, Valid: true}
+	}
+
+	dbExport, err := m.store.CreateBoardExport(ctx, params)
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var created Export
+	created.copyFromDbExport(&dbExport)
+
+	return &created, nil
+}
+
+// newPdf creates a pending pdf export of all the pages of the board
+func (m *DbExportModel) newPdf(export *Export) (*Export, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	params := db.CreateBoardPdfExportParams{
+		UserID:  export.UserId,
+		BoardID: export.BoardId,
+	}
+	if export.Delivery == ExportDeliveryEmail {
+		params.Email = pgtype.Text{String: export.Email, Valid: true}
+	}
+
+	dbExport, err := m.store.CreateBoardPdfExport(ctx, params)
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var created Export
+	created.copyFromDbExport(&dbExport)
+
+	return &created, nil
+}
+
+// Get returns the export with its content, it is used by the worker
+func (m *DbExportModel) Get(id int64) (*Export, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbExport, err := m.store.GetBoardExport(ctx, int32(id))
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var export Export
+	export.copyFromDbExport(&dbExport)
+
+	return &export, nil
+}
+
+// GetForUser returns the export if the user requested it
+func (m *DbExportModel) GetForUser(id int64, userId int64) (*Export, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbExport, err := m.store.GetBoardExportForUser(ctx, db.GetBoardExportForUserParams{
+		ID:     int32(id),
+		UserID: userId,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var export Export
+	export.copyFromDbExport(&dbExport)
+
+	return &export, nil
+}
+
+// Complete stores the rendered file of the pending export
+func (m *DbExportModel) Complete(id int64, content []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rowsAffected, err := m.store.CompleteBoardExport(ctx, db.CompleteBoardExportParams{
+		ID:      int32(id),
+		Content: content,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Fail marks the pending export as failed, failed exports are not retried
+func (m *DbExportModel) Fail(id int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rowsAffected, err := m.store.FailBoardExport(ctx, int32(id))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// MarkEmailed records that the export is sent by email, so that it is not sent again when the job is retried.
+// ErrRecordNotFound is returned if the export is not delivered by email or it is already sent.
+func (m *DbExportModel) MarkEmailed(id int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rowsAffected, err := m.store.MarkBoardExportEmailed(ctx, int32(id))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Purge deletes the exports that are older than the retention and returns the number of deleted exports
+func (m *DbExportModel) Purge(retention time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return m.store.PurgeBoardExports(ctx, pgtype.Timestamptz{Time: time.Now().Add(-retention), Valid: true})
+}
@@ -0,0 +1,172 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"testing"
+	"time"
+)
+
+// TestValidateExport tests the validation rules of the exports
+func TestValidateExport(t *testing.T) {
+	v := validator.New()
+	ValidateExport(v, &Export{Format: "gif", Scale: 5})
+	require.Contains(t, v.Errors, "format")
+	require.Contains(t, v.Errors, "scale")
+
+	pageId := int64(8)
+
+	v = validator.New()
+	ValidateExport(v, &Export{PageId: &pageId, Format: ExportFormatPNG, Scale: 1, Region: &ExportRegion{Width: 0, Height: 10}, Delivery: ExportDeliveryLink})
+	require.Contains(t, v.Errors, "region")
+
+	v = validator.New()
+	ValidateExport(v, &Export{PageId: &pageId, Format: ExportFormatPNG, Scale: 4, Region: &ExportRegion{Width: 3000, Height: 10}, Delivery: ExportDeliveryLink})
+	require.Contains(t, v.Errors, "region")
+
+	v = validator.New()
+	ValidateExport(v, &Export{PageId: &pageId, Format: ExportFormatPNG, Scale: 2, Region: &ExportRegion{X: -10, Y: -10, Width: 300, Height: 200}, Delivery: ExportDeliveryLink})
+	require.True(t, v.Valid())
+
+	// png exports are made from a page and only downloaded
+	v = validator.New()
+	ValidateExport(v, &Export{Format: ExportFormatPNG, Scale: 1, Delivery: ExportDeliveryEmail})
+	require.Contains(t, v.Errors, "format")
+	require.Contains(t, v.Errors, "delivery")
+
+	// pdf exports contain all the pages as vectors
+	v = validator.New()
+	ValidateExport(v, &Export{PageId: &pageId, Format: ExportFormatPDF, Scale: 2, Region: &ExportRegion{Width: 10, Height: 10}, Delivery: "fax"})
+	require.Contains(t, v.Errors, "format")
+	require.Contains(t, v.Errors, "scale")
+	require.Contains(t, v.Errors, "region")
+	require.Contains(t, v.Errors, "delivery")
+
+	v = validator.New()
+	ValidateExport(v, &Export{Format: ExportFormatPDF, Scale: 1, Delivery: ExportDeliveryEmail})
+	require.True(t, v.Valid())
+}
+
+// TestExportModel tests creating the exports and storing their rendered files
+func TestExportModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	exportModel := DbExportModel{store: store}
+
+	pageId := int64(8)
+	export := &Export{BoardId: 3, PageId: &pageId, UserId: 1, Format: ExportFormatPNG, Scale: 2, Region: &ExportRegion{X: 1, Y: 2, Width: 30, Height: 40}}
+	params := db.CreateBoardExportParams{
+		UserID:       1,
+		Format:       ExportFormatPNG,
+		Scale:        2,
+		RegionX:      pgtype.Float8{Float64: 1, Valid: true},
+		RegionY:      pgtype.Float8{Float64: 2, Valid: true},
+		RegionWidth:  pgtype.Float8{Float64: 30, Valid: true},
+		RegionHeight: pgtype.Float8{Float64: 40, Valid: true},
+		PageID:       8,
+		BoardID:      3,
+	}
+
+	// the page is not a page of the board
+	store.EXPECT().
+		CreateBoardExport(gomock.Any(), gomock.Eq(params)).
+		Return(db.BoardExport{}, pgx.ErrNoRows)
+	_, err := exportModel.New(export)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		CreateBoardExport(gomock.Any(), gomock.Eq(params)).
+		Return(db.BoardExport{
+			ID: 5, BoardID: 3, PageID: pgtype.Int8{Int64: 8, Valid: true}, UserID: 1, Format: ExportFormatPNG, Scale: 2, Status: ExportStatusPending,
+			RegionX: params.RegionX, RegionY: params.RegionY, RegionWidth: params.RegionWidth, RegionHeight: params.RegionHeight,
+		}, nil)
+	created, err := exportModel.New(export)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), created.Id)
+	require.Equal(t, *export.Region, *created.Region)
+	require.Equal(t, pageId, *created.PageId)
+	require.Equal(t, ExportDeliveryLink, created.Delivery)
+	require.Nil(t, created.CompletedAt)
+
+	completedAt := time.Now()
+	store.EXPECT().
+		GetBoardExportForUser(gomock.Any(), gomock.Eq(db.GetBoardExportForUserParams{ID: 5, UserID: 1})).
+		Return(db.BoardExport{
+			ID: 5, Status: ExportStatusCompleted, Content: []byte("png"),
+			CompletedAt: pgtype.Timestamptz{Time: completedAt, Valid: true},
+		}, nil)
+	found, err := exportModel.GetForUser(5, 1)
+	require.NoError(t, err)
+	require.Nil(t, found.Region)
+	require.Equal(t, []byte("png"), found.Content)
+	require.Equal(t, completedAt, *found.CompletedAt)
+
+	store.EXPECT().
+		GetBoardExport(gomock.Any(), gomock.Eq(int32(6))).
+		Return(db.BoardExport{}, pgx.ErrNoRows)
+	_, err = exportModel.Get(6)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	// exports that are not pending cannot be completed
+	store.EXPECT().
+		CompleteBoardExport(gomock.Any(), gomock.Eq(db.CompleteBoardExportParams{ID: 5, Content: []byte("png")})).
+		Return(int64(0), nil)
+	require.EqualError(t, exportModel.Complete(5, []byte("png")), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		FailBoardExport(gomock.Any(), gomock.Eq(int32(5))).
+		Return(int64(1), nil)
+	require.NoError(t, exportModel.Fail(5))
+}
+
+// TestExportModelPdf tests creating the pdf exports of the boards and recording their emails
+func TestExportModelPdf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	exportModel := DbExportModel{store: store}
+
+	params := db.CreateBoardPdfExportParams{
+		UserID:  1,
+		Email:   pgtype.Text{String: "test@test.com", Valid: true},
+		BoardID: 3,
+	}
+
+	store.EXPECT().
+		CreateBoardPdfExport(gomock.Any(), gomock.Eq(params)).
+		Return(db.BoardExport{
+			ID: 6, BoardID: 3, UserID: 1, Format: ExportFormatPDF, Scale: 1, Status: ExportStatusPending,
+			Email: params.Email,
+		}, nil)
+	created, err := exportModel.New(&Export{
+		BoardId: 3, UserId: 1, Format: ExportFormatPDF, Scale: 1, Delivery: ExportDeliveryEmail, Email: "test@test.com",
+	})
+	require.NoError(t, err)
+	require.Nil(t, created.PageId)
+	require.Equal(t, ExportDeliveryEmail, created.Delivery)
+	require.Equal(t, "test@test.com", created.Email)
+	require.Equal(t, "export-6.pdf", created.Filename())
+	require.Equal(t, "application/pdf", created.ContentType())
+
+	// the board is deleted
+	store.EXPECT().
+		CreateBoardPdfExport(gomock.Any(), gomock.Eq(db.CreateBoardPdfExportParams{UserID: 1, BoardID: 3})).
+		Return(db.BoardExport{}, pgx.ErrNoRows)
+	_, err = exportModel.New(&Export{BoardId: 3, UserId: 1, Format: ExportFormatPDF, Scale: 1, Delivery: ExportDeliveryLink})
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		MarkBoardExportEmailed(gomock.Any(), gomock.Eq(int32(6))).
+		Return(int64(1), nil)
+	require.NoError(t, exportModel.MarkEmailed(6))
+
+	// the export is already sent
+	store.EXPECT().
+		MarkBoardExportEmailed(gomock.Any(), gomock.Eq(int32(6))).
+		Return(int64(0), nil)
+	require.EqualError(t, exportModel.MarkEmailed(6), ErrRecordNotFound.Error())
+}
@@ -0,0 +1,106 @@
+package data
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when the cursor of a list cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Ownership filters of the board list
+const (
+	BoardOwnershipAll    = "all"
+	BoardOwnershipOwned  = "owned"
+	BoardOwnershipShared = "shared"
+)
+
+const (
+	DefaultBoardPageSize = 50
+	MaxBoardPageSize     = 100
+)
+
+const (
+	DefaultRecentBoardsLimit = 10
+	MaxRecentBoardsLimit     = 50
+)
+
+// BoardSortSafelist contains the supported sort values of the board list. A leading hyphen means descending order.
+var BoardSortSafelist = []string{"name", "-name", "created_at", "-created_at", "last_activity", "-last_activity"}
+
+// BoardFilters are the options of the board list
+type BoardFilters struct {
+	Search    string
+	Ownership string
+	Sort      string
+	PageSize  int
+	// FolderId lists only the boards in the folder of the user, zero means any folder
+	FolderId int64
+	// Starred lists only the boards starred by the user
+	Starred bool
+	// Cursor is the next_cursor of the previous page, it is empty for the first page
+	Cursor string
+}
+
+// Metadata is sent with the paginated lists
+type Metadata struct {
+	PageSize int `json:"page_size"`
+	// NextCursor is empty when there are no more results
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func ValidateBoardFilters(v *validator.Validator, f BoardFilters) {
+	v.Check(len(f.Search) <= 100, "search", "must not be more than 100 bytes long")
+	v.Check(validator.PermittedValue(f.Ownership, BoardOwnershipAll, BoardOwnershipOwned, BoardOwnershipShared), "filter", "invalid filter value")
+	v.Check(validator.PermittedValue(f.Sort, BoardSortSafelist...), "sort", "invalid sort value")
+	v.Check(f.FolderId >= 0, "folder", "must be a positive integer")
+	v.Check(f.PageSize > 0 && f.PageSize <= MaxBoardPageSize, "page_size", "must be between 1 and 100")
+
+	if f.Cursor != "" {
+		cursor, err := decodeBoardCursor(f.Cursor)
+		v.Check(err == nil && cursor.Sort == f.Sort, "cursor", "must be a valid cursor")
+	}
+}
+
+// boardCursor points to the last board of a page. The sort value is kept in Name or Time depending on the sort.
+type boardCursor struct {
+	Sort string    `json:"s"`
+	Id   int64     `json:"i"`
+	Name string    `json:"n,omitempty"`
+	Time time.Time `json:"t,omitempty"`
+}
+
+// sortColumn returns the sort value without the direction
+func sortColumn(sort string) string {
+	return strings.TrimPrefix(sort, "-")
+}
+
+// encode returns the cursor as an url safe string
+func (c *boardCursor) encode() string {
+	js, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(js)
+}
+
+// decodeBoardCursor decodes the cursor returned with the previous page
+func decodeBoardCursor(cursor string) (*boardCursor, error) {
+	js, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c boardCursor
+	if err := json.Unmarshal(js, &c); err != nil || c.Id < 1 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// escapeLikePattern escapes the wildcard characters so that the search matches them literally
+func escapeLikePattern(search string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(search)
+}
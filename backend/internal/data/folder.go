@@ -0,0 +1,135 @@
+package data
+
+import (
+	"context"
+	"errors"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrDuplicateFolderName is returned when the user already has a folder with the same name
+var ErrDuplicateFolderName = errors.New("duplicate folder name")
+
+// Folder represents db.BoardFolder. Folders are personal, every user organizes the boards in their own folders.
+type Folder struct {
+	Id        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// copyFromDbFolder copies data from db package to repository
+func (f *Folder) copyFromDbFolder(dbFolder *db.BoardFolder) {
+	f.Id = int64(dbFolder.ID)
+	f.Name = dbFolder.Name
+	f.CreatedAt = dbFolder.CreatedAt.Time
+}
+
+func ValidateFolder(v *validator.Validator, folder *Folder) {
+	folderNameLen := utf8.RuneCountInString(folder.Name)
+	v.Check(folderNameLen >= 1 && folderNameLen <= 50, "name", "must be between 1 and 50")
+}
+
+type FolderModel interface {
+	New(userId int64, name string) (*Folder, error)
+	GetAll(userId int64) ([]Folder, error)
+	Rename(userId int64, folderId int64, name string) (*Folder, error)
+	Delete(userId int64, folderId int64) error
+}
+
+type DbFolderModel struct {
+	store db.Store
+}
+
+// Ensure DbFolderModel implements FolderModel interface
+var _ FolderModel = (*DbFolderModel)(nil)
+
+// New creates a folder for the user
+func (m *DbFolderModel) New(userId int64, name string) (*Folder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbFolder, err := m.store.CreateBoardFolder(ctx, db.CreateBoardFolderParams{
+		UserID: userId,
+		Name:   name,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrUniqueViolation(err):
+			return nil, ErrDuplicateFolderName
+		default:
+			return nil, err
+		}
+	}
+
+	var folder Folder
+	folder.copyFromDbFolder(&dbFolder)
+
+	return &folder, nil
+}
+
+// GetAll returns the folders of the user sorted by their names
+func (m *DbFolderModel) GetAll(userId int64) ([]Folder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbFolders, err := m.store.GetBoardFoldersForUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make([]Folder, len(dbFolders))
+	for i := range dbFolders {
+		folders[i].copyFromDbFolder(&dbFolders[i])
+	}
+
+	return folders, nil
+}
+
+// Rename changes the name of the folder of the user
+func (m *DbFolderModel) Rename(userId int64, folderId int64, name string) (*Folder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbFolder, err := m.store.RenameBoardFolder(ctx, db.RenameBoardFolderParams{
+		ID:     int32(folderId),
+		UserID: userId,
+		Name:   name,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		case db.IsErrUniqueViolation(err):
+			return nil, ErrDuplicateFolderName
+		default:
+			return nil, err
+		}
+	}
+
+	var folder Folder
+	folder.copyFromDbFolder(&dbFolder)
+
+	return &folder, nil
+}
+
+// Delete removes the folder of the user. The boards in the folder are not deleted, they are taken out of it.
+func (m *DbFolderModel) Delete(userId int64, folderId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rowsAffected, err := m.store.DeleteBoardFolder(ctx, db.DeleteBoardFolderParams{
+		ID:     int32(folderId),
+		UserID: userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
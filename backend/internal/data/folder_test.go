@@ -0,0 +1,90 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"strings"
+	"testing"
+)
+
+// TestValidateFolder tests the validation rules of the folder names
+func TestValidateFolder(t *testing.T) {
+	v := validator.New()
+	ValidateFolder(v, &Folder{Name: ""})
+	require.Contains(t, v.Errors, "name")
+
+	v = validator.New()
+	ValidateFolder(v, &Folder{Name: strings.Repeat("ş", 51)})
+	require.Contains(t, v.Errors, "name")
+
+	v = validator.New()
+	ValidateFolder(v, &Folder{Name: strings.Repeat("ş", 50)})
+	require.True(t, v.Valid())
+}
+
+// TestFolderModel tests creating, listing, renaming and deleting the folders
+func TestFolderModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	folderModel := DbFolderModel{store: store}
+
+	uniqueErr := &pgconn.PgError{Code: "23505"}
+
+	store.EXPECT().
+		CreateBoardFolder(gomock.Any(), gomock.Eq(db.CreateBoardFolderParams{UserID: 1, Name: "work"})).
+		Return(db.BoardFolder{ID: 4, UserID: 1, Name: "work"}, nil)
+	folder, err := folderModel.New(1, "work")
+	require.NoError(t, err)
+	require.Equal(t, int64(4), folder.Id)
+	require.Equal(t, "work", folder.Name)
+
+	store.EXPECT().
+		CreateBoardFolder(gomock.Any(), gomock.Any()).
+		Return(db.BoardFolder{}, uniqueErr)
+	folder, err = folderModel.New(1, "work")
+	require.EqualError(t, err, ErrDuplicateFolderName.Error())
+	require.Nil(t, folder)
+
+	store.EXPECT().
+		GetBoardFoldersForUser(gomock.Any(), gomock.Eq(int64(1))).
+		Return([]db.BoardFolder{{ID: 5, Name: "archive"}, {ID: 4, Name: "work"}}, nil)
+	folders, err := folderModel.GetAll(1)
+	require.NoError(t, err)
+	require.Len(t, folders, 2)
+	require.Equal(t, "archive", folders[0].Name)
+
+	renameParams := db.RenameBoardFolderParams{ID: 4, UserID: 1, Name: "projects"}
+	store.EXPECT().
+		RenameBoardFolder(gomock.Any(), gomock.Eq(renameParams)).
+		Return(db.BoardFolder{}, pgx.ErrNoRows)
+	_, err = folderModel.Rename(1, 4, "projects")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		RenameBoardFolder(gomock.Any(), gomock.Eq(renameParams)).
+		Return(db.BoardFolder{}, uniqueErr)
+	_, err = folderModel.Rename(1, 4, "projects")
+	require.EqualError(t, err, ErrDuplicateFolderName.Error())
+
+	store.EXPECT().
+		RenameBoardFolder(gomock.Any(), gomock.Eq(renameParams)).
+		Return(db.BoardFolder{ID: 4, UserID: 1, Name: "projects"}, nil)
+	folder, err = folderModel.Rename(1, 4, "projects")
+	require.NoError(t, err)
+	require.Equal(t, "projects", folder.Name)
+
+	store.EXPECT().
+		DeleteBoardFolder(gomock.Any(), gomock.Eq(db.DeleteBoardFolderParams{ID: 4, UserID: 1})).
+		Return(int64(0), nil)
+	require.EqualError(t, folderModel.Delete(1, 4), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		DeleteBoardFolder(gomock.Any(), gomock.Any()).
+		Return(int64(1), nil)
+	require.NoError(t, folderModel.Delete(1, 4))
+}
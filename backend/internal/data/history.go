@@ -0,0 +1,146 @@
+package data
+
+import (
+	"context"
+	"errors"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"time"
+)
+
+// HistoryPageSize is the maximum number of revisions returned in a single history request
+const HistoryPageSize = 100
+
+var ErrRevisionNotAvailable = errors.New("revision is not available")
+
+type HistoryAuthor struct {
+	Id       int64  `json:"id"`
+	FullName string `json:"full_name"`
+}
+
+// HistoryRevision is a single operation in the history of a board
+type HistoryRevision struct {
+	Revision  int64  `json:"revision"`
+	Type      string `json:"type"`
+	PageId    int64  `json:"page_id"`
+	ElementId int64  `json:"element_id"`
+	// Author is nil if the user who made the change is deleted
+	Author    *HistoryAuthor `json:"author"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// HistorySnapshot is a revision that the snapshots of the board pages are taken at
+type HistorySnapshot struct {
+	Revision  int64     `json:"revision"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type History struct {
+	Revision  int64             `json:"revision"`
+	Snapshots []HistorySnapshot `json:"snapshots"`
+	Revisions []HistoryRevision `json:"revisions"`
+}
+
+type HistoryModel interface {
+	Get(boardSlugId string, beforeRevision int64) (*History, error)
+	RestorePage(boardSlugId string, userId int64, pageId int64, revision int64) (int64, error)
+}
+
+type DbHistoryModel struct {
+	store db.Store
+}
+
+// Ensure DbHistoryModel implements HistoryModel interface
+var _ HistoryModel = (*DbHistoryModel)(nil)
+
+// Get returns the snapshots of the board and its revisions before the given revision, latest first.
+// Revisions before the compacted operations are only available as snapshots.
+func (m *DbHistoryModel) Get(boardSlugId string, beforeRevision int64) (*History, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	revision, err := m.store.GetBoardRevisionBySlugId(ctx, boardSlugId)
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if beforeRevision <= 0 || beforeRevision > revision {
+		beforeRevision = revision + 1
+	}
+
+	dbSnapshots, err := m.store.GetBoardSnapshotRevisions(ctx, boardSlugId)
+	if err != nil {
+		return nil, err
+	}
+
+	dbOperations, err := m.store.GetBoardOperationsWithAuthors(ctx, db.GetBoardOperationsWithAuthorsParams{
+		SlugID:   boardSlugId,
+		Revision: beforeRevision,
+		Limit:    HistoryPageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := &History{
+		Revision:  revision,
+		Snapshots: make([]HistorySnapshot, len(dbSnapshots)),
+		Revisions: make([]HistoryRevision, len(dbOperations)),
+	}
+
+	for i, dbSnapshot := range dbSnapshots {
+		history.Snapshots[i] = HistorySnapshot{
+			Revision:  dbSnapshot.Revision,
+			CreatedAt: dbSnapshot.CreatedAt.Time,
+		}
+	}
+
+	for i, dbOperation := range dbOperations {
+		history.Revisions[i] = HistoryRevision{
+			Revision:  dbOperation.Revision,
+			Type:      dbOperation.Type,
+			PageId:    dbOperation.PageID,
+			ElementId: dbOperation.ElementID,
+			CreatedAt: dbOperation.CreatedAt.Time,
+		}
+
+		if dbOperation.UserID.Valid {
+			history.Revisions[i].Author = &HistoryAuthor{
+				Id:       dbOperation.UserID.Int64,
+				FullName: dbOperation.FullName.String,
+			}
+		}
+	}
+
+	return history, nil
+}
+
+// RestorePage brings the page to its state at the given revision and returns the new revision of the board.
+// The state is built from the latest snapshot of the page before the revision and the operations after it.
+func (m *DbHistoryModel) RestorePage(boardSlugId string, userId int64, pageId int64, revision int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := m.store.RestorePageTx(ctx, db.RestorePageTxParams{
+		BoardSlugId: boardSlugId,
+		PageId:      pageId,
+		UserId:      userId,
+		Revision:    revision,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return 0, ErrRecordNotFound
+		case errors.Is(err, db.ErrRevisionNotAvailable):
+			return 0, ErrRevisionNotAvailable
+		default:
+			return 0, err
+		}
+	}
+
+	return result.Revision, nil
+}
@@ -0,0 +1,126 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"testing"
+)
+
+// TestHistoryModel_Get tests listing the revisions of a board
+func TestHistoryModel_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbHistoryModel{store: store}
+
+	store.EXPECT().
+		GetBoardRevisionBySlugId(gomock.Any(), "test").
+		Return(int64(0), pgx.ErrNoRows)
+
+	history, err := model.Get("test", 0)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+	require.Nil(t, history)
+
+	store.EXPECT().
+		GetBoardRevisionBySlugId(gomock.Any(), "test").
+		Return(int64(12), nil)
+	store.EXPECT().
+		GetBoardSnapshotRevisions(gomock.Any(), "test").
+		Return([]db.GetBoardSnapshotRevisionsRow{{Revision: 10}}, nil)
+	// revisions after the current one are not valid, whole history is listed instead
+	store.EXPECT().
+		GetBoardOperationsWithAuthors(gomock.Any(), gomock.Eq(db.GetBoardOperationsWithAuthorsParams{
+			SlugID:   "test",
+			Revision: 13,
+			Limit:    HistoryPageSize,
+		})).
+		Return([]db.GetBoardOperationsWithAuthorsRow{
+			{
+				Revision: 12,
+				Type:     db.OperationElementUpdate,
+				UserID:   pgtype.Int8{Int64: 1, Valid: true},
+				FullName: pgtype.Text{String: "Test User", Valid: true},
+			},
+			{Revision: 11, Type: db.OperationElementCreate},
+		}, nil)
+
+	history, err = model.Get("test", 50)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), history.Revision)
+	require.Len(t, history.Snapshots, 1)
+	require.Len(t, history.Revisions, 2)
+	require.Equal(t, "Test User", history.Revisions[0].Author.FullName)
+	require.Nil(t, history.Revisions[1].Author)
+}
+
+// TestHistoryModel_RestorePage tests restoring a page to a revision
+func TestHistoryModel_RestorePage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbHistoryModel{store: store}
+
+	testCases := []struct {
+		name          string
+		revision      int64
+		buildStub     func()
+		checkResponse func(t *testing.T, revision int64, err error)
+	}{
+		{
+			name:     "Page is not found",
+			revision: 8,
+			buildStub: func() {
+				store.EXPECT().
+					RestorePageTx(gomock.Any(), gomock.Any()).
+					Return(db.RestorePageTxResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, revision int64, err error) {
+				require.ErrorIs(t, err, ErrRecordNotFound)
+			},
+		},
+		{
+			name:     "Compacted operations",
+			revision: 8,
+			buildStub: func() {
+				store.EXPECT().
+					RestorePageTx(gomock.Any(), gomock.Any()).
+					Return(db.RestorePageTxResult{}, db.ErrRevisionNotAvailable)
+			},
+			checkResponse: func(t *testing.T, revision int64, err error) {
+				require.ErrorIs(t, err, ErrRevisionNotAvailable)
+			},
+		},
+		{
+			name:     "Successful",
+			revision: 7,
+			buildStub: func() {
+				store.EXPECT().
+					RestorePageTx(gomock.Any(), gomock.Eq(db.RestorePageTxParams{
+						BoardSlugId: "test",
+						PageId:      3,
+						UserId:      1,
+						Revision:    7,
+					})).
+					Return(db.RestorePageTxResult{
+						Operations: []db.BoardOperation{{Revision: 13}, {Revision: 14}},
+						Revision:   14,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, revision int64, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(14), revision)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+
+			revision, err := model.RestorePage("test", 1, 3, tc.revision)
+			tc.checkResponse(t, revision, err)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/token"
+	"time"
+)
+
+// InvitationTTL is how long an invitation waits for the invited person to sign up
+const InvitationTTL = 7 * 24 * time.Hour
+
+// Invitation represents db.BoardInvitation. It is a pending board membership for an email without an account.
+type Invitation struct {
+	BoardId   int64     `json:"board_id"`
+	BoardName string    `json:"board_name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Expiry    time.Time `json:"expiry"`
+	// Token is the plaintext token of the invitation. It is only sent to the invited email, never to the inviter.
+	Token string `json:"-"`
+}
+
+type InvitationModel interface {
+	New(boardId int64, email string, role string, invitedBy int64) (*Invitation, error)
+	AcceptAll(user *User) ([]int64, error)
+	Accept(user *User, tokenPlaintext string) (int64, error)
+}
+
+type DbInvitationModel struct {
+	store db.Store
+}
+
+// Ensure DbInvitationModel implements InvitationModel interface
+var _ InvitationModel = (*DbInvitationModel)(nil)
+
+// New creates an invitation to the board for the email. Inviting the same email again renews the invitation.
+func (m *DbInvitationModel) New(boardId int64, email string, role string, invitedBy int64) (*Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	board, err := m.store.GetBoardById(ctx, int32(boardId))
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	invitationToken, err := generateToken(0, InvitationTTL, token.ScopeInvitation)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInvitation, err := m.store.CreateBoardInvitation(ctx, db.CreateBoardInvitationParams{
+		Hash:      invitationToken.Hash,
+		BoardID:   int64(board.ID),
+		Email:     email,
+		Role:      role,
+		InvitedBy: pgtype.Int8{Int64: invitedBy, Valid: invitedBy != 0},
+		Expiry:    pgtype.Timestamptz{Time: invitationToken.Expiry, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Invitation{
+		BoardId:   dbInvitation.BoardID,
+		BoardName: board.Name,
+		Email:     dbInvitation.Email,
+		Role:      dbInvitation.Role,
+		Expiry:    dbInvitation.Expiry.Time,
+		Token:     invitationToken.Plaintext,
+	}, nil
+}
+
+// AcceptAll turns the pending invitations of the user's email into board memberships and
+// returns the ids of the boards that the user has joined. Invitations are only accepted
+// for verified users, since anyone can sign up with an email they do not own.
+func (m *DbInvitationModel) AcceptAll(user *User) ([]int64, error) {
+	if !user.IsVerified {
+		return []int64{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	boardUsers, err := m.store.AcceptBoardInvitations(ctx, db.AcceptBoardInvitationsParams{
+		Email:  user.Email,
+		UserID: int64(user.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	boardIds := make([]int64, len(boardUsers))
+	for i, boardUser := range boardUsers {
+		boardIds[i] = boardUser.BoardID
+	}
+
+	return boardIds, nil
+}
+
+// Accept turns the invitation with the token into a board membership of the user and returns the id of the board.
+// The token is the proof of the invitation email, so the user can accept it with an account of another email.
+func (m *DbInvitationModel) Accept(user *User, tokenPlaintext string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tokenHash := sha256.Sum256([]byte(tokenPlaintext))
+	boardUser, err := m.store.AcceptBoardInvitationByHash(ctx, db.AcceptBoardInvitationByHashParams{
+		Hash:   tokenHash[:],
+		UserID: int64(user.ID),
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return 0, ErrRecordNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	return boardUser.BoardID, nil
+}
@@ -0,0 +1,147 @@
+package data
+
+import (
+	"crypto/sha256"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"testing"
+)
+
+// TestInvitationModel_New tests creating invitations for emails without an account
+func TestInvitationModel_New(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbInvitationModel{store: store}
+
+	testCases := []struct {
+		name          string
+		buildStub     func()
+		checkResponse func(t *testing.T, invitation *Invitation, err error)
+	}{
+		{
+			name: "Board not found",
+			buildStub: func() {
+				store.EXPECT().
+					GetBoardById(gomock.Any(), int32(3)).
+					Return(db.Board{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, invitation *Invitation, err error) {
+				require.EqualError(t, err, ErrRecordNotFound.Error())
+				require.Nil(t, invitation)
+			},
+		},
+		{
+			name: "Unexpected error",
+			buildStub: func() {
+				store.EXPECT().
+					GetBoardById(gomock.Any(), int32(3)).
+					Return(db.Board{ID: 3, Name: "test"}, nil)
+				store.EXPECT().
+					CreateBoardInvitation(gomock.Any(), gomock.Any()).
+					Return(db.BoardInvitation{}, unexpectedErr)
+			},
+			checkResponse: func(t *testing.T, invitation *Invitation, err error) {
+				require.EqualError(t, err, unexpectedErr.Error())
+				require.Nil(t, invitation)
+			},
+		},
+		{
+			name: "Successful",
+			buildStub: func() {
+				store.EXPECT().
+					GetBoardById(gomock.Any(), int32(3)).
+					Return(db.Board{ID: 3, Name: "test"}, nil)
+				store.EXPECT().
+					CreateBoardInvitation(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.CreateBoardInvitationParams) (db.BoardInvitation, error) {
+						require.Equal(t, int64(3), arg.BoardID)
+						require.Equal(t, int64(1), arg.InvitedBy.Int64)
+						require.Len(t, arg.Hash, 32)
+						return db.BoardInvitation{
+							Hash:      arg.Hash,
+							BoardID:   arg.BoardID,
+							Email:     arg.Email,
+							Role:      arg.Role,
+							InvitedBy: arg.InvitedBy,
+							Expiry:    arg.Expiry,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, invitation *Invitation, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(3), invitation.BoardId)
+				require.Equal(t, "test", invitation.BoardName)
+				require.Equal(t, "new@test.com", invitation.Email)
+				require.Equal(t, BoardRoleViewer, invitation.Role)
+				require.Len(t, invitation.Token, 26)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+
+			invitation, err := model.New(3, "new@test.com", BoardRoleViewer, 1)
+			tc.checkResponse(t, invitation, err)
+		})
+	}
+}
+
+// TestInvitationModel_AcceptAll tests turning the pending invitations into board memberships
+func TestInvitationModel_AcceptAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbInvitationModel{store: store}
+	user := &User{ID: 4, Email: "new@test.com", IsVerified: true}
+
+	// unverified users do not get the memberships of the invitations
+	boardIds, err := model.AcceptAll(&User{ID: 4, Email: "new@test.com"})
+	require.NoError(t, err)
+	require.Empty(t, boardIds)
+
+	store.EXPECT().
+		AcceptBoardInvitations(gomock.Any(), db.AcceptBoardInvitationsParams{Email: "new@test.com", UserID: 4}).
+		Return(nil, unexpectedErr)
+
+	boardIds, err = model.AcceptAll(user)
+	require.EqualError(t, err, unexpectedErr.Error())
+	require.Nil(t, boardIds)
+
+	store.EXPECT().
+		AcceptBoardInvitations(gomock.Any(), db.AcceptBoardInvitationsParams{Email: "new@test.com", UserID: 4}).
+		Return([]db.BoardUser{{BoardID: 3, UserID: 4}, {BoardID: 5, UserID: 4}}, nil)
+
+	boardIds, err = model.AcceptAll(user)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3, 5}, boardIds)
+}
+
+// TestInvitationModel_Accept tests accepting an invitation with its token
+func TestInvitationModel_Accept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbInvitationModel{store: store}
+	user := &User{ID: 4, Email: "other@test.com", IsVerified: true}
+
+	tokenHash := sha256.Sum256([]byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ"))
+	params := db.AcceptBoardInvitationByHashParams{Hash: tokenHash[:], UserID: 4}
+
+	store.EXPECT().
+		AcceptBoardInvitationByHash(gomock.Any(), params).
+		Return(db.BoardUser{}, pgx.ErrNoRows)
+
+	_, err := model.Accept(user, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		AcceptBoardInvitationByHash(gomock.Any(), params).
+		Return(db.BoardUser{BoardID: 3, UserID: 4, Role: BoardRoleEditor}, nil)
+
+	boardId, err := model.Accept(user, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), boardId)
+}
@@ -6,6 +6,7 @@ package mockdata
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	data "github.com/umtdemr/wb-backend/internal/data"
@@ -49,19 +50,94 @@ func (mr *MockBoardModelMockRecorder) CreateBoard(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoard", reflect.TypeOf((*MockBoardModel)(nil).CreateBoard), arg0)
 }
 
+// Delete mocks base method.
+func (m *MockBoardModel) Delete(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBoardModelMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBoardModel)(nil).Delete), arg0)
+}
+
+// Duplicate mocks base method.
+func (m *MockBoardModel) Duplicate(arg0 int64, arg1 *data.Board) (*data.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Duplicate", arg0, arg1)
+	ret0, _ := ret[0].(*data.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Duplicate indicates an expected call of Duplicate.
+func (mr *MockBoardModelMockRecorder) Duplicate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duplicate", reflect.TypeOf((*MockBoardModel)(nil).Duplicate), arg0, arg1)
+}
+
+// Export mocks base method.
+func (m *MockBoardModel) Export(arg0 *data.BoardResult) (*data.BoardFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0)
+	ret0, _ := ret[0].(*data.BoardFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBoardModelMockRecorder) Export(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBoardModel)(nil).Export), arg0)
+}
+
 // GetAllBoards mocks base method.
-func (m *MockBoardModel) GetAllBoards(arg0 int64) ([]*data.BoardResult, error) {
+func (m *MockBoardModel) GetAllBoards(arg0 int64, arg1 data.BoardFilters) ([]*data.BoardResult, data.Metadata, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllBoards", arg0)
+	ret := m.ctrl.Call(m, "GetAllBoards", arg0, arg1)
 	ret0, _ := ret[0].([]*data.BoardResult)
+	ret1, _ := ret[1].(data.Metadata)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllBoards indicates an expected call of GetAllBoards.
+func (mr *MockBoardModelMockRecorder) GetAllBoards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBoards", reflect.TypeOf((*MockBoardModel)(nil).GetAllBoards), arg0, arg1)
+}
+
+// GetBoard mocks base method.
+func (m *MockBoardModel) GetBoard(arg0 int64, arg1 string) (*data.BoardResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", arg0, arg1)
+	ret0, _ := ret[0].(*data.BoardResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllBoards indicates an expected call of GetAllBoards.
-func (mr *MockBoardModelMockRecorder) GetAllBoards(arg0 interface{}) *gomock.Call {
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockBoardModelMockRecorder) GetBoard(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBoards", reflect.TypeOf((*MockBoardModel)(nil).GetAllBoards), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockBoardModel)(nil).GetBoard), arg0, arg1)
+}
+
+// GetBoardById mocks base method.
+func (m *MockBoardModel) GetBoardById(arg0, arg1 int64) (*data.BoardResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardById", arg0, arg1)
+	ret0, _ := ret[0].(*data.BoardResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardById indicates an expected call of GetBoardById.
+func (mr *MockBoardModelMockRecorder) GetBoardById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardById", reflect.TypeOf((*MockBoardModel)(nil).GetBoardById), arg0, arg1)
 }
 
 // GetBoardUsers mocks base method.
@@ -79,18 +155,180 @@ func (mr *MockBoardModelMockRecorder) GetBoardUsers(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardUsers", reflect.TypeOf((*MockBoardModel)(nil).GetBoardUsers), arg0)
 }
 
+// GetDeletedBoards mocks base method.
+func (m *MockBoardModel) GetDeletedBoards(arg0 int64) ([]*data.BoardResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedBoards", arg0)
+	ret0, _ := ret[0].([]*data.BoardResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedBoards indicates an expected call of GetDeletedBoards.
+func (mr *MockBoardModelMockRecorder) GetDeletedBoards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedBoards", reflect.TypeOf((*MockBoardModel)(nil).GetDeletedBoards), arg0)
+}
+
+// GetRecentBoards mocks base method.
+func (m *MockBoardModel) GetRecentBoards(arg0 int64, arg1 int) ([]*data.BoardResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentBoards", arg0, arg1)
+	ret0, _ := ret[0].([]*data.BoardResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentBoards indicates an expected call of GetRecentBoards.
+func (mr *MockBoardModelMockRecorder) GetRecentBoards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentBoards", reflect.TypeOf((*MockBoardModel)(nil).GetRecentBoards), arg0, arg1)
+}
+
+// GetSharedBoard mocks base method.
+func (m *MockBoardModel) GetSharedBoard(arg0, arg1 string) (*data.BoardResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedBoard", arg0, arg1)
+	ret0, _ := ret[0].(*data.BoardResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedBoard indicates an expected call of GetSharedBoard.
+func (mr *MockBoardModelMockRecorder) GetSharedBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedBoard", reflect.TypeOf((*MockBoardModel)(nil).GetSharedBoard), arg0, arg1)
+}
+
+// Import mocks base method.
+func (m *MockBoardModel) Import(arg0 int64, arg1 string, arg2 *data.BoardFile) (*data.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*data.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockBoardModelMockRecorder) Import(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockBoardModel)(nil).Import), arg0, arg1, arg2)
+}
+
 // InviteUser mocks base method.
-func (m *MockBoardModel) InviteUser(arg0 *data.User, arg1 int64) error {
+func (m *MockBoardModel) InviteUser(arg0 *data.User, arg1 int64, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InviteUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "InviteUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InviteUser indicates an expected call of InviteUser.
-func (mr *MockBoardModelMockRecorder) InviteUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockBoardModelMockRecorder) InviteUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUser", reflect.TypeOf((*MockBoardModel)(nil).InviteUser), arg0, arg1, arg2)
+}
+
+// MarkOpened mocks base method.
+func (m *MockBoardModel) MarkOpened(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOpened", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOpened indicates an expected call of MarkOpened.
+func (mr *MockBoardModelMockRecorder) MarkOpened(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOpened", reflect.TypeOf((*MockBoardModel)(nil).MarkOpened), arg0, arg1)
+}
+
+// MoveToFolder mocks base method.
+func (m *MockBoardModel) MoveToFolder(arg0, arg1 int64, arg2 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveToFolder indicates an expected call of MoveToFolder.
+func (mr *MockBoardModelMockRecorder) MoveToFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToFolder", reflect.TypeOf((*MockBoardModel)(nil).MoveToFolder), arg0, arg1, arg2)
+}
+
+// MoveToWorkspace mocks base method.
+func (m *MockBoardModel) MoveToWorkspace(arg0 int64, arg1 *int64) (*data.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(*data.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveToWorkspace indicates an expected call of MoveToWorkspace.
+func (mr *MockBoardModelMockRecorder) MoveToWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToWorkspace", reflect.TypeOf((*MockBoardModel)(nil).MoveToWorkspace), arg0, arg1)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockBoardModel) PurgeDeleted(arg0 time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockBoardModelMockRecorder) PurgeDeleted(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockBoardModel)(nil).PurgeDeleted), arg0)
+}
+
+// RemoveMember mocks base method.
+func (m *MockBoardModel) RemoveMember(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockBoardModelMockRecorder) RemoveMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockBoardModel)(nil).RemoveMember), arg0, arg1)
+}
+
+// Rename mocks base method.
+func (m *MockBoardModel) Rename(arg0 int64, arg1 string) (*data.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", arg0, arg1)
+	ret0, _ := ret[0].(*data.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockBoardModelMockRecorder) Rename(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockBoardModel)(nil).Rename), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockBoardModel) Restore(arg0 int64, arg1 string) (*data.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(*data.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBoardModelMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUser", reflect.TypeOf((*MockBoardModel)(nil).InviteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBoardModel)(nil).Restore), arg0, arg1)
 }
 
 // RetrieveBoard mocks base method.
@@ -107,3 +345,74 @@ func (mr *MockBoardModelMockRecorder) RetrieveBoard(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveBoard", reflect.TypeOf((*MockBoardModel)(nil).RetrieveBoard), arg0, arg1)
 }
+
+// RetrieveSharedBoard mocks base method.
+func (m *MockBoardModel) RetrieveSharedBoard(arg0, arg1 string) (*data.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveSharedBoard", arg0, arg1)
+	ret0, _ := ret[0].(*data.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveSharedBoard indicates an expected call of RetrieveSharedBoard.
+func (mr *MockBoardModelMockRecorder) RetrieveSharedBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveSharedBoard", reflect.TypeOf((*MockBoardModel)(nil).RetrieveSharedBoard), arg0, arg1)
+}
+
+// Star mocks base method.
+func (m *MockBoardModel) Star(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Star", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Star indicates an expected call of Star.
+func (mr *MockBoardModelMockRecorder) Star(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Star", reflect.TypeOf((*MockBoardModel)(nil).Star), arg0, arg1)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockBoardModel) TransferOwnership(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockBoardModelMockRecorder) TransferOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockBoardModel)(nil).TransferOwnership), arg0, arg1)
+}
+
+// Unstar mocks base method.
+func (m *MockBoardModel) Unstar(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unstar", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unstar indicates an expected call of Unstar.
+func (mr *MockBoardModelMockRecorder) Unstar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unstar", reflect.TypeOf((*MockBoardModel)(nil).Unstar), arg0, arg1)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockBoardModel) UpdateMemberRole(arg0, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockBoardModelMockRecorder) UpdateMemberRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockBoardModel)(nil).UpdateMemberRole), arg0, arg1, arg2)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: ElementModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockElementModel is a mock of ElementModel interface.
+type MockElementModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockElementModelMockRecorder
+}
+
+// MockElementModelMockRecorder is the mock recorder for MockElementModel.
+type MockElementModelMockRecorder struct {
+	mock *MockElementModel
+}
+
+// NewMockElementModel creates a new mock instance.
+func NewMockElementModel(ctrl *gomock.Controller) *MockElementModel {
+	mock := &MockElementModel{ctrl: ctrl}
+	mock.recorder = &MockElementModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockElementModel) EXPECT() *MockElementModelMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockElementModel) Create(arg0 string, arg1 int64, arg2 *data.Element) (*data.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*data.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockElementModelMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockElementModel)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockElementModel) Delete(arg0 string, arg1, arg2 int64, arg3 *int32) (*data.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*data.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockElementModelMockRecorder) Delete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockElementModel)(nil).Delete), arg0, arg1, arg2, arg3)
+}
+
+// GetAllForPage mocks base method.
+func (m *MockElementModel) GetAllForPage(arg0 int64) ([]data.Element, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllForPage", arg0)
+	ret0, _ := ret[0].([]data.Element)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllForPage indicates an expected call of GetAllForPage.
+func (mr *MockElementModelMockRecorder) GetAllForPage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllForPage", reflect.TypeOf((*MockElementModel)(nil).GetAllForPage), arg0)
+}
+
+// Reorder mocks base method.
+func (m *MockElementModel) Reorder(arg0 string, arg1, arg2 int64, arg3, arg4 int32) (*data.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*data.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockElementModelMockRecorder) Reorder(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockElementModel)(nil).Reorder), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Restore mocks base method.
+func (m *MockElementModel) Restore(arg0 string, arg1 int64, arg2 *data.Element) (*data.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*data.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockElementModelMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockElementModel)(nil).Restore), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockElementModel) Update(arg0 string, arg1, arg2 int64, arg3 int32, arg4 *data.ElementPatch) (*data.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*data.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockElementModelMockRecorder) Update(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockElementModel)(nil).Update), arg0, arg1, arg2, arg3, arg4)
+}
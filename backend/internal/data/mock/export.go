@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: ExportModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockExportModel is a mock of ExportModel interface.
+type MockExportModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportModelMockRecorder
+}
+
+// MockExportModelMockRecorder is the mock recorder for MockExportModel.
+type MockExportModelMockRecorder struct {
+	mock *MockExportModel
+}
+
+// NewMockExportModel creates a new mock instance.
+func NewMockExportModel(ctrl *gomock.Controller) *MockExportModel {
+	mock := &MockExportModel{ctrl: ctrl}
+	mock.recorder = &MockExportModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportModel) EXPECT() *MockExportModelMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockExportModel) Complete(arg0 int64, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockExportModelMockRecorder) Complete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockExportModel)(nil).Complete), arg0, arg1)
+}
+
+// Fail mocks base method.
+func (m *MockExportModel) Fail(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockExportModelMockRecorder) Fail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockExportModel)(nil).Fail), arg0)
+}
+
+// Get mocks base method.
+func (m *MockExportModel) Get(arg0 int64) (*data.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*data.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockExportModelMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockExportModel)(nil).Get), arg0)
+}
+
+// GetForUser mocks base method.
+func (m *MockExportModel) GetForUser(arg0, arg1 int64) (*data.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUser", arg0, arg1)
+	ret0, _ := ret[0].(*data.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUser indicates an expected call of GetForUser.
+func (mr *MockExportModelMockRecorder) GetForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUser", reflect.TypeOf((*MockExportModel)(nil).GetForUser), arg0, arg1)
+}
+
+// MarkEmailed mocks base method.
+func (m *MockExportModel) MarkEmailed(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailed", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailed indicates an expected call of MarkEmailed.
+func (mr *MockExportModelMockRecorder) MarkEmailed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailed", reflect.TypeOf((*MockExportModel)(nil).MarkEmailed), arg0)
+}
+
+// New mocks base method.
+func (m *MockExportModel) New(arg0 *data.Export) (*data.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", arg0)
+	ret0, _ := ret[0].(*data.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// New indicates an expected call of New.
+func (mr *MockExportModelMockRecorder) New(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockExportModel)(nil).New), arg0)
+}
+
+// Purge mocks base method.
+func (m *MockExportModel) Purge(arg0 time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockExportModelMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockExportModel)(nil).Purge), arg0)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: FolderModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockFolderModel is a mock of FolderModel interface.
+type MockFolderModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockFolderModelMockRecorder
+}
+
+// MockFolderModelMockRecorder is the mock recorder for MockFolderModel.
+type MockFolderModelMockRecorder struct {
+	mock *MockFolderModel
+}
+
+// NewMockFolderModel creates a new mock instance.
+func NewMockFolderModel(ctrl *gomock.Controller) *MockFolderModel {
+	mock := &MockFolderModel{ctrl: ctrl}
+	mock.recorder = &MockFolderModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFolderModel) EXPECT() *MockFolderModelMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFolderModel) Delete(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFolderModelMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFolderModel)(nil).Delete), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockFolderModel) GetAll(arg0 int64) ([]data.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]data.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockFolderModelMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockFolderModel)(nil).GetAll), arg0)
+}
+
+// New mocks base method.
+func (m *MockFolderModel) New(arg0 int64, arg1 string) (*data.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", arg0, arg1)
+	ret0, _ := ret[0].(*data.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// New indicates an expected call of New.
+func (mr *MockFolderModelMockRecorder) New(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockFolderModel)(nil).New), arg0, arg1)
+}
+
+// Rename mocks base method.
+func (m *MockFolderModel) Rename(arg0, arg1 int64, arg2 string) (*data.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*data.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockFolderModelMockRecorder) Rename(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockFolderModel)(nil).Rename), arg0, arg1, arg2)
+}
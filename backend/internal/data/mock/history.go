@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: HistoryModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockHistoryModel is a mock of HistoryModel interface.
+type MockHistoryModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryModelMockRecorder
+}
+
+// MockHistoryModelMockRecorder is the mock recorder for MockHistoryModel.
+type MockHistoryModelMockRecorder struct {
+	mock *MockHistoryModel
+}
+
+// NewMockHistoryModel creates a new mock instance.
+func NewMockHistoryModel(ctrl *gomock.Controller) *MockHistoryModel {
+	mock := &MockHistoryModel{ctrl: ctrl}
+	mock.recorder = &MockHistoryModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryModel) EXPECT() *MockHistoryModelMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockHistoryModel) Get(arg0 string, arg1 int64) (*data.History, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*data.History)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHistoryModelMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHistoryModel)(nil).Get), arg0, arg1)
+}
+
+// RestorePage mocks base method.
+func (m *MockHistoryModel) RestorePage(arg0 string, arg1, arg2, arg3 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestorePage indicates an expected call of RestorePage.
+func (mr *MockHistoryModelMockRecorder) RestorePage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePage", reflect.TypeOf((*MockHistoryModel)(nil).RestorePage), arg0, arg1, arg2, arg3)
+}
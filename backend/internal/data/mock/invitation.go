@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: InvitationModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockInvitationModel is a mock of InvitationModel interface.
+type MockInvitationModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationModelMockRecorder
+}
+
+// MockInvitationModelMockRecorder is the mock recorder for MockInvitationModel.
+type MockInvitationModelMockRecorder struct {
+	mock *MockInvitationModel
+}
+
+// NewMockInvitationModel creates a new mock instance.
+func NewMockInvitationModel(ctrl *gomock.Controller) *MockInvitationModel {
+	mock := &MockInvitationModel{ctrl: ctrl}
+	mock.recorder = &MockInvitationModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationModel) EXPECT() *MockInvitationModelMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockInvitationModel) Accept(arg0 *data.User, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockInvitationModelMockRecorder) Accept(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockInvitationModel)(nil).Accept), arg0, arg1)
+}
+
+// AcceptAll mocks base method.
+func (m *MockInvitationModel) AcceptAll(arg0 *data.User) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptAll", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptAll indicates an expected call of AcceptAll.
+func (mr *MockInvitationModelMockRecorder) AcceptAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptAll", reflect.TypeOf((*MockInvitationModel)(nil).AcceptAll), arg0)
+}
+
+// New mocks base method.
+func (m *MockInvitationModel) New(arg0 int64, arg1, arg2 string, arg3 int64) (*data.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*data.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// New indicates an expected call of New.
+func (mr *MockInvitationModelMockRecorder) New(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockInvitationModel)(nil).New), arg0, arg1, arg2, arg3)
+}
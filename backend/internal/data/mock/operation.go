@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: OperationModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockOperationModel is a mock of OperationModel interface.
+type MockOperationModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationModelMockRecorder
+}
+
+// MockOperationModelMockRecorder is the mock recorder for MockOperationModel.
+type MockOperationModelMockRecorder struct {
+	mock *MockOperationModel
+}
+
+// NewMockOperationModel creates a new mock instance.
+func NewMockOperationModel(ctrl *gomock.Controller) *MockOperationModel {
+	mock := &MockOperationModel{ctrl: ctrl}
+	mock.recorder = &MockOperationModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationModel) EXPECT() *MockOperationModelMockRecorder {
+	return m.recorder
+}
+
+// GetAllAfter mocks base method.
+func (m *MockOperationModel) GetAllAfter(arg0 string, arg1 int64) ([]data.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllAfter", arg0, arg1)
+	ret0, _ := ret[0].([]data.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllAfter indicates an expected call of GetAllAfter.
+func (mr *MockOperationModelMockRecorder) GetAllAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAfter", reflect.TypeOf((*MockOperationModel)(nil).GetAllAfter), arg0, arg1)
+}
+
+// GetCurrentRevision mocks base method.
+func (m *MockOperationModel) GetCurrentRevision(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentRevision", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentRevision indicates an expected call of GetCurrentRevision.
+func (mr *MockOperationModelMockRecorder) GetCurrentRevision(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentRevision", reflect.TypeOf((*MockOperationModel)(nil).GetCurrentRevision), arg0)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: PageModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockPageModel is a mock of PageModel interface.
+type MockPageModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockPageModelMockRecorder
+}
+
+// MockPageModelMockRecorder is the mock recorder for MockPageModel.
+type MockPageModelMockRecorder struct {
+	mock *MockPageModel
+}
+
+// NewMockPageModel creates a new mock instance.
+func NewMockPageModel(ctrl *gomock.Controller) *MockPageModel {
+	mock := &MockPageModel{ctrl: ctrl}
+	mock.recorder = &MockPageModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPageModel) EXPECT() *MockPageModelMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPageModel) Create(arg0, arg1 int64, arg2 string) (*data.Page, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*data.Page)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPageModelMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPageModel)(nil).Create), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockPageModel) Delete(arg0, arg1, arg2 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPageModelMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPageModel)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Duplicate mocks base method.
+func (m *MockPageModel) Duplicate(arg0, arg1, arg2 int64) (*data.Page, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Duplicate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*data.Page)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Duplicate indicates an expected call of Duplicate.
+func (mr *MockPageModelMockRecorder) Duplicate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duplicate", reflect.TypeOf((*MockPageModel)(nil).Duplicate), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockPageModel) Get(arg0, arg1 int64) (*data.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*data.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPageModelMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPageModel)(nil).Get), arg0, arg1)
+}
+
+// GetAll mocks base method.
+func (m *MockPageModel) GetAll(arg0 int64) ([]data.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]data.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPageModelMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPageModel)(nil).GetAll), arg0)
+}
+
+// Rename mocks base method.
+func (m *MockPageModel) Rename(arg0, arg1, arg2 int64, arg3 string) (*data.Page, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*data.Page)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockPageModelMockRecorder) Rename(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockPageModel)(nil).Rename), arg0, arg1, arg2, arg3)
+}
+
+// Reorder mocks base method.
+func (m *MockPageModel) Reorder(arg0, arg1 int64, arg2 []int64) ([]data.Page, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]data.Page)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockPageModelMockRecorder) Reorder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockPageModel)(nil).Reorder), arg0, arg1, arg2)
+}
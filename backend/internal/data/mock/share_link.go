@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: ShareLinkModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockShareLinkModel is a mock of ShareLinkModel interface.
+type MockShareLinkModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareLinkModelMockRecorder
+}
+
+// MockShareLinkModelMockRecorder is the mock recorder for MockShareLinkModel.
+type MockShareLinkModelMockRecorder struct {
+	mock *MockShareLinkModel
+}
+
+// NewMockShareLinkModel creates a new mock instance.
+func NewMockShareLinkModel(ctrl *gomock.Controller) *MockShareLinkModel {
+	mock := &MockShareLinkModel{ctrl: ctrl}
+	mock.recorder = &MockShareLinkModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareLinkModel) EXPECT() *MockShareLinkModelMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockShareLinkModel) GetAll(arg0 int64) ([]data.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]data.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockShareLinkModelMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockShareLinkModel)(nil).GetAll), arg0)
+}
+
+// New mocks base method.
+func (m *MockShareLinkModel) New(arg0 int64, arg1 string, arg2 *time.Time, arg3 int64) (*data.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*data.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// New indicates an expected call of New.
+func (mr *MockShareLinkModelMockRecorder) New(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockShareLinkModel)(nil).New), arg0, arg1, arg2, arg3)
+}
+
+// Revoke mocks base method.
+func (m *MockShareLinkModel) Revoke(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockShareLinkModelMockRecorder) Revoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockShareLinkModel)(nil).Revoke), arg0, arg1)
+}
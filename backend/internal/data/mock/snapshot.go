@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: SnapshotModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockSnapshotModel is a mock of SnapshotModel interface.
+type MockSnapshotModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotModelMockRecorder
+}
+
+// MockSnapshotModelMockRecorder is the mock recorder for MockSnapshotModel.
+type MockSnapshotModelMockRecorder struct {
+	mock *MockSnapshotModel
+}
+
+// NewMockSnapshotModel creates a new mock instance.
+func NewMockSnapshotModel(ctrl *gomock.Controller) *MockSnapshotModel {
+	mock := &MockSnapshotModel{ctrl: ctrl}
+	mock.recorder = &MockSnapshotModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotModel) EXPECT() *MockSnapshotModelMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSnapshotModel) Create(arg0 string) ([]data.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].([]data.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSnapshotModelMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSnapshotModel)(nil).Create), arg0)
+}
+
+// GetLatest mocks base method.
+func (m *MockSnapshotModel) GetLatest(arg0 string) ([]data.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", arg0)
+	ret0, _ := ret[0].([]data.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockSnapshotModelMockRecorder) GetLatest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockSnapshotModel)(nil).GetLatest), arg0)
+}
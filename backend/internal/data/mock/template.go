@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: TemplateModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockTemplateModel is a mock of TemplateModel interface.
+type MockTemplateModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateModelMockRecorder
+}
+
+// MockTemplateModelMockRecorder is the mock recorder for MockTemplateModel.
+type MockTemplateModelMockRecorder struct {
+	mock *MockTemplateModel
+}
+
+// NewMockTemplateModel creates a new mock instance.
+func NewMockTemplateModel(ctrl *gomock.Controller) *MockTemplateModel {
+	mock := &MockTemplateModel{ctrl: ctrl}
+	mock.recorder = &MockTemplateModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateModel) EXPECT() *MockTemplateModelMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockTemplateModel) GetAll(arg0 int64) ([]data.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].([]data.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTemplateModelMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTemplateModel)(nil).GetAll), arg0)
+}
+
+// Mark mocks base method.
+func (m *MockTemplateModel) Mark(arg0 int64, arg1 string) (*data.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mark", arg0, arg1)
+	ret0, _ := ret[0].(*data.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Mark indicates an expected call of Mark.
+func (mr *MockTemplateModelMockRecorder) Mark(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mark", reflect.TypeOf((*MockTemplateModel)(nil).Mark), arg0, arg1)
+}
+
+// Unmark mocks base method.
+func (m *MockTemplateModel) Unmark(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmark", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmark indicates an expected call of Unmark.
+func (mr *MockTemplateModelMockRecorder) Unmark(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmark", reflect.TypeOf((*MockTemplateModel)(nil).Unmark), arg0)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: ThumbnailModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockThumbnailModel is a mock of ThumbnailModel interface.
+type MockThumbnailModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockThumbnailModelMockRecorder
+}
+
+// MockThumbnailModelMockRecorder is the mock recorder for MockThumbnailModel.
+type MockThumbnailModelMockRecorder struct {
+	mock *MockThumbnailModel
+}
+
+// NewMockThumbnailModel creates a new mock instance.
+func NewMockThumbnailModel(ctrl *gomock.Controller) *MockThumbnailModel {
+	mock := &MockThumbnailModel{ctrl: ctrl}
+	mock.recorder = &MockThumbnailModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThumbnailModel) EXPECT() *MockThumbnailModelMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockThumbnailModel) Get(arg0 int64) (*data.Thumbnail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*data.Thumbnail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockThumbnailModelMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockThumbnailModel)(nil).Get), arg0)
+}
+
+// GetSource mocks base method.
+func (m *MockThumbnailModel) GetSource(arg0 string) (*data.ThumbnailSource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSource", arg0)
+	ret0, _ := ret[0].(*data.ThumbnailSource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSource indicates an expected call of GetSource.
+func (mr *MockThumbnailModelMockRecorder) GetSource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSource", reflect.TypeOf((*MockThumbnailModel)(nil).GetSource), arg0)
+}
+
+// Save mocks base method.
+func (m *MockThumbnailModel) Save(arg0 *data.Thumbnail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockThumbnailModelMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockThumbnailModel)(nil).Save), arg0)
+}
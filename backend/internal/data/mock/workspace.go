@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/umtdemr/wb-backend/internal/data (interfaces: WorkspaceModel)
+
+// Package mockdata is a generated GoMock package.
+package mockdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	data "github.com/umtdemr/wb-backend/internal/data"
+)
+
+// MockWorkspaceModel is a mock of WorkspaceModel interface.
+type MockWorkspaceModel struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceModelMockRecorder
+}
+
+// MockWorkspaceModelMockRecorder is the mock recorder for MockWorkspaceModel.
+type MockWorkspaceModelMockRecorder struct {
+	mock *MockWorkspaceModel
+}
+
+// NewMockWorkspaceModel creates a new mock instance.
+func NewMockWorkspaceModel(ctrl *gomock.Controller) *MockWorkspaceModel {
+	mock := &MockWorkspaceModel{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceModelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceModel) EXPECT() *MockWorkspaceModelMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockWorkspaceModel) AddMember(arg0, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockWorkspaceModelMockRecorder) AddMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockWorkspaceModel)(nil).AddMember), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockWorkspaceModel) Delete(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWorkspaceModelMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWorkspaceModel)(nil).Delete), arg0)
+}
+
+// GetAllForUser mocks base method.
+func (m *MockWorkspaceModel) GetAllForUser(arg0 int64) ([]data.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllForUser", arg0)
+	ret0, _ := ret[0].([]data.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllForUser indicates an expected call of GetAllForUser.
+func (mr *MockWorkspaceModelMockRecorder) GetAllForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllForUser", reflect.TypeOf((*MockWorkspaceModel)(nil).GetAllForUser), arg0)
+}
+
+// GetBoards mocks base method.
+func (m *MockWorkspaceModel) GetBoards(arg0 int64) ([]*data.BoardResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoards", arg0)
+	ret0, _ := ret[0].([]*data.BoardResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoards indicates an expected call of GetBoards.
+func (mr *MockWorkspaceModelMockRecorder) GetBoards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoards", reflect.TypeOf((*MockWorkspaceModel)(nil).GetBoards), arg0)
+}
+
+// GetMemberRole mocks base method.
+func (m *MockWorkspaceModel) GetMemberRole(arg0, arg1 int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberRole", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberRole indicates an expected call of GetMemberRole.
+func (mr *MockWorkspaceModelMockRecorder) GetMemberRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberRole", reflect.TypeOf((*MockWorkspaceModel)(nil).GetMemberRole), arg0, arg1)
+}
+
+// GetMembers mocks base method.
+func (m *MockWorkspaceModel) GetMembers(arg0 int64) ([]data.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", arg0)
+	ret0, _ := ret[0].([]data.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockWorkspaceModelMockRecorder) GetMembers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockWorkspaceModel)(nil).GetMembers), arg0)
+}
+
+// New mocks base method.
+func (m *MockWorkspaceModel) New(arg0 int64, arg1 string) (*data.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", arg0, arg1)
+	ret0, _ := ret[0].(*data.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// New indicates an expected call of New.
+func (mr *MockWorkspaceModelMockRecorder) New(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockWorkspaceModel)(nil).New), arg0, arg1)
+}
+
+// RemoveMember mocks base method.
+func (m *MockWorkspaceModel) RemoveMember(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockWorkspaceModelMockRecorder) RemoveMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockWorkspaceModel)(nil).RemoveMember), arg0, arg1)
+}
+
+// Rename mocks base method.
+func (m *MockWorkspaceModel) Rename(arg0 int64, arg1 string) (*data.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", arg0, arg1)
+	ret0, _ := ret[0].(*data.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockWorkspaceModelMockRecorder) Rename(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockWorkspaceModel)(nil).Rename), arg0, arg1)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockWorkspaceModel) UpdateMemberRole(arg0, arg1 int64, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockWorkspaceModelMockRecorder) UpdateMemberRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockWorkspaceModel)(nil).UpdateMemberRole), arg0, arg1, arg2)
+}
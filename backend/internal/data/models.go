@@ -16,6 +16,18 @@ type Models struct {
 	Tokens      TokenModel
 	Permissions PermissionModel
 	Boards      BoardModel
+	Pages       PageModel
+	Elements    ElementModel
+	Operations  OperationModel
+	Snapshots   SnapshotModel
+	History     HistoryModel
+	Invitations InvitationModel
+	ShareLinks  ShareLinkModel
+	Folders     FolderModel
+	Templates   TemplateModel
+	Workspaces  WorkspaceModel
+	Exports     ExportModel
+	Thumbnails  ThumbnailModel
 }
 
 // NewModels initiates and returns Models.
@@ -26,5 +38,17 @@ func NewModels(dbStore db.Store) Models {
 		Tokens:      &DbTokenModel{dbStore},
 		Permissions: &DbPermissionModel{dbStore},
 		Boards:      &DbBoardModel{dbStore},
+		Pages:       &DbPageModel{dbStore},
+		Elements:    &DbElementModel{dbStore},
+		Operations:  &DbOperationModel{dbStore},
+		Snapshots:   &DbSnapshotModel{dbStore},
+		History:     &DbHistoryModel{dbStore},
+		Invitations: &DbInvitationModel{dbStore},
+		ShareLinks:  &DbShareLinkModel{dbStore},
+		Folders:     &DbFolderModel{dbStore},
+		Templates:   &DbTemplateModel{dbStore},
+		Workspaces:  &DbWorkspaceModel{dbStore},
+		Exports:     &DbExportModel{dbStore},
+		Thumbnails:  &DbThumbnailModel{dbStore},
 	}
 }
@@ -0,0 +1,182 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/umtdemr/wb-backend/internal/crdt"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"strconv"
+	"time"
+)
+
+// MaxReplayOperations is the maximum number of operations that a client can catch up with.
+// Clients that missed more operations than this should reload the whole board instead.
+const MaxReplayOperations = 500
+
+// Operation represents db.BoardOperation. It is a single mutation in the board's operation log
+type Operation struct {
+	Revision  int64     `json:"revision"`
+	UserId    int64     `json:"user_id"`
+	Type      string    `json:"type"`
+	PageId    int64     `json:"page_id"`
+	ElementId int64     `json:"element_id"`
+	Element   *Element  `json:"element"`
+	CreatedAt time.Time `json:"created_at"`
+	// Page is the page state after the operation. It is only set for the page operations, which have no element.
+	Page *Page `json:"page,omitempty"`
+	// Previous is the element state before the operation. It is only known for the updates made right now.
+	Previous *Element `json:"-"`
+}
+
+// copyFromDbOperation copies data from db package to repository
+func (o *Operation) copyFromDbOperation(dbOperation *db.BoardOperation) error {
+	o.Revision = dbOperation.Revision
+	o.UserId = dbOperation.UserID.Int64
+	o.Type = dbOperation.Type
+	o.PageId = dbOperation.PageID
+	o.ElementId = dbOperation.ElementID
+	o.CreatedAt = dbOperation.CreatedAt.Time
+
+	if isPageOperation(dbOperation.Type) {
+		var dbPage db.BoardPage
+		if err := json.Unmarshal(dbOperation.Data, &dbPage); err != nil {
+			return err
+		}
+
+		o.Page = &Page{}
+		o.Page.copyFromDbPage(&dbPage)
+		return nil
+	}
+
+	// operation data is the element state right after the operation
+	var dbElement db.BoardElement
+	if err := json.Unmarshal(dbOperation.Data, &dbElement); err != nil {
+		return err
+	}
+
+	o.Element = &Element{}
+	o.Element.copyFromDbElement(&dbElement)
+
+	return nil
+}
+
+// isPageOperation reports whether the operation changes a page instead of an element
+func isPageOperation(operationType string) bool {
+	switch operationType {
+	case db.OperationPageCreate, db.OperationPageRename, db.OperationPageReorder, db.OperationPageDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// newOperationFromTx creates an Operation from an element operation transaction result
+func newOperationFromTx(result *db.ElementOperationTxResult) *Operation {
+	element := &Element{}
+	element.copyFromDbElement(&result.Element)
+
+	operation := &Operation{
+		Revision:  result.Operation.Revision,
+		UserId:    result.Operation.UserID.Int64,
+		Type:      result.Operation.Type,
+		PageId:    result.Operation.PageID,
+		ElementId: result.Operation.ElementID,
+		Element:   element,
+		CreatedAt: result.Operation.CreatedAt.Time,
+	}
+
+	if result.Previous.ID != 0 {
+		operation.Previous = &Element{}
+		operation.Previous.copyFromDbElement(&result.Previous)
+	}
+
+	return operation
+}
+
+// CrdtOperation converts the operation to a crdt.Operation. Revisions are given by the database in order,
+// so the revision is used as the lamport counter and the operations of a board are ordered in the same way on every node.
+// It is built after the operation is persisted, the persistence itself does not go through the crdt package.
+func (o *Operation) CrdtOperation() (crdt.Operation, error) {
+	operation := crdt.Operation{
+		ElementId: o.ElementId,
+		Timestamp: crdt.Timestamp{Counter: o.Revision, Node: strconv.FormatInt(o.UserId, 10)},
+		Deleted:   o.Type == db.OperationElementDelete,
+	}
+
+	if o.Element == nil {
+		return operation, nil
+	}
+
+	// element state is the whole state after the operation, every field is written with the operation
+	elementData, err := json.Marshal(o.Element)
+	if err != nil {
+		return crdt.Operation{}, err
+	}
+
+	if err := json.Unmarshal(elementData, &operation.Fields); err != nil {
+		return crdt.Operation{}, err
+	}
+
+	// z order is kept in the sequence of the document
+	delete(operation.Fields, "z_index")
+	if !operation.Deleted {
+		zIndex := o.Element.ZIndex
+		operation.ZIndex = &zIndex
+	}
+
+	return operation, nil
+}
+
+type OperationModel interface {
+	GetCurrentRevision(boardSlugId string) (int64, error)
+	GetAllAfter(boardSlugId string, revision int64) ([]Operation, error)
+}
+
+type DbOperationModel struct {
+	store db.Store
+}
+
+// Ensure DbOperationModel implements OperationModel interface
+var _ OperationModel = (*DbOperationModel)(nil)
+
+// GetCurrentRevision returns the latest revision of the board
+func (m *DbOperationModel) GetCurrentRevision(boardSlugId string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	revision, err := m.store.GetBoardRevisionBySlugId(ctx, boardSlugId)
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return 0, ErrRecordNotFound
+		default:
+			return 0, err
+		}
+	}
+
+	return revision, nil
+}
+
+// GetAllAfter returns the operations after the given revision, at most MaxReplayOperations of them
+func (m *DbOperationModel) GetAllAfter(boardSlugId string, revision int64) ([]Operation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbOperations, err := m.store.GetBoardOperationsAfterRevision(ctx, db.GetBoardOperationsAfterRevisionParams{
+		SlugID:   boardSlugId,
+		Revision: revision,
+		Limit:    MaxReplayOperations,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]Operation, len(dbOperations))
+	for i := range dbOperations {
+		if err := operations[i].copyFromDbOperation(&dbOperations[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return operations, nil
+}
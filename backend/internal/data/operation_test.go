@@ -0,0 +1,155 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/crdt"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"testing"
+)
+
+// TestOperationModel_GetCurrentRevision tests retrieving the revision of a board
+func TestOperationModel_GetCurrentRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbOperationModel{store: store}
+
+	store.EXPECT().
+		GetBoardRevisionBySlugId(gomock.Any(), "missing").
+		Return(int64(0), pgx.ErrNoRows)
+
+	_, err := model.GetCurrentRevision("missing")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		GetBoardRevisionBySlugId(gomock.Any(), "test").
+		Return(int64(12), nil)
+
+	revision, err := model.GetCurrentRevision("test")
+	require.NoError(t, err)
+	require.Equal(t, int64(12), revision)
+}
+
+// TestOperationModel_GetAllAfter tests retrieving missed operations with their element state
+func TestOperationModel_GetAllAfter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbOperationModel{store: store}
+
+	testCases := []struct {
+		name          string
+		buildStub     func()
+		checkResponse func(t *testing.T, operations []Operation, err error)
+	}{
+		{
+			name: "Unexpected error",
+			buildStub: func() {
+				store.EXPECT().
+					GetBoardOperationsAfterRevision(gomock.Any(), gomock.Any()).
+					Return(nil, unexpectedErr)
+			},
+			checkResponse: func(t *testing.T, operations []Operation, err error) {
+				require.EqualError(t, err, unexpectedErr.Error())
+				require.Empty(t, operations)
+			},
+		},
+		{
+			name: "Corrupted operation data",
+			buildStub: func() {
+				store.EXPECT().
+					GetBoardOperationsAfterRevision(gomock.Any(), gomock.Any()).
+					Return([]db.BoardOperation{{Revision: 3, Data: []byte("{")}}, nil)
+			},
+			checkResponse: func(t *testing.T, operations []Operation, err error) {
+				require.Error(t, err)
+				require.Empty(t, operations)
+			},
+		},
+		{
+			name: "Successful",
+			buildStub: func() {
+				store.EXPECT().
+					GetBoardOperationsAfterRevision(gomock.Any(), gomock.Eq(db.GetBoardOperationsAfterRevisionParams{
+						SlugID:   "test",
+						Revision: 2,
+						Limit:    MaxReplayOperations,
+					})).
+					Return([]db.BoardOperation{
+						{
+							Revision:  3,
+							Type:      db.OperationElementCreate,
+							PageID:    1,
+							ElementID: 9,
+							Data:      []byte(`{"id": 9, "page_id": 1, "type": "rectangle", "x": 5}`),
+						},
+						{
+							Revision: 4,
+							Type:     db.OperationPageRename,
+							PageID:   1,
+							Data:     []byte(`{"id": 1, "board_id": 2, "name": "renamed", "position": 0}`),
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, operations []Operation, err error) {
+				require.NoError(t, err)
+				require.Len(t, operations, 2)
+				require.Equal(t, int64(3), operations[0].Revision)
+				require.Equal(t, int64(9), operations[0].Element.Id)
+				require.Equal(t, ElementTypeRectangle, operations[0].Element.Type)
+				require.Equal(t, float64(5), operations[0].Element.X)
+				require.Nil(t, operations[0].Page)
+				// page operations carry the page instead of an element
+				require.Nil(t, operations[1].Element)
+				require.Equal(t, "renamed", operations[1].Page.Name)
+				require.Equal(t, int64(2), operations[1].Page.BoardId)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+
+			operations, err := model.GetAllAfter("test", 2)
+			tc.checkResponse(t, operations, err)
+		})
+	}
+}
+
+// TestOperation_CrdtOperation tests converting operations to be applied on a crdt.Document
+func TestOperation_CrdtOperation(t *testing.T) {
+	created := &Operation{
+		Revision:  1,
+		UserId:    2,
+		Type:      db.OperationElementCreate,
+		ElementId: 5,
+		Element:   &Element{Id: 5, Type: ElementTypeRectangle, X: 10, ZIndex: 3},
+	}
+	deleted := &Operation{
+		Revision:  2,
+		UserId:    3,
+		Type:      db.OperationElementDelete,
+		ElementId: 5,
+		Element:   &Element{Id: 5, Type: ElementTypeRectangle, X: 10, ZIndex: 3},
+	}
+
+	createdOperation, err := created.CrdtOperation()
+	require.NoError(t, err)
+	require.Equal(t, crdt.Timestamp{Counter: 1, Node: "2"}, createdOperation.Timestamp)
+	require.Equal(t, int32(3), *createdOperation.ZIndex)
+	require.JSONEq(t, "10", string(createdOperation.Fields["x"]))
+	require.NotContains(t, createdOperation.Fields, "z_index")
+
+	deletedOperation, err := deleted.CrdtOperation()
+	require.NoError(t, err)
+	require.True(t, deletedOperation.Deleted)
+	require.Nil(t, deletedOperation.ZIndex)
+
+	// delete wins whatever order the operations are applied in
+	doc := crdt.NewDocument()
+	require.True(t, doc.Apply(deletedOperation))
+	require.False(t, doc.Apply(createdOperation))
+	require.Empty(t, doc.Order())
+}
@@ -0,0 +1,287 @@
+package data
+
+import (
+	"context"
+	"errors"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	// ErrLastPage is returned when the only page of a board is tried to be deleted
+	ErrLastPage = errors.New("last page of the board")
+	// ErrInvalidPageOrder is returned when the new order does not contain every page of the board exactly once
+	ErrInvalidPageOrder = errors.New("invalid page order")
+)
+
+// Page represents db.BoardPage
+type Page struct {
+	Id        int64     `json:"id"`
+	BoardId   int64     `json:"board_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	// Position is the place of the page in the page tabs of the board, starting from 0
+	Position int32     `json:"position"`
+	Elements []Element `json:"elements"`
+}
+
+// copyFromDbPage copies data from db package to repository
+func (p *Page) copyFromDbPage(dbPage *db.BoardPage) {
+	p.Id = int64(dbPage.ID)
+	p.BoardId = dbPage.BoardID
+	p.Name = dbPage.Name
+	p.CreatedAt = dbPage.CreatedAt.Time
+	p.Position = dbPage.Position
+}
+
+// DuplicatePageName returns the name of a copy of the page, shortened to fit the page name rules
+func DuplicatePageName(name string) string {
+	copyName := []rune(duplicateNamePrefix + name)
+	if len(copyName) > 100 {
+		copyName = copyName[:100]
+	}
+
+	return string(copyName)
+}
+
+func ValidatePage(v *validator.Validator, page *Page) {
+	pageNameLen := utf8.RuneCountInString(page.Name)
+	v.Check(pageNameLen >= 1 && pageNameLen <= 100, "name", "must be between 1 and 100")
+}
+
+type PageModel interface {
+	Get(boardId int64, pageId int64) (*Page, error)
+	GetAll(boardId int64) ([]Page, error)
+	Create(boardId int64, userId int64, name string) (*Page, int64, error)
+	Rename(boardId int64, userId int64, pageId int64, name string) (*Page, int64, error)
+	Reorder(boardId int64, userId int64, pageIds []int64) ([]Page, int64, error)
+	Delete(boardId int64, userId int64, pageId int64) (int64, error)
+	Duplicate(boardId int64, userId int64, pageId int64) (*Page, int64, error)
+}
+
+type DbPageModel struct {
+	store db.Store
+}
+
+// Ensure DbPageModel implements PageModel interface
+var _ PageModel = (*DbPageModel)(nil)
+
+// Get returns the page of the board with its elements
+func (m *DbPageModel) Get(boardId int64, pageId int64) (*Page, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	page, err := m.getPage(ctx, boardId, pageId)
+	if err != nil {
+		return nil, err
+	}
+
+	dbElements, err := m.store.GetBoardElementsByPageId(ctx, page.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	page.Elements = make([]Element, len(dbElements))
+	for i := range dbElements {
+		page.Elements[i].copyFromDbElement(&dbElements[i])
+	}
+
+	return page, nil
+}
+
+// GetAll returns all the pages of the board with their elements
+func (m *DbPageModel) GetAll(boardId int64) ([]Page, error) {
+	return loadBoardPages(m.store, boardId)
+}
+
+// Create adds a new empty page after the last page of the board and returns it with the new revision of the board
+func (m *DbPageModel) Create(boardId int64, userId int64, name string) (*Page, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.store.CreatePageTx(ctx, db.CreatePageTxParams{
+		BoardId: boardId,
+		UserId:  userId,
+		Name:    name,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, 0, ErrRecordNotFound
+		default:
+			return nil, 0, err
+		}
+	}
+
+	page := &Page{Elements: []Element{}}
+	page.copyFromDbPage(&result.Page)
+
+	return page, result.Operation.Revision, nil
+}
+
+// Rename changes the name of the page of the board and returns it with the new revision of the board
+func (m *DbPageModel) Rename(boardId int64, userId int64, pageId int64, name string) (*Page, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.store.RenamePageTx(ctx, db.RenamePageTxParams{
+		BoardId: boardId,
+		UserId:  userId,
+		PageId:  pageId,
+		Name:    name,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, 0, ErrRecordNotFound
+		default:
+			return nil, 0, err
+		}
+	}
+
+	var page Page
+	page.copyFromDbPage(&result.Page)
+
+	return &page, result.Operation.Revision, nil
+}
+
+// Reorder puts the pages of the board in the given order. pageIds must contain every page of the board exactly once,
+// otherwise ErrInvalidPageOrder is returned. The pages are returned in their new order without their elements,
+// with the new revision of the board.
+func (m *DbPageModel) Reorder(boardId int64, userId int64, pageIds []int64) ([]Page, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbPages, err := m.store.GetBoardPageByBoardId(ctx, boardId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(pageIds) != len(dbPages) {
+		return nil, 0, ErrInvalidPageOrder
+	}
+
+	pagesById := make(map[int64]db.GetBoardPageByBoardIdRow, len(dbPages))
+	for _, dbPage := range dbPages {
+		pagesById[int64(dbPage.ID)] = dbPage
+	}
+
+	orderedIds := make([]int32, len(pageIds))
+	for i, pageId := range pageIds {
+		dbPage, ok := pagesById[pageId]
+		if !ok {
+			return nil, 0, ErrInvalidPageOrder
+		}
+		// the same page cannot be used twice
+		delete(pagesById, pageId)
+
+		orderedIds[i] = dbPage.ID
+	}
+
+	result, err := m.store.ReorderPagesTx(ctx, db.ReorderPagesTxParams{
+		BoardId: boardId,
+		UserId:  userId,
+		PageIds: orderedIds,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			// one of the pages is deleted in the meantime
+			return nil, 0, ErrInvalidPageOrder
+		default:
+			return nil, 0, err
+		}
+	}
+
+	pages := make([]Page, len(result.Pages))
+	for i := range result.Pages {
+		pages[i].copyFromDbPage(&result.Pages[i])
+	}
+
+	return pages, result.Operations[len(result.Operations)-1].Revision, nil
+}
+
+// Delete removes the page of the board with its elements and returns the new revision of the board.
+// The last page of a board cannot be deleted.
+func (m *DbPageModel) Delete(boardId int64, userId int64, pageId int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.store.DeletePageTx(ctx, db.DeletePageTxParams{
+		BoardId: boardId,
+		UserId:  userId,
+		PageId:  pageId,
+	})
+	if err == nil {
+		return result.Operation.Revision, nil
+	}
+
+	if !db.IsErrNoRows(err) {
+		return 0, err
+	}
+
+	// the page is either not found or the only page of the board
+	if _, err = m.getPage(ctx, boardId, pageId); err != nil {
+		return 0, err
+	}
+
+	return 0, ErrLastPage
+}
+
+// Duplicate creates a copy of the page with its elements after the last page of the board.
+// The copy is returned with its elements and the new revision of the board.
+func (m *DbPageModel) Duplicate(boardId int64, userId int64, pageId int64) (*Page, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	page, err := m.getPage(ctx, boardId, pageId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result, err := m.store.DuplicateBoardPageTx(ctx, db.DuplicateBoardPageTxParams{
+		BoardId:      boardId,
+		UserId:       userId,
+		SourcePageId: pageId,
+		Name:         DuplicatePageName(page.Name),
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, 0, ErrRecordNotFound
+		default:
+			return nil, 0, err
+		}
+	}
+
+	duplicated := &Page{Elements: make([]Element, len(result.Elements))}
+	duplicated.copyFromDbPage(&result.Page)
+	for i := range result.Elements {
+		duplicated.Elements[i].copyFromDbElement(&result.Elements[i])
+	}
+
+	return duplicated, result.Operations[len(result.Operations)-1].Revision, nil
+}
+
+// getPage returns the page of the board
+func (m *DbPageModel) getPage(ctx context.Context, boardId int64, pageId int64) (*Page, error) {
+	dbPage, err := m.store.GetBoardPage(ctx, db.GetBoardPageParams{
+		ID:      int32(pageId),
+		BoardID: boardId,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var page Page
+	page.copyFromDbPage(&dbPage)
+
+	return &page, nil
+}
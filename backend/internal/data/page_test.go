@@ -0,0 +1,247 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"strings"
+	"testing"
+)
+
+// TestValidatePage tests the validation rules of the page names
+func TestValidatePage(t *testing.T) {
+	v := validator.New()
+	ValidatePage(v, &Page{Name: ""})
+	require.Contains(t, v.Errors, "name")
+
+	v = validator.New()
+	ValidatePage(v, &Page{Name: strings.Repeat("a", 101)})
+	require.Contains(t, v.Errors, "name")
+
+	v = validator.New()
+	ValidatePage(v, &Page{Name: "Page 2"})
+	require.True(t, v.Valid())
+
+	require.Equal(t, "Copy of Page 2", DuplicatePageName("Page 2"))
+	require.Len(t, []rune(DuplicatePageName(strings.Repeat("ş", 100))), 100)
+}
+
+// TestPageModel_Get tests getting a page of a board with its elements
+func TestPageModel_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	pageModel := DbPageModel{store: store}
+
+	getParams := db.GetBoardPageParams{ID: 8, BoardID: 3}
+
+	store.EXPECT().
+		GetBoardPage(gomock.Any(), gomock.Eq(getParams)).
+		Return(db.BoardPage{}, pgx.ErrNoRows)
+	_, err := pageModel.Get(3, 8)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		GetBoardPage(gomock.Any(), gomock.Eq(getParams)).
+		Return(db.BoardPage{ID: 8, BoardID: 3, Name: "Page 1"}, nil)
+	store.EXPECT().
+		GetBoardElementsByPageId(gomock.Any(), gomock.Eq(int64(8))).
+		Return([]db.BoardElement{{ID: 1, PageID: 8, Type: ElementTypeRectangle}, {ID: 2, PageID: 8, Type: ElementTypeTextBox}}, nil)
+	page, err := pageModel.Get(3, 8)
+	require.NoError(t, err)
+	require.Equal(t, "Page 1", page.Name)
+	require.Len(t, page.Elements, 2)
+	require.Equal(t, ElementTypeTextBox, page.Elements[1].Type)
+}
+
+// TestPageModel_GetAll tests getting all the pages of a board with their elements
+func TestPageModel_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	pageModel := DbPageModel{store: store}
+
+	store.EXPECT().
+		GetBoardPageByBoardId(gomock.Any(), gomock.Eq(int64(3))).
+		Return([]db.GetBoardPageByBoardIdRow{{ID: 8, Name: "Page 1"}, {ID: 9, Name: "Page 2", Position: 1}}, nil)
+	store.EXPECT().
+		GetBoardElementsByBoardId(gomock.Any(), gomock.Eq(int64(3))).
+		Return([]db.BoardElement{{ID: 1, PageID: 9, Type: ElementTypeRectangle}}, nil)
+	pages, err := pageModel.GetAll(3)
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	require.Equal(t, int64(3), pages[0].BoardId)
+	require.Empty(t, pages[0].Elements)
+	require.Equal(t, "Page 2", pages[1].Name)
+	require.Len(t, pages[1].Elements, 1)
+}
+
+// TestPageModel_RenameAndDelete tests renaming and deleting the pages of a board
+func TestPageModel_RenameAndDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	pageModel := DbPageModel{store: store}
+
+	store.EXPECT().
+		RenamePageTx(gomock.Any(), gomock.Eq(db.RenamePageTxParams{BoardId: 3, UserId: 1, PageId: 8, Name: "renamed"})).
+		Return(db.PageOperationTxResult{}, pgx.ErrNoRows)
+	page, _, err := pageModel.Rename(3, 1, 8, "renamed")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+	require.Nil(t, page)
+
+	store.EXPECT().
+		RenamePageTx(gomock.Any(), gomock.Any()).
+		Return(db.PageOperationTxResult{
+			Page:      db.BoardPage{ID: 8, BoardID: 3, Name: "renamed", Position: 1},
+			Operation: db.BoardOperation{Revision: 5, Type: db.OperationPageRename},
+		}, nil)
+	page, revision, err := pageModel.Rename(3, 1, 8, "renamed")
+	require.NoError(t, err)
+	require.Equal(t, "renamed", page.Name)
+	require.Equal(t, int32(1), page.Position)
+	require.Equal(t, int64(5), revision)
+
+	deleteParams := db.DeletePageTxParams{BoardId: 3, UserId: 1, PageId: 8}
+	getParams := db.GetBoardPageParams{ID: 8, BoardID: 3}
+
+	store.EXPECT().
+		DeletePageTx(gomock.Any(), gomock.Eq(deleteParams)).
+		Return(db.PageOperationTxResult{
+			Page:      db.BoardPage{ID: 8, IsDeleted: true},
+			Operation: db.BoardOperation{Revision: 6, Type: db.OperationPageDelete},
+		}, nil)
+	revision, err = pageModel.Delete(3, 1, 8)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), revision)
+
+	// page does not exist
+	store.EXPECT().
+		DeletePageTx(gomock.Any(), gomock.Eq(deleteParams)).
+		Return(db.PageOperationTxResult{}, pgx.ErrNoRows)
+	store.EXPECT().
+		GetBoardPage(gomock.Any(), gomock.Eq(getParams)).
+		Return(db.BoardPage{}, pgx.ErrNoRows)
+	_, err = pageModel.Delete(3, 1, 8)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	// page is the only page of the board
+	store.EXPECT().
+		DeletePageTx(gomock.Any(), gomock.Eq(deleteParams)).
+		Return(db.PageOperationTxResult{}, pgx.ErrNoRows)
+	store.EXPECT().
+		GetBoardPage(gomock.Any(), gomock.Eq(getParams)).
+		Return(db.BoardPage{ID: 8, BoardID: 3}, nil)
+	_, err = pageModel.Delete(3, 1, 8)
+	require.EqualError(t, err, ErrLastPage.Error())
+}
+
+// TestPageModel_Reorder tests changing the order of the pages
+func TestPageModel_Reorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	pageModel := DbPageModel{store: store}
+
+	boardPages := []db.GetBoardPageByBoardIdRow{
+		{ID: 7, Name: "page 1", Position: 0},
+		{ID: 8, Name: "page 2", Position: 1},
+	}
+
+	testCases := []struct {
+		name    string
+		pageIds []int64
+	}{
+		{name: "Missing page", pageIds: []int64{8}},
+		{name: "Repeated page", pageIds: []int64{8, 8}},
+		{name: "Page of another board", pageIds: []int64{8, 9}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store.EXPECT().
+				GetBoardPageByBoardId(gomock.Any(), int64(3)).
+				Return(boardPages, nil)
+			pages, _, err := pageModel.Reorder(3, 1, tc.pageIds)
+			require.EqualError(t, err, ErrInvalidPageOrder.Error())
+			require.Nil(t, pages)
+		})
+	}
+
+	reorderParams := db.ReorderPagesTxParams{BoardId: 3, UserId: 1, PageIds: []int32{8, 7}}
+
+	// one of the pages is deleted after the order is checked
+	store.EXPECT().
+		GetBoardPageByBoardId(gomock.Any(), int64(3)).
+		Return(boardPages, nil)
+	store.EXPECT().
+		ReorderPagesTx(gomock.Any(), gomock.Eq(reorderParams)).
+		Return(db.ReorderPagesTxResult{}, pgx.ErrNoRows)
+	_, _, err := pageModel.Reorder(3, 1, []int64{8, 7})
+	require.EqualError(t, err, ErrInvalidPageOrder.Error())
+
+	store.EXPECT().
+		GetBoardPageByBoardId(gomock.Any(), int64(3)).
+		Return(boardPages, nil)
+	store.EXPECT().
+		ReorderPagesTx(gomock.Any(), gomock.Eq(reorderParams)).
+		Return(db.ReorderPagesTxResult{
+			Pages: []db.BoardPage{
+				{ID: 8, BoardID: 3, Name: "page 2", Position: 0},
+				{ID: 7, BoardID: 3, Name: "page 1", Position: 1},
+			},
+			Operations: []db.BoardOperation{{Revision: 4}, {Revision: 5}},
+		}, nil)
+	pages, revision, err := pageModel.Reorder(3, 1, []int64{8, 7})
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	require.Equal(t, int64(8), pages[0].Id)
+	require.Equal(t, int32(0), pages[0].Position)
+	require.Equal(t, int64(7), pages[1].Id)
+	require.Equal(t, int32(1), pages[1].Position)
+	require.Equal(t, int64(5), revision)
+}
+
+// TestPageModel_CreateAndDuplicate tests adding new pages and copying the existing ones
+func TestPageModel_CreateAndDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	pageModel := DbPageModel{store: store}
+
+	store.EXPECT().
+		CreatePageTx(gomock.Any(), gomock.Eq(db.CreatePageTxParams{BoardId: 3, UserId: 1, Name: "page 2"})).
+		Return(db.PageOperationTxResult{
+			Page:      db.BoardPage{ID: 8, BoardID: 3, Name: "page 2", Position: 1},
+			Operation: db.BoardOperation{Revision: 2, Type: db.OperationPageCreate},
+		}, nil)
+	page, revision, err := pageModel.Create(3, 1, "page 2")
+	require.NoError(t, err)
+	require.Equal(t, int64(8), page.Id)
+	require.Equal(t, int32(1), page.Position)
+	require.NotNil(t, page.Elements)
+	require.Equal(t, int64(2), revision)
+
+	store.EXPECT().
+		GetBoardPage(gomock.Any(), gomock.Eq(db.GetBoardPageParams{ID: 9, BoardID: 3})).
+		Return(db.BoardPage{}, pgx.ErrNoRows)
+	page, _, err = pageModel.Duplicate(3, 1, 9)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+	require.Nil(t, page)
+
+	store.EXPECT().
+		GetBoardPage(gomock.Any(), gomock.Eq(db.GetBoardPageParams{ID: 8, BoardID: 3})).
+		Return(db.BoardPage{ID: 8, BoardID: 3, Name: "page 2", Position: 1}, nil)
+	store.EXPECT().
+		DuplicateBoardPageTx(gomock.Any(), gomock.Eq(db.DuplicateBoardPageTxParams{BoardId: 3, UserId: 1, SourcePageId: 8, Name: "Copy of page 2"})).
+		Return(db.DuplicateBoardPageTxResult{
+			Page:       db.BoardPage{ID: 10, BoardID: 3, Name: "Copy of page 2", Position: 2},
+			Elements:   []db.BoardElement{{ID: 20, PageID: 10, Type: "rectangle"}},
+			Operations: []db.BoardOperation{{Revision: 3}, {Revision: 4}},
+		}, nil)
+	page, revision, err = pageModel.Duplicate(3, 1, 8)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), page.Id)
+	require.Equal(t, int32(2), page.Position)
+	require.Len(t, page.Elements, 1)
+	require.Equal(t, int64(10), page.Elements[0].PageId)
+	require.Equal(t, int64(4), revision)
+}
@@ -0,0 +1,125 @@
+package data
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/token"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"time"
+)
+
+// ShareLink represents db.BoardShareLink. Anyone with the token of the link can access the board with the link's role.
+type ShareLink struct {
+	Id      int64  `json:"id"`
+	BoardId int64  `json:"board_id"`
+	Role    string `json:"role"`
+	// Token is the plaintext token of the link. Only the hash is stored, so it is known only when the link is created.
+	Token     string     `json:"token,omitempty"`
+	Expiry    *time.Time `json:"expiry"`
+	IsRevoked bool       `json:"is_revoked"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// copyFromDbShareLink copies data from db package to repository
+func (l *ShareLink) copyFromDbShareLink(dbShareLink *db.BoardShareLink) {
+	l.Id = int64(dbShareLink.ID)
+	l.BoardId = dbShareLink.BoardID
+	l.Role = dbShareLink.Role
+	l.IsRevoked = dbShareLink.IsRevoked
+	l.CreatedAt = dbShareLink.CreatedAt.Time
+
+	if dbShareLink.Expiry.Valid {
+		expiry := dbShareLink.Expiry.Time
+		l.Expiry = &expiry
+	}
+}
+
+// ValidateShareLink checks the role and the optional expiry of a new share link
+func ValidateShareLink(v *validator.Validator, link *ShareLink) {
+	ValidateMemberRole(v, link.Role)
+	v.Check(link.Expiry == nil || link.Expiry.After(time.Now()), "expiry", "must be in the future")
+}
+
+type ShareLinkModel interface {
+	New(boardId int64, role string, expiry *time.Time, createdBy int64) (*ShareLink, error)
+	GetAll(boardId int64) ([]ShareLink, error)
+	Revoke(boardId int64, id int64) error
+}
+
+type DbShareLinkModel struct {
+	store db.Store
+}
+
+// Ensure DbShareLinkModel implements ShareLinkModel interface
+var _ ShareLinkModel = (*DbShareLinkModel)(nil)
+
+// New creates a share link for the board. Links without an expiry are valid until they are revoked.
+func (m *DbShareLinkModel) New(boardId int64, role string, expiry *time.Time, createdBy int64) (*ShareLink, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	plaintext, hash, err := token.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	params := db.CreateBoardShareLinkParams{
+		Hash:      hash,
+		BoardID:   boardId,
+		Role:      role,
+		CreatedBy: pgtype.Int8{Int64: createdBy, Valid: createdBy != 0},
+	}
+	if expiry != nil {
+		params.Expiry = pgtype.Timestamptz{Time: *expiry, Valid: true}
+	}
+
+	dbShareLink, err := m.store.CreateBoardShareLink(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	link := &ShareLink{Token: plaintext}
+	link.copyFromDbShareLink(&dbShareLink)
+
+	return link, nil
+}
+
+// GetAll returns the share links of the board, including the expired and revoked ones
+func (m *DbShareLinkModel) GetAll(boardId int64) ([]ShareLink, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbShareLinks, err := m.store.GetBoardShareLinks(ctx, boardId)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]ShareLink, len(dbShareLinks))
+	for i := range dbShareLinks {
+		links[i].copyFromDbShareLink(&dbShareLinks[i])
+	}
+
+	return links, nil
+}
+
+// Revoke disables the share link of the board. Revoked links are kept to be listed.
+func (m *DbShareLinkModel) Revoke(boardId int64, id int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.store.RevokeBoardShareLink(ctx, db.RevokeBoardShareLinkParams{
+		ID:      int32(id),
+		BoardID: boardId,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"testing"
+	"time"
+)
+
+// TestValidateShareLink tests the validation rules of the new share links
+func TestValidateShareLink(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	v := validator.New()
+	ValidateShareLink(v, &ShareLink{Role: BoardRoleOwner, Expiry: &past})
+	require.Contains(t, v.Errors, "role")
+	require.Contains(t, v.Errors, "expiry")
+
+	v = validator.New()
+	ValidateShareLink(v, &ShareLink{Role: BoardRoleViewer})
+	require.True(t, v.Valid())
+
+	v = validator.New()
+	ValidateShareLink(v, &ShareLink{Role: BoardRoleEditor, Expiry: &future})
+	require.True(t, v.Valid())
+}
+
+// TestShareLinkModel_New tests creating share links with and without an expiry
+func TestShareLinkModel_New(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbShareLinkModel{store: store}
+
+	store.EXPECT().
+		CreateBoardShareLink(gomock.Any(), gomock.Any()).
+		Return(db.BoardShareLink{}, unexpectedErr)
+
+	link, err := model.New(3, BoardRoleViewer, nil, 1)
+	require.EqualError(t, err, unexpectedErr.Error())
+	require.Nil(t, link)
+
+	store.EXPECT().
+		CreateBoardShareLink(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateBoardShareLinkParams) (db.BoardShareLink, error) {
+			require.Len(t, arg.Hash, 32)
+			require.False(t, arg.Expiry.Valid)
+			return db.BoardShareLink{ID: 4, Hash: arg.Hash, BoardID: arg.BoardID, Role: arg.Role}, nil
+		})
+
+	link, err = model.New(3, BoardRoleViewer, nil, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), link.Id)
+	require.Len(t, link.Token, 26)
+	require.Nil(t, link.Expiry)
+
+	expiry := time.Now().Add(time.Hour)
+	store.EXPECT().
+		CreateBoardShareLink(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateBoardShareLinkParams) (db.BoardShareLink, error) {
+			require.True(t, arg.Expiry.Valid)
+			return db.BoardShareLink{ID: 5, Hash: arg.Hash, BoardID: arg.BoardID, Role: arg.Role, Expiry: arg.Expiry}, nil
+		})
+
+	link, err = model.New(3, BoardRoleEditor, &expiry, 1)
+	require.NoError(t, err)
+	require.Equal(t, BoardRoleEditor, link.Role)
+	require.WithinDuration(t, expiry, *link.Expiry, time.Second)
+}
+
+// TestShareLinkModel_GetAll tests listing the share links of a board
+func TestShareLinkModel_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbShareLinkModel{store: store}
+
+	store.EXPECT().
+		GetBoardShareLinks(gomock.Any(), int64(3)).
+		Return([]db.BoardShareLink{
+			{ID: 4, BoardID: 3, Role: BoardRoleViewer, Expiry: pgtype.Timestamptz{Time: time.Now(), Valid: true}},
+			{ID: 5, BoardID: 3, Role: BoardRoleEditor, IsRevoked: true},
+		}, nil)
+
+	links, err := model.GetAll(3)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	require.NotNil(t, links[0].Expiry)
+	require.Empty(t, links[0].Token)
+	require.True(t, links[1].IsRevoked)
+	require.Nil(t, links[1].Expiry)
+}
+
+// TestShareLinkModel_Revoke tests revoking the share links of a board
+func TestShareLinkModel_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbShareLinkModel{store: store}
+
+	store.EXPECT().
+		RevokeBoardShareLink(gomock.Any(), db.RevokeBoardShareLinkParams{ID: 4, BoardID: 3}).
+		Return(db.BoardShareLink{}, pgx.ErrNoRows)
+
+	require.EqualError(t, model.Revoke(3, 4), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		RevokeBoardShareLink(gomock.Any(), db.RevokeBoardShareLinkParams{ID: 4, BoardID: 3}).
+		Return(db.BoardShareLink{ID: 4, BoardID: 3, IsRevoked: true}, nil)
+
+	require.NoError(t, model.Revoke(3, 4))
+}
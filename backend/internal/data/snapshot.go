@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"time"
+)
+
+// SnapshotInterval is the number of operations between two snapshots of a board
+const SnapshotInterval = 100
+
+// Snapshot represents db.BoardSnapshot. It is the element state of a page at a board revision
+type Snapshot struct {
+	PageId    int64     `json:"page_id"`
+	Revision  int64     `json:"revision"`
+	Elements  []Element `json:"elements"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// copyFromDbSnapshot copies data from db package to repository
+func (s *Snapshot) copyFromDbSnapshot(dbSnapshot *db.BoardSnapshot) error {
+	s.PageId = dbSnapshot.PageID
+	s.Revision = dbSnapshot.Revision
+	s.CreatedAt = dbSnapshot.CreatedAt.Time
+
+	var dbElements []db.BoardElement
+	if err := json.Unmarshal(dbSnapshot.Data, &dbElements); err != nil {
+		return err
+	}
+
+	s.Elements = make([]Element, len(dbElements))
+	for i := range dbElements {
+		s.Elements[i].copyFromDbElement(&dbElements[i])
+	}
+
+	return nil
+}
+
+type SnapshotModel interface {
+	Create(boardSlugId string) ([]Snapshot, error)
+	GetLatest(boardSlugId string) ([]Snapshot, error)
+}
+
+type DbSnapshotModel struct {
+	store db.Store
+}
+
+// Ensure DbSnapshotModel implements SnapshotModel interface
+var _ SnapshotModel = (*DbSnapshotModel)(nil)
+
+// Create takes a snapshot of every page of the board and compacts the operation history.
+// It returns no snapshots if the board has not changed since the latest snapshot.
+func (m *DbSnapshotModel) Create(boardSlugId string) ([]Snapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := m.store.CreateSnapshotTx(ctx, boardSlugId)
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return copySnapshots(result.Snapshots)
+}
+
+// GetLatest returns the snapshots of the pages at the latest snapshot revision of the board
+func (m *DbSnapshotModel) GetLatest(boardSlugId string) ([]Snapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbSnapshots, err := m.store.GetLatestBoardSnapshots(ctx, boardSlugId)
+	if err != nil {
+		return nil, err
+	}
+
+	return copySnapshots(dbSnapshots)
+}
+
+func copySnapshots(dbSnapshots []db.BoardSnapshot) ([]Snapshot, error) {
+	snapshots := make([]Snapshot, len(dbSnapshots))
+	for i := range dbSnapshots {
+		if err := snapshots[i].copyFromDbSnapshot(&dbSnapshots[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"testing"
+)
+
+// TestSnapshotModel_Create tests taking snapshots of a board
+func TestSnapshotModel_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbSnapshotModel{store: store}
+
+	testCases := []struct {
+		name          string
+		buildStub     func()
+		checkResponse func(t *testing.T, snapshots []Snapshot, err error)
+	}{
+		{
+			name: "Board not found",
+			buildStub: func() {
+				store.EXPECT().
+					CreateSnapshotTx(gomock.Any(), "test").
+					Return(db.CreateSnapshotTxResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, snapshots []Snapshot, err error) {
+				require.EqualError(t, err, ErrRecordNotFound.Error())
+				require.Nil(t, snapshots)
+			},
+		},
+		{
+			name: "Unchanged board",
+			buildStub: func() {
+				store.EXPECT().
+					CreateSnapshotTx(gomock.Any(), "test").
+					Return(db.CreateSnapshotTxResult{Revision: 4}, nil)
+			},
+			checkResponse: func(t *testing.T, snapshots []Snapshot, err error) {
+				require.NoError(t, err)
+				require.Empty(t, snapshots)
+			},
+		},
+		{
+			name: "Successful",
+			buildStub: func() {
+				store.EXPECT().
+					CreateSnapshotTx(gomock.Any(), "test").
+					Return(db.CreateSnapshotTxResult{
+						Revision: 4,
+						Snapshots: []db.BoardSnapshot{
+							{PageID: 1, Revision: 4, Data: []byte(`[{"id": 3, "page_id": 1, "type": "ellipse", "version": 2}]`)},
+							{PageID: 2, Revision: 4, Data: []byte(`[]`)},
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, snapshots []Snapshot, err error) {
+				require.NoError(t, err)
+				require.Len(t, snapshots, 2)
+				require.Equal(t, int64(4), snapshots[0].Revision)
+				require.Len(t, snapshots[0].Elements, 1)
+				require.Equal(t, ElementTypeEllipse, snapshots[0].Elements[0].Type)
+				require.Equal(t, int32(2), snapshots[0].Elements[0].Version)
+				require.Empty(t, snapshots[1].Elements)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.buildStub()
+
+			snapshots, err := model.Create("test")
+			tc.checkResponse(t, snapshots, err)
+		})
+	}
+}
+
+// TestSnapshotModel_GetLatest tests retrieving the latest snapshots of a board
+func TestSnapshotModel_GetLatest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	model := DbSnapshotModel{store: store}
+
+	store.EXPECT().
+		GetLatestBoardSnapshots(gomock.Any(), "test").
+		Return(nil, unexpectedErr)
+
+	snapshots, err := model.GetLatest("test")
+	require.EqualError(t, err, unexpectedErr.Error())
+	require.Empty(t, snapshots)
+
+	store.EXPECT().
+		GetLatestBoardSnapshots(gomock.Any(), "test").
+		Return([]db.BoardSnapshot{{PageID: 1, Revision: 7, Data: []byte(`[{"id": 1}, {"id": 2}]`)}}, nil)
+
+	snapshots, err = model.GetLatest("test")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Len(t, snapshots[0].Elements, 2)
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"time"
+)
+
+// Scopes of the templates. Personal templates can only be used by their owners, workspace templates by the members
+// of the workspace of the board, and system templates are seeded with the migrations and can be used by everyone.
+const (
+	TemplateScopePersonal  = "personal"
+	TemplateScopeWorkspace = "workspace"
+	TemplateScopeSystem    = "system"
+)
+
+// Template is a board that new boards can be created from
+type Template struct {
+	Id        int64     `json:"id"`
+	OwnerId   int64     `json:"owner_id"`
+	Name      string    `json:"name"`
+	SlugId    string    `json:"slug_id"`
+	Scope     string    `json:"scope"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// copyFromDbBoard copies the template data of the board from db package to repository
+func (t *Template) copyFromDbBoard(dbBoard *db.Board) {
+	t.Id = int64(dbBoard.ID)
+	t.OwnerId = dbBoard.OwnerID
+	t.Name = dbBoard.Name
+	t.SlugId = dbBoard.SlugID
+	t.Scope = dbBoard.TemplateScope.String
+	t.CreatedAt = dbBoard.CreatedAt.Time
+}
+
+// ValidateTemplateScope checks if the scope can be given to a board by its owner.
+// System templates cannot be created with the API and only the boards in a workspace can be workspace templates.
+func ValidateTemplateScope(v *validator.Validator, scope string, board *BoardResult) {
+	v.Check(validator.PermittedValue(scope, TemplateScopePersonal, TemplateScopeWorkspace), "scope", "must be one of personal or workspace")
+	v.Check(scope != TemplateScopeWorkspace || board.WorkspaceId != nil, "scope", "board must belong to a workspace")
+}
+
+type TemplateModel interface {
+	GetAll(userId int64) ([]Template, error)
+	Mark(boardId int64, scope string) (*Template, error)
+	Unmark(boardId int64) error
+}
+
+type DbTemplateModel struct {
+	store db.Store
+}
+
+// Ensure DbTemplateModel implements TemplateModel interface
+var _ TemplateModel = (*DbTemplateModel)(nil)
+
+// GetAll returns the templates that the user can create boards from, system templates first
+func (m *DbTemplateModel) GetAll(userId int64) ([]Template, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbBoards, err := m.store.GetTemplatesForUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]Template, len(dbBoards))
+	for i := range dbBoards {
+		templates[i].copyFromDbBoard(&dbBoards[i])
+	}
+
+	return templates, nil
+}
+
+// Mark makes the board a template with the given scope
+func (m *DbTemplateModel) Mark(boardId int64, scope string) (*Template, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbBoard, err := m.store.UpdateBoardTemplateScope(ctx, db.UpdateBoardTemplateScopeParams{
+		TemplateScope: pgtype.Text{String: scope, Valid: true},
+		ID:            int32(boardId),
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var template Template
+	template.copyFromDbBoard(&dbBoard)
+
+	return &template, nil
+}
+
+// Unmark stops the board from being a template. The boards created from it are not changed.
+func (m *DbTemplateModel) Unmark(boardId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.store.UpdateBoardTemplateScope(ctx, db.UpdateBoardTemplateScopeParams{
+		ID: int32(boardId),
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return ErrRecordNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
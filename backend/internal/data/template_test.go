@@ -0,0 +1,98 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"testing"
+)
+
+// TestValidateTemplateScope tests that system templates cannot be created by the users and
+// only the boards in a workspace can be workspace templates
+func TestValidateTemplateScope(t *testing.T) {
+	workspaceId := int64(2)
+
+	v := validator.New()
+	ValidateTemplateScope(v, TemplateScopeSystem, &BoardResult{})
+	require.Contains(t, v.Errors, "scope")
+
+	v = validator.New()
+	ValidateTemplateScope(v, TemplateScopeWorkspace, &BoardResult{})
+	require.Contains(t, v.Errors, "scope")
+
+	v = validator.New()
+	ValidateTemplateScope(v, TemplateScopeWorkspace, &BoardResult{WorkspaceId: &workspaceId})
+	require.True(t, v.Valid())
+
+	v = validator.New()
+	ValidateTemplateScope(v, TemplateScopePersonal, &BoardResult{})
+	require.True(t, v.Valid())
+}
+
+// TestTemplateModel tests listing, marking and unmarking the templates
+func TestTemplateModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	templateModel := DbTemplateModel{store: store}
+
+	store.EXPECT().
+		GetTemplatesForUser(gomock.Any(), gomock.Eq(int64(1))).
+		Return([]db.Board{
+			{ID: 1, Name: "Kanban", TemplateScope: pgtype.Text{String: TemplateScopeSystem, Valid: true}},
+			{ID: 7, OwnerID: 1, Name: "Weekly", TemplateScope: pgtype.Text{String: TemplateScopePersonal, Valid: true}},
+		}, nil)
+	templates, err := templateModel.GetAll(1)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	require.Equal(t, TemplateScopeSystem, templates[0].Scope)
+	require.Equal(t, TemplateScopePersonal, templates[1].Scope)
+
+	store.EXPECT().
+		UpdateBoardTemplateScope(gomock.Any(), gomock.Eq(db.UpdateBoardTemplateScopeParams{
+			TemplateScope: pgtype.Text{String: TemplateScopePersonal, Valid: true},
+			ID:            7,
+		})).
+		Return(db.Board{ID: 7, Name: "Weekly", TemplateScope: pgtype.Text{String: TemplateScopePersonal, Valid: true}}, nil)
+	template, err := templateModel.Mark(7, TemplateScopePersonal)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), template.Id)
+	require.Equal(t, TemplateScopePersonal, template.Scope)
+
+	store.EXPECT().
+		UpdateBoardTemplateScope(gomock.Any(), gomock.Eq(db.UpdateBoardTemplateScopeParams{ID: 7})).
+		Return(db.Board{}, pgx.ErrNoRows)
+	require.EqualError(t, templateModel.Unmark(7), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		UpdateBoardTemplateScope(gomock.Any(), gomock.Eq(db.UpdateBoardTemplateScopeParams{ID: 7})).
+		Return(db.Board{ID: 7}, nil)
+	require.NoError(t, templateModel.Unmark(7))
+}
+
+// TestBoardModel_CreateBoardFromTemplate tests creating a board from a template
+func TestBoardModel_CreateBoardFromTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	boardModel := DbBoardModel{store: store}
+
+	params := db.CreateBoardTxParams{SlugId: "testingslug1", OwnerId: 5, TemplateId: 1}
+
+	store.EXPECT().
+		CreateBoardTx(gomock.Any(), gomock.Eq(params)).
+		Return(db.CreateBoardTxResult{}, pgx.ErrNoRows)
+	board, err := boardModel.CreateBoard(&Board{SlugId: "testingslug1", OwnerId: 5, TemplateId: 1})
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+	require.Nil(t, board)
+
+	store.EXPECT().
+		CreateBoardTx(gomock.Any(), gomock.Eq(params)).
+		Return(db.CreateBoardTxResult{Board: db.Board{ID: 9, Name: "Kanban", OwnerID: 5, SlugID: "testingslug1"}}, nil)
+	board, err = boardModel.CreateBoard(&Board{SlugId: "testingslug1", OwnerId: 5, TemplateId: 1})
+	require.NoError(t, err)
+	require.Equal(t, "Kanban", board.Name)
+	require.Empty(t, board.TemplateScope)
+}
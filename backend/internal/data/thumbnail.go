@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"time"
+)
+
+const (
+	// ThumbnailWidth and ThumbnailHeight are the size of the board thumbnails in pixels
+	ThumbnailWidth  = 320
+	ThumbnailHeight = 200
+	// ThumbnailDelay is how long a board must be left unchanged before its thumbnail is rendered
+	ThumbnailDelay = 30 * time.Second
+)
+
+// Thumbnail represents db.BoardThumbnail. It is a small PNG image of the first page of a board.
+type Thumbnail struct {
+	BoardId int64
+	// Revision is the board revision that the thumbnail shows
+	Revision  int64
+	Content   []byte
+	UpdatedAt time.Time
+}
+
+// ThumbnailSource is the page that the thumbnail of a board is rendered from
+type ThumbnailSource struct {
+	BoardId int64
+	PageId  int64
+	// Revision is the current revision of the board
+	Revision int64
+	// ThumbnailRevision is the board revision that the current thumbnail shows, it is nil if there is no thumbnail
+	ThumbnailRevision *int64
+}
+
+// IsUpToDate checks if the current thumbnail already shows the latest revision of the board
+func (s *ThumbnailSource) IsUpToDate() bool {
+	return s.ThumbnailRevision != nil && *s.ThumbnailRevision >= s.Revision
+}
+
+// thumbnailUrl returns the url of the thumbnail of the board, or an empty string if the board has no thumbnail.
+// The url changes with every new thumbnail, so that the clients can cache it.
+func thumbnailUrl(slugId string, updatedAt pgtype.Timestamptz) string {
+	if !updatedAt.Valid {
+		return ""
+	}
+
+	return fmt.Sprintf("/v1/boards/%s/thumbnail.png?v=%d", slugId, updatedAt.Time.Unix())
+}
+
+type ThumbnailModel interface {
+	Get(boardId int64) (*Thumbnail, error)
+	GetSource(boardSlugId string) (*ThumbnailSource, error)
+	Save(thumbnail *Thumbnail) error
+}
+
+type DbThumbnailModel struct {
+	store db.Store
+}
+
+// Ensure DbThumbnailModel implements ThumbnailModel interface
+var _ ThumbnailModel = (*DbThumbnailModel)(nil)
+
+// Get returns the thumbnail of the board
+func (m *DbThumbnailModel) Get(boardId int64) (*Thumbnail, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbThumbnail, err := m.store.GetBoardThumbnail(ctx, boardId)
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &Thumbnail{
+		BoardId:   dbThumbnail.BoardID,
+		Revision:  dbThumbnail.Revision,
+		Content:   dbThumbnail.Content,
+		UpdatedAt: dbThumbnail.UpdatedAt.Time,
+	}, nil
+}
+
+// GetSource returns the first page of the board with the revisions of the board and its thumbnail
+func (m *DbThumbnailModel) GetSource(boardSlugId string) (*ThumbnailSource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	row, err := m.store.GetBoardThumbnailSource(ctx, boardSlugId)
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	source := &ThumbnailSource{
+		BoardId:  int64(row.BoardID),
+		PageId:   int64(row.PageID),
+		Revision: row.Revision,
+	}
+	if row.ThumbnailRevision.Valid {
+		source.ThumbnailRevision = &row.ThumbnailRevision.Int64
+	}
+
+	return source, nil
+}
+
+// Save stores the thumbnail of the board. It is ignored if the board already has a thumbnail of a later revision.
+func (m *DbThumbnailModel) Save(thumbnail *Thumbnail) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.store.SaveBoardThumbnail(ctx, db.SaveBoardThumbnailParams{
+		BoardID:  thumbnail.BoardId,
+		Revision: thumbnail.Revision,
+		Content:  thumbnail.Content,
+	})
+
+	return err
+}
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"testing"
+	"time"
+)
+
+// TestThumbnailModel tests finding the page to render the thumbnails from and storing them
+func TestThumbnailModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	thumbnailModel := DbThumbnailModel{store: store}
+
+	// the board is deleted or has no pages
+	store.EXPECT().
+		GetBoardThumbnailSource(gomock.Any(), "valid-12-ch-").
+		Return(db.GetBoardThumbnailSourceRow{}, pgx.ErrNoRows)
+	_, err := thumbnailModel.GetSource("valid-12-ch-")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	// the board has no thumbnail yet
+	store.EXPECT().
+		GetBoardThumbnailSource(gomock.Any(), "valid-12-ch-").
+		Return(db.GetBoardThumbnailSourceRow{BoardID: 3, PageID: 8, Revision: 12}, nil)
+	source, err := thumbnailModel.GetSource("valid-12-ch-")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), source.BoardId)
+	require.Equal(t, int64(8), source.PageId)
+	require.Nil(t, source.ThumbnailRevision)
+	require.False(t, source.IsUpToDate())
+
+	store.EXPECT().
+		GetBoardThumbnailSource(gomock.Any(), "valid-12-ch-").
+		Return(db.GetBoardThumbnailSourceRow{BoardID: 3, PageID: 8, Revision: 12, ThumbnailRevision: pgtype.Int8{Int64: 12, Valid: true}}, nil)
+	source, err = thumbnailModel.GetSource("valid-12-ch-")
+	require.NoError(t, err)
+	require.True(t, source.IsUpToDate())
+
+	store.EXPECT().
+		SaveBoardThumbnail(gomock.Any(), gomock.Eq(db.SaveBoardThumbnailParams{BoardID: 3, Revision: 12, Content: []byte("png")})).
+		Return(int64(0), nil)
+	// a thumbnail of a later revision is kept
+	err = thumbnailModel.Save(&Thumbnail{BoardId: 3, Revision: 12, Content: []byte("png")})
+	require.NoError(t, err)
+
+	store.EXPECT().
+		GetBoardThumbnail(gomock.Any(), int64(4)).
+		Return(db.BoardThumbnail{}, pgx.ErrNoRows)
+	_, err = thumbnailModel.Get(4)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	updatedAt := time.Now()
+	store.EXPECT().
+		GetBoardThumbnail(gomock.Any(), int64(3)).
+		Return(db.BoardThumbnail{BoardID: 3, Revision: 12, Content: []byte("png"), UpdatedAt: pgtype.Timestamptz{Time: updatedAt, Valid: true}}, nil)
+	thumbnail, err := thumbnailModel.Get(3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("png"), thumbnail.Content)
+	require.WithinDuration(t, updatedAt, thumbnail.UpdatedAt, 0)
+}
@@ -2,7 +2,9 @@ package data
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -28,6 +30,26 @@ type User struct {
 	AuthProvider string    `json:"auth_provider"`
 	Version      int       `json:"version"`
 	IsVerified   bool      `json:"is_verified"`
+	// IsGuest is set for the people who access a board with a share link without logging in
+	IsGuest bool `json:"is_guest,omitempty"`
+}
+
+// GuestName is the name that the guests are shown with
+const GuestName = "Guest"
+
+// NewGuestUser creates a user for a guest of a board. Guests do not have an account, so they are
+// given a random negative id that is only used to tell the connected guests apart.
+func NewGuestUser() (*User, error) {
+	var id int32
+	if err := binary.Read(rand.Reader, binary.BigEndian, &id); err != nil {
+		return nil, err
+	}
+
+	if id >= 0 {
+		id = -id - 1
+	}
+
+	return &User{ID: id, FullName: GuestName, IsGuest: true}, nil
 }
 
 // IsAnonymous checks if the user is anonymous
@@ -78,6 +100,11 @@ func (p *password) Set(plaintextPassword string) error {
 
 // Matches checks if the given plain password is correct
 func (p *password) Matches(plaintextString string) (bool, error) {
+	// users without a password, such as the owner of the system templates, cannot sign in with one
+	if p.hash == nil {
+		return false, nil
+	}
+
 	err := bcrypt.CompareHashAndPassword(p.hash, []byte(plaintextString))
 	if err != nil {
 		switch {
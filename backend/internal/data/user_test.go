@@ -32,6 +32,11 @@ func TestPassword(t *testing.T) {
 	ok, err := passwordVar.Matches(plainPassword)
 	require.NoError(t, err)
 	require.True(t, ok)
+
+	// users without a password cannot sign in with one
+	ok, err = (&password{}).Matches(plainPassword)
+	require.NoError(t, err)
+	require.False(t, ok)
 }
 
 func TestUser_IsAnonymous(t *testing.T) {
@@ -796,3 +801,15 @@ func TestDbUserModel_ActivateUser(t *testing.T) {
 		})
 	}
 }
+
+// TestNewGuestUser tests that guests are given negative ids so that they never clash with the users
+func TestNewGuestUser(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		guest, err := NewGuestUser()
+		require.NoError(t, err)
+		require.Less(t, guest.ID, int32(0))
+		require.True(t, guest.IsGuest)
+		require.Equal(t, GuestName, guest.FullName)
+		require.False(t, guest.IsAnonymous())
+	}
+}
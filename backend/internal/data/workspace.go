@@ -0,0 +1,327 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	// ErrAlreadyWorkspaceMember is returned when the user is added to a workspace that they are already a member of
+	ErrAlreadyWorkspaceMember = errors.New("already a workspace member")
+	// ErrLastWorkspaceAdmin is returned when the only admin of a workspace is removed or demoted
+	ErrLastWorkspaceAdmin = errors.New("last admin of the workspace")
+)
+
+// Roles of the users in a workspace. Members of a workspace inherit the access to the boards of the workspace,
+// admins and editors can edit the boards and viewers can only view them.
+const (
+	WorkspaceRoleAdmin  = db.WorkspaceRoleAdmin
+	WorkspaceRoleEditor = db.WorkspaceRoleEditor
+	WorkspaceRoleViewer = db.WorkspaceRoleViewer
+)
+
+// Permissions given by the workspace roles, they are checked by requirePermission on the workspace routes
+const (
+	PermissionWorkspaceRead   = "workspaces:read"
+	PermissionWorkspaceWrite  = "workspaces:write"
+	PermissionWorkspaceManage = "workspaces:manage"
+)
+
+// WorkspaceRolePermissions returns the permissions that the role gives in its workspace
+func WorkspaceRolePermissions(role string) Permissions {
+	switch role {
+	case WorkspaceRoleAdmin:
+		return Permissions{PermissionWorkspaceRead, PermissionWorkspaceWrite, PermissionWorkspaceManage}
+	case WorkspaceRoleEditor:
+		return Permissions{PermissionWorkspaceRead, PermissionWorkspaceWrite}
+	case WorkspaceRoleViewer:
+		return Permissions{PermissionWorkspaceRead}
+	default:
+		return Permissions{}
+	}
+}
+
+// Workspace represents db.Workspace
+type Workspace struct {
+	Id        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	// Role is the role of the user who retrieves the workspace
+	Role string `json:"role"`
+}
+
+// copyFromDbWorkspace copies data from db package to repository
+func (ws *Workspace) copyFromDbWorkspace(dbWorkspace *db.Workspace) {
+	ws.Id = int64(dbWorkspace.ID)
+	ws.Name = dbWorkspace.Name
+	ws.CreatedAt = dbWorkspace.CreatedAt.Time
+}
+
+type WorkspaceMember struct {
+	Id        int64     `json:"id"`
+	FullName  string    `json:"full_name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ValidateWorkspace(v *validator.Validator, workspace *Workspace) {
+	workspaceNameLen := utf8.RuneCountInString(workspace.Name)
+	v.Check(workspaceNameLen >= 1 && workspaceNameLen <= 50, "name", "must be between 1 and 50")
+}
+
+func ValidateWorkspaceRole(v *validator.Validator, role string) {
+	v.Check(validator.PermittedValue(role, WorkspaceRoleAdmin, WorkspaceRoleEditor, WorkspaceRoleViewer), "role", "must be one of admin, editor or viewer")
+}
+
+type WorkspaceModel interface {
+	New(userId int64, name string) (*Workspace, error)
+	GetAllForUser(userId int64) ([]Workspace, error)
+	Rename(workspaceId int64, name string) (*Workspace, error)
+	Delete(workspaceId int64) error
+	GetMemberRole(workspaceId int64, userId int64) (string, error)
+	GetMembers(workspaceId int64) ([]WorkspaceMember, error)
+	AddMember(workspaceId int64, userId int64, role string) error
+	UpdateMemberRole(workspaceId int64, userId int64, role string) error
+	RemoveMember(workspaceId int64, userId int64) error
+	GetBoards(workspaceId int64) ([]*BoardResult, error)
+}
+
+type DbWorkspaceModel struct {
+	store db.Store
+}
+
+// Ensure DbWorkspaceModel implements WorkspaceModel interface
+var _ WorkspaceModel = (*DbWorkspaceModel)(nil)
+
+// New creates a workspace, the user who creates it becomes its admin
+func (m *DbWorkspaceModel) New(userId int64, name string) (*Workspace, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	result, err := m.store.CreateWorkspaceTx(ctx, db.CreateWorkspaceTxParams{
+		Name:   name,
+		UserId: userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := &Workspace{Role: result.Member.Role}
+	workspace.copyFromDbWorkspace(&result.Workspace)
+
+	return workspace, nil
+}
+
+// GetAllForUser returns the workspaces that the user is a member of with the role of the user
+func (m *DbWorkspaceModel) GetAllForUser(userId int64) ([]Workspace, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.store.GetWorkspacesForUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := make([]Workspace, len(rows))
+	for i, row := range rows {
+		workspaces[i] = Workspace{
+			Id:        int64(row.ID),
+			Name:      row.Name,
+			CreatedAt: row.CreatedAt.Time,
+			Role:      row.Role,
+		}
+	}
+
+	return workspaces, nil
+}
+
+// Rename changes the name of the workspace
+func (m *DbWorkspaceModel) Rename(workspaceId int64, name string) (*Workspace, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	dbWorkspace, err := m.store.RenameWorkspace(ctx, db.RenameWorkspaceParams{
+		ID:   int32(workspaceId),
+		Name: name,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return nil, ErrRecordNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	var workspace Workspace
+	workspace.copyFromDbWorkspace(&dbWorkspace)
+
+	return &workspace, nil
+}
+
+// Delete removes the workspace and its memberships. The boards of the workspace are kept by their owners.
+func (m *DbWorkspaceModel) Delete(workspaceId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rowsAffected, err := m.store.DeleteWorkspace(ctx, int32(workspaceId))
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetMemberRole returns the role of the user in the workspace, ErrRecordNotFound is returned for non members
+func (m *DbWorkspaceModel) GetMemberRole(workspaceId int64, userId int64) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	role, err := m.store.GetWorkspaceMemberRole(ctx, db.GetWorkspaceMemberRoleParams{
+		WorkspaceID: workspaceId,
+		UserID:      userId,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrNoRows(err):
+			return "", ErrRecordNotFound
+		default:
+			return "", err
+		}
+	}
+
+	return role, nil
+}
+
+// GetMembers returns the members of the workspace in the order they joined
+func (m *DbWorkspaceModel) GetMembers(workspaceId int64) ([]WorkspaceMember, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rows, err := m.store.GetWorkspaceMembers(ctx, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]WorkspaceMember, len(rows))
+	for i, row := range rows {
+		members[i] = WorkspaceMember{
+			Id:        int64(row.ID),
+			FullName:  row.FullName,
+			Email:     row.Email,
+			Role:      row.Role,
+			CreatedAt: row.CreatedAt.Time,
+		}
+	}
+
+	return members, nil
+}
+
+// AddMember adds the user to the workspace with the given role
+func (m *DbWorkspaceModel) AddMember(workspaceId int64, userId int64, role string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.store.AddWorkspaceMember(ctx, db.AddWorkspaceMemberParams{
+		WorkspaceID: workspaceId,
+		UserID:      userId,
+		Role:        role,
+	})
+	if err != nil {
+		switch {
+		case db.IsErrUniqueViolation(err):
+			return ErrAlreadyWorkspaceMember
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateMemberRole changes the role of the workspace member. The last admin of the workspace cannot be demoted.
+func (m *DbWorkspaceModel) UpdateMemberRole(workspaceId int64, userId int64, role string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rowsAffected, err := m.store.UpdateWorkspaceMemberRole(ctx, db.UpdateWorkspaceMemberRoleParams{
+		Role:        role,
+		WorkspaceID: workspaceId,
+		UserID:      userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return m.lastAdminOrNotFound(workspaceId, userId)
+	}
+
+	return nil
+}
+
+// RemoveMember removes the user from the workspace. The last admin of the workspace cannot be removed.
+func (m *DbWorkspaceModel) RemoveMember(workspaceId int64, userId int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	rowsAffected, err := m.store.DeleteWorkspaceMember(ctx, db.DeleteWorkspaceMemberParams{
+		WorkspaceID: workspaceId,
+		UserID:      userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return m.lastAdminOrNotFound(workspaceId, userId)
+	}
+
+	return nil
+}
+
+// lastAdminOrNotFound tells why a change on the membership did not affect any rows,
+// the user is either not a member of the workspace or the last admin of it
+func (m *DbWorkspaceModel) lastAdminOrNotFound(workspaceId int64, userId int64) error {
+	if _, err := m.GetMemberRole(workspaceId, userId); err != nil {
+		return err
+	}
+
+	return ErrLastWorkspaceAdmin
+}
+
+// GetBoards returns the boards in the workspace sorted by their names
+func (m *DbWorkspaceModel) GetBoards(workspaceId int64) ([]*BoardResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	boards, err := m.store.GetWorkspaceBoards(ctx, pgtype.Int8{Int64: workspaceId, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*BoardResult, len(boards))
+	for i, board := range boards {
+		results[i] = &BoardResult{
+			Id:             int64(board.ID),
+			OwnerId:        board.OwnerID,
+			Name:           board.Name,
+			SlugId:         board.SlugID,
+			CreatedAt:      board.CreatedAt.Time,
+			LastActivityAt: board.LastActivityAt.Time,
+			WorkspaceId:    optionalId(board.WorkspaceID),
+		}
+	}
+
+	return results, nil
+}
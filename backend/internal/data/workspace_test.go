@@ -0,0 +1,125 @@
+package data
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/umtdemr/wb-backend/internal/db/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"strings"
+	"testing"
+)
+
+// TestValidateWorkspace tests the validation rules of the workspace names and roles
+func TestValidateWorkspace(t *testing.T) {
+	v := validator.New()
+	ValidateWorkspace(v, &Workspace{Name: ""})
+	require.Contains(t, v.Errors, "name")
+
+	v = validator.New()
+	ValidateWorkspace(v, &Workspace{Name: strings.Repeat("ş", 51)})
+	require.Contains(t, v.Errors, "name")
+
+	v = validator.New()
+	ValidateWorkspaceRole(v, "owner")
+	require.Contains(t, v.Errors, "role")
+
+	v = validator.New()
+	ValidateWorkspace(v, &Workspace{Name: strings.Repeat("ş", 50)})
+	ValidateWorkspaceRole(v, WorkspaceRoleEditor)
+	require.True(t, v.Valid())
+}
+
+// TestWorkspaceRolePermissions tests the permissions given by the workspace roles
+func TestWorkspaceRolePermissions(t *testing.T) {
+	admin := WorkspaceRolePermissions(WorkspaceRoleAdmin)
+	require.True(t, admin.Include(PermissionWorkspaceManage))
+
+	editor := WorkspaceRolePermissions(WorkspaceRoleEditor)
+	require.True(t, editor.Include(PermissionWorkspaceWrite))
+	require.False(t, editor.Include(PermissionWorkspaceManage))
+
+	viewer := WorkspaceRolePermissions(WorkspaceRoleViewer)
+	require.True(t, viewer.Include(PermissionWorkspaceRead))
+	require.False(t, viewer.Include(PermissionWorkspaceWrite))
+
+	require.Empty(t, WorkspaceRolePermissions("unknown"))
+}
+
+// TestWorkspaceModel tests creating the workspaces and managing their members
+func TestWorkspaceModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	workspaceModel := DbWorkspaceModel{store: store}
+
+	store.EXPECT().
+		CreateWorkspaceTx(gomock.Any(), gomock.Eq(db.CreateWorkspaceTxParams{Name: "team", UserId: 1})).
+		Return(db.CreateWorkspaceTxResult{
+			Workspace: db.Workspace{ID: 3, Name: "team"},
+			Member:    db.WorkspaceMember{WorkspaceID: 3, UserID: 1, Role: db.WorkspaceRoleAdmin},
+		}, nil)
+	workspace, err := workspaceModel.New(1, "team")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), workspace.Id)
+	require.Equal(t, WorkspaceRoleAdmin, workspace.Role)
+
+	store.EXPECT().
+		GetWorkspacesForUser(gomock.Any(), gomock.Eq(int64(1))).
+		Return([]db.GetWorkspacesForUserRow{{ID: 3, Name: "team", Role: db.WorkspaceRoleEditor}}, nil)
+	workspaces, err := workspaceModel.GetAllForUser(1)
+	require.NoError(t, err)
+	require.Len(t, workspaces, 1)
+	require.Equal(t, WorkspaceRoleEditor, workspaces[0].Role)
+
+	store.EXPECT().
+		RenameWorkspace(gomock.Any(), gomock.Eq(db.RenameWorkspaceParams{ID: 3, Name: "new"})).
+		Return(db.Workspace{}, pgx.ErrNoRows)
+	_, err = workspaceModel.Rename(3, "new")
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		DeleteWorkspace(gomock.Any(), gomock.Eq(int32(3))).
+		Return(int64(0), nil)
+	require.EqualError(t, workspaceModel.Delete(3), ErrRecordNotFound.Error())
+
+	memberParams := db.GetWorkspaceMemberRoleParams{WorkspaceID: 3, UserID: 8}
+	store.EXPECT().
+		GetWorkspaceMemberRole(gomock.Any(), gomock.Eq(memberParams)).
+		Return("", pgx.ErrNoRows)
+	_, err = workspaceModel.GetMemberRole(3, 8)
+	require.EqualError(t, err, ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		AddWorkspaceMember(gomock.Any(), gomock.Eq(db.AddWorkspaceMemberParams{WorkspaceID: 3, UserID: 8, Role: db.WorkspaceRoleViewer})).
+		Return(db.WorkspaceMember{}, &pgconn.PgError{Code: "23505"})
+	require.EqualError(t, workspaceModel.AddMember(3, 8, WorkspaceRoleViewer), ErrAlreadyWorkspaceMember.Error())
+
+	// the only admin cannot be demoted
+	store.EXPECT().
+		UpdateWorkspaceMemberRole(gomock.Any(), gomock.Eq(db.UpdateWorkspaceMemberRoleParams{Role: db.WorkspaceRoleViewer, WorkspaceID: 3, UserID: 1})).
+		Return(int64(0), nil)
+	store.EXPECT().
+		GetWorkspaceMemberRole(gomock.Any(), gomock.Eq(db.GetWorkspaceMemberRoleParams{WorkspaceID: 3, UserID: 1})).
+		Return(db.WorkspaceRoleAdmin, nil)
+	require.EqualError(t, workspaceModel.UpdateMemberRole(3, 1, WorkspaceRoleViewer), ErrLastWorkspaceAdmin.Error())
+
+	// non members are not found
+	store.EXPECT().
+		DeleteWorkspaceMember(gomock.Any(), gomock.Eq(db.DeleteWorkspaceMemberParams{WorkspaceID: 3, UserID: 8})).
+		Return(int64(0), nil)
+	store.EXPECT().
+		GetWorkspaceMemberRole(gomock.Any(), gomock.Eq(memberParams)).
+		Return("", pgx.ErrNoRows)
+	require.EqualError(t, workspaceModel.RemoveMember(3, 8), ErrRecordNotFound.Error())
+
+	store.EXPECT().
+		GetWorkspaceBoards(gomock.Any(), gomock.Eq(pgtype.Int8{Int64: 3, Valid: true})).
+		Return([]db.Board{{ID: 7, Name: "roadmap", WorkspaceID: pgtype.Int8{Int64: 3, Valid: true}}}, nil)
+	boards, err := workspaceModel.GetBoards(3)
+	require.NoError(t, err)
+	require.Len(t, boards, 1)
+	require.Equal(t, int64(3), *boards[0].WorkspaceId)
+}
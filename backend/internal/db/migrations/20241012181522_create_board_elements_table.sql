@@ -0,0 +1,29 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "board_elements" (
+    id SERIAL PRIMARY KEY,
+    page_id BIGINT NOT NULL REFERENCES board_pages ON DELETE CASCADE,
+    type varchar(20) NOT NULL,
+    x double precision NOT NULL DEFAULT 0,
+    y double precision NOT NULL DEFAULT 0,
+    width double precision NOT NULL DEFAULT 0,
+    height double precision NOT NULL DEFAULT 0,
+    z_index integer NOT NULL DEFAULT 0,
+    stroke_color bigint NOT NULL DEFAULT 255,
+    fill_color bigint NOT NULL DEFAULT 0,
+    stroke_width double precision NOT NULL DEFAULT 2,
+    border_radius double precision NOT NULL DEFAULT 0,
+    text text NOT NULL DEFAULT '',
+    font_size integer NOT NULL DEFAULT 14,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    is_deleted bool NOT NULL DEFAULT FALSE,
+    CONSTRAINT chk_board_elements_type CHECK ( type IN ('rectangle', 'ellipse', 'triangle', 'text_box') )
+);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_board_elements_page_id ON board_elements(page_id);
+
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS idx_board_elements_page_id;
+DROP TABLE IF EXISTS board_elements;
@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE boards ADD COLUMN revision bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "board_operations" (
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    user_id BIGINT REFERENCES users ON DELETE SET NULL,
+    type varchar(30) NOT NULL,
+    page_id BIGINT NOT NULL,
+    element_id BIGINT NOT NULL,
+    data jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (board_id, revision)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS board_operations;
+ALTER TABLE boards DROP COLUMN revision;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE board_elements ADD COLUMN version integer NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE board_elements DROP COLUMN version;
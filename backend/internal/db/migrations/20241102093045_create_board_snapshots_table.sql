@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "board_snapshots" (
+    id SERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    page_id BIGINT NOT NULL REFERENCES board_pages ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    data jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (page_id, revision)
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_snapshots_board_id_revision ON board_snapshots (board_id, revision);
+
+-- +goose Down
+DROP TABLE IF EXISTS board_snapshots;
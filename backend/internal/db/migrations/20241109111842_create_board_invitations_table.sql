@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "board_invitations" (
+    hash bytea PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    email CITEXT NOT NULL,
+    role varchar(20) NOT NULL DEFAULT 'editor',
+    invited_by BIGINT REFERENCES users ON DELETE SET NULL,
+    expiry timestamp(0) with time zone NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (board_id, email),
+    CONSTRAINT chk_board_invitations_role CHECK ( role IN ('editor', 'viewer') )
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_invitations_email ON board_invitations (email);
+
+-- +goose Down
+DROP TABLE IF EXISTS board_invitations;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "board_share_links" (
+    id SERIAL PRIMARY KEY,
+    hash bytea NOT NULL UNIQUE,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    role varchar(20) NOT NULL DEFAULT 'viewer',
+    created_by BIGINT REFERENCES users ON DELETE SET NULL,
+    expiry timestamp(0) with time zone,
+    is_revoked bool NOT NULL DEFAULT FALSE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT chk_board_share_links_role CHECK ( role IN ('editor', 'viewer') )
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_share_links_board_id ON board_share_links (board_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS board_share_links;
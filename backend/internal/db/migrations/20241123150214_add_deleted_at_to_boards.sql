@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE boards ADD COLUMN deleted_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS idx_boards_deleted_at ON boards (deleted_at) WHERE is_deleted = TRUE;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_boards_deleted_at;
+ALTER TABLE boards DROP COLUMN deleted_at;
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE board_pages ADD COLUMN position integer NOT NULL DEFAULT 0;
+
+-- existing pages keep the order they were created in
+UPDATE board_pages bp
+SET position = ordered.position
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY board_id ORDER BY id) - 1 AS position
+    FROM board_pages
+) ordered
+WHERE bp.id = ordered.id;
+
+CREATE INDEX IF NOT EXISTS idx_board_pages_board_id_position ON board_pages (board_id, position);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_board_pages_board_id_position;
+ALTER TABLE board_pages DROP COLUMN position;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE boards ADD COLUMN last_activity_at timestamp with time zone NOT NULL DEFAULT now();
+
+-- boards without operations are considered active since they were created
+UPDATE boards b
+SET last_activity_at = COALESCE(
+    (SELECT MAX(o.created_at) FROM board_operations o WHERE o.board_id = b.id),
+    b.created_at
+);
+
+CREATE INDEX IF NOT EXISTS idx_boards_name_trgm ON boards USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_boards_last_activity_at ON boards (last_activity_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_boards_last_activity_at;
+DROP INDEX IF EXISTS idx_boards_name_trgm;
+ALTER TABLE boards DROP COLUMN last_activity_at;
@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE board_users ADD COLUMN last_opened_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS idx_board_users_user_id_last_opened_at ON board_users (user_id, last_opened_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_board_users_user_id_last_opened_at;
+ALTER TABLE board_users DROP COLUMN last_opened_at;
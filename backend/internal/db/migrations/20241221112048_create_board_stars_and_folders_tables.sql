@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "board_folders" (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
+    name varchar(50) NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (user_id, name)
+);
+
+-- folders are personal, every member puts the board into their own folder
+ALTER TABLE board_users ADD COLUMN folder_id BIGINT REFERENCES board_folders ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS "board_stars" (
+    user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, board_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_users_folder_id ON board_users (folder_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_board_users_folder_id;
+DROP TABLE IF EXISTS board_stars;
+ALTER TABLE board_users DROP COLUMN folder_id;
+DROP TABLE IF EXISTS board_folders;
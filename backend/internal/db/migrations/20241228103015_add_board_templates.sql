@@ -0,0 +1,55 @@
+-- +goose Up
+ALTER TABLE boards ADD COLUMN template_scope varchar(20);
+ALTER TABLE boards ADD CONSTRAINT chk_boards_template_scope CHECK ( template_scope IN ('personal', 'system') );
+
+CREATE INDEX IF NOT EXISTS idx_boards_template_scope ON boards (template_scope) WHERE template_scope IS NOT NULL;
+
+-- system templates are owned by a user that cannot sign in
+INSERT INTO users (full_name, email, is_verified, auth_provider)
+VALUES ('Whiteboard', 'templates@whiteboard.system', false, 'system');
+
+WITH template AS (
+    INSERT INTO boards (slug_id, name, owner_id, template_scope)
+    SELECT 'templ-kanban', 'Kanban', id, 'system' FROM users WHERE email = 'templates@whiteboard.system'
+    RETURNING id
+), page AS (
+    INSERT INTO board_pages (board_id, name, position)
+    SELECT id, 'Board', 0 FROM template
+    RETURNING id
+)
+INSERT INTO board_elements (page_id, type, x, y, width, height, z_index, stroke_color, fill_color, border_radius, text, font_size)
+SELECT page.id, e.type, e.x, e.y, e.width, e.height, e.z_index, e.stroke_color, e.fill_color, e.border_radius, e.text, e.font_size
+FROM page, (VALUES
+    ('rectangle', 40, 100, 300, 600, 0, 0, 4059297279, 8, '', 14),
+    ('rectangle', 380, 100, 300, 600, 1, 0, 4059297279, 8, '', 14),
+    ('rectangle', 720, 100, 300, 600, 2, 0, 4059297279, 8, '', 14),
+    ('text_box', 60, 120, 260, 30, 3, 255, 0, 0, 'To do', 20),
+    ('text_box', 400, 120, 260, 30, 4, 255, 0, 0, 'In progress', 20),
+    ('text_box', 740, 120, 260, 30, 5, 255, 0, 0, 'Done', 20)
+) AS e(type, x, y, width, height, z_index, stroke_color, fill_color, border_radius, text, font_size);
+
+WITH template AS (
+    INSERT INTO boards (slug_id, name, owner_id, template_scope)
+    SELECT 'templ-retro-', 'Retrospective', id, 'system' FROM users WHERE email = 'templates@whiteboard.system'
+    RETURNING id
+), page AS (
+    INSERT INTO board_pages (board_id, name, position)
+    SELECT id, 'Retro', 0 FROM template
+    RETURNING id
+)
+INSERT INTO board_elements (page_id, type, x, y, width, height, z_index, stroke_color, fill_color, border_radius, text, font_size)
+SELECT page.id, e.type, e.x, e.y, e.width, e.height, e.z_index, e.stroke_color, e.fill_color, e.border_radius, e.text, e.font_size
+FROM page, (VALUES
+    ('rectangle', 40, 100, 300, 600, 0, 0, 3556366591, 8, '', 14),
+    ('rectangle', 380, 100, 300, 600, 1, 0, 4293125119, 8, '', 14),
+    ('rectangle', 720, 100, 300, 600, 2, 0, 3505127423, 8, '', 14),
+    ('text_box', 60, 120, 260, 30, 3, 255, 0, 0, 'Went well', 20),
+    ('text_box', 400, 120, 260, 30, 4, 255, 0, 0, 'To improve', 20),
+    ('text_box', 740, 120, 260, 30, 5, 255, 0, 0, 'Action items', 20)
+) AS e(type, x, y, width, height, z_index, stroke_color, fill_color, border_radius, text, font_size);
+
+-- +goose Down
+DELETE FROM users WHERE email = 'templates@whiteboard.system';
+DROP INDEX IF EXISTS idx_boards_template_scope;
+ALTER TABLE boards DROP CONSTRAINT IF EXISTS chk_boards_template_scope;
+ALTER TABLE boards DROP COLUMN template_scope;
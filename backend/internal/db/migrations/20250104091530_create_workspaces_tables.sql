@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "workspaces" (
+    id SERIAL PRIMARY KEY,
+    name varchar(50) NOT NULL,
+    created_by BIGINT REFERENCES users ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "workspace_members" (
+    workspace_id BIGINT NOT NULL REFERENCES workspaces ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
+    role varchar(20) NOT NULL DEFAULT 'viewer',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (workspace_id, user_id),
+    CONSTRAINT chk_workspace_members_role CHECK ( role IN ('admin', 'editor', 'viewer') )
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members (user_id);
+
+ALTER TABLE boards ADD COLUMN workspace_id BIGINT REFERENCES workspaces ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_boards_workspace_id ON boards (workspace_id) WHERE workspace_id IS NOT NULL;
+
+ALTER TABLE boards DROP CONSTRAINT IF EXISTS chk_boards_template_scope;
+ALTER TABLE boards ADD CONSTRAINT chk_boards_template_scope CHECK ( template_scope IN ('personal', 'workspace', 'system') );
+
+-- +goose Down
+UPDATE boards SET template_scope = NULL WHERE template_scope = 'workspace';
+ALTER TABLE boards DROP CONSTRAINT IF EXISTS chk_boards_template_scope;
+ALTER TABLE boards ADD CONSTRAINT chk_boards_template_scope CHECK ( template_scope IN ('personal', 'system') );
+
+DROP INDEX IF EXISTS idx_boards_workspace_id;
+ALTER TABLE boards DROP COLUMN workspace_id;
+
+DROP INDEX IF EXISTS idx_workspace_members_user_id;
+DROP TABLE IF EXISTS "workspace_members";
+DROP TABLE IF EXISTS "workspaces";
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "board_exports" (
+    id SERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    page_id BIGINT NOT NULL REFERENCES board_pages ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
+    format varchar(10) NOT NULL,
+    scale double precision NOT NULL DEFAULT 1,
+    region_x double precision,
+    region_y double precision,
+    region_width double precision,
+    region_height double precision,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    content bytea,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    completed_at timestamp with time zone,
+    CONSTRAINT chk_board_exports_format CHECK ( format IN ('png') ),
+    CONSTRAINT chk_board_exports_status CHECK ( status IN ('pending', 'completed', 'failed') )
+);
+
+CREATE INDEX IF NOT EXISTS idx_board_exports_created_at ON board_exports (created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS "board_exports";
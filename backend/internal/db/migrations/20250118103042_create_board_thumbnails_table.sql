@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS "board_thumbnails" (
+    board_id BIGINT PRIMARY KEY REFERENCES boards ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    content bytea NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS "board_thumbnails";
@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE board_exports ALTER COLUMN page_id DROP NOT NULL;
+ALTER TABLE board_exports ADD COLUMN email CITEXT;
+ALTER TABLE board_exports ADD COLUMN emailed_at timestamp with time zone;
+ALTER TABLE board_exports DROP CONSTRAINT chk_board_exports_format;
+ALTER TABLE board_exports ADD CONSTRAINT chk_board_exports_format CHECK ( format IN ('png', 'pdf') );
+
+-- +goose Down
+DELETE FROM board_exports WHERE format = 'pdf';
+ALTER TABLE board_exports DROP CONSTRAINT chk_board_exports_format;
+ALTER TABLE board_exports ADD CONSTRAINT chk_board_exports_format CHECK ( format IN ('png') );
+ALTER TABLE board_exports DROP COLUMN emailed_at;
+ALTER TABLE board_exports DROP COLUMN email;
+ALTER TABLE board_exports ALTER COLUMN page_id SET NOT NULL;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- board_invitations was created without the hash column for a while. The invitations of those databases
+-- get a random hash that no token matches, so they can still be accepted by email, but not by link.
+ALTER TABLE board_invitations ADD COLUMN IF NOT EXISTS hash bytea;
+UPDATE board_invitations SET hash = sha256(gen_random_uuid()::text::bytea) WHERE hash IS NULL;
+ALTER TABLE board_invitations ALTER COLUMN hash SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_board_invitations_hash ON board_invitations (hash);
+
+-- +goose Down
+-- the hash column is a part of the table since it is created, only the index is added here
+DROP INDEX IF EXISTS idx_board_invitations_hash;
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
 )
 
@@ -35,6 +36,36 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptBoardInvitationByHash mocks base method.
+func (m *MockStore) AcceptBoardInvitationByHash(arg0 context.Context, arg1 db.AcceptBoardInvitationByHashParams) (db.BoardUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptBoardInvitationByHash", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptBoardInvitationByHash indicates an expected call of AcceptBoardInvitationByHash.
+func (mr *MockStoreMockRecorder) AcceptBoardInvitationByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptBoardInvitationByHash", reflect.TypeOf((*MockStore)(nil).AcceptBoardInvitationByHash), arg0, arg1)
+}
+
+// AcceptBoardInvitations mocks base method.
+func (m *MockStore) AcceptBoardInvitations(arg0 context.Context, arg1 db.AcceptBoardInvitationsParams) ([]db.BoardUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptBoardInvitations", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptBoardInvitations indicates an expected call of AcceptBoardInvitations.
+func (mr *MockStoreMockRecorder) AcceptBoardInvitations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptBoardInvitations", reflect.TypeOf((*MockStore)(nil).AcceptBoardInvitations), arg0, arg1)
+}
+
 // ActivateUserTx mocks base method.
 func (m *MockStore) ActivateUserTx(arg0 context.Context, arg1 string) (db.ActivateUserTxResult, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +126,51 @@ func (mr *MockStoreMockRecorder) AddToBoardUsers(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToBoardUsers", reflect.TypeOf((*MockStore)(nil).AddToBoardUsers), arg0, arg1)
 }
 
+// AddWorkspaceMember mocks base method.
+func (m *MockStore) AddWorkspaceMember(arg0 context.Context, arg1 db.AddWorkspaceMemberParams) (db.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkspaceMember", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWorkspaceMember indicates an expected call of AddWorkspaceMember.
+func (mr *MockStoreMockRecorder) AddWorkspaceMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkspaceMember", reflect.TypeOf((*MockStore)(nil).AddWorkspaceMember), arg0, arg1)
+}
+
+// CompleteBoardExport mocks base method.
+func (m *MockStore) CompleteBoardExport(arg0 context.Context, arg1 db.CompleteBoardExportParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteBoardExport", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteBoardExport indicates an expected call of CompleteBoardExport.
+func (mr *MockStoreMockRecorder) CompleteBoardExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteBoardExport", reflect.TypeOf((*MockStore)(nil).CompleteBoardExport), arg0, arg1)
+}
+
+// CopyBoardPageElements mocks base method.
+func (m *MockStore) CopyBoardPageElements(arg0 context.Context, arg1 db.CopyBoardPageElementsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyBoardPageElements", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyBoardPageElements indicates an expected call of CopyBoardPageElements.
+func (mr *MockStoreMockRecorder) CopyBoardPageElements(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyBoardPageElements", reflect.TypeOf((*MockStore)(nil).CopyBoardPageElements), arg0, arg1)
+}
+
 // CreateBoard mocks base method.
 func (m *MockStore) CreateBoard(arg0 context.Context, arg1 db.CreateBoardParams) (db.Board, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +186,81 @@ func (mr *MockStoreMockRecorder) CreateBoard(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoard", reflect.TypeOf((*MockStore)(nil).CreateBoard), arg0, arg1)
 }
 
+// CreateBoardElement mocks base method.
+func (m *MockStore) CreateBoardElement(arg0 context.Context, arg1 db.CreateBoardElementParams) (db.BoardElement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardElement", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardElement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardElement indicates an expected call of CreateBoardElement.
+func (mr *MockStoreMockRecorder) CreateBoardElement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardElement", reflect.TypeOf((*MockStore)(nil).CreateBoardElement), arg0, arg1)
+}
+
+// CreateBoardExport mocks base method.
+func (m *MockStore) CreateBoardExport(arg0 context.Context, arg1 db.CreateBoardExportParams) (db.BoardExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardExport", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardExport indicates an expected call of CreateBoardExport.
+func (mr *MockStoreMockRecorder) CreateBoardExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardExport", reflect.TypeOf((*MockStore)(nil).CreateBoardExport), arg0, arg1)
+}
+
+// CreateBoardFolder mocks base method.
+func (m *MockStore) CreateBoardFolder(arg0 context.Context, arg1 db.CreateBoardFolderParams) (db.BoardFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardFolder", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardFolder indicates an expected call of CreateBoardFolder.
+func (mr *MockStoreMockRecorder) CreateBoardFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardFolder", reflect.TypeOf((*MockStore)(nil).CreateBoardFolder), arg0, arg1)
+}
+
+// CreateBoardInvitation mocks base method.
+func (m *MockStore) CreateBoardInvitation(arg0 context.Context, arg1 db.CreateBoardInvitationParams) (db.BoardInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardInvitation indicates an expected call of CreateBoardInvitation.
+func (mr *MockStoreMockRecorder) CreateBoardInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardInvitation", reflect.TypeOf((*MockStore)(nil).CreateBoardInvitation), arg0, arg1)
+}
+
+// CreateBoardOperation mocks base method.
+func (m *MockStore) CreateBoardOperation(arg0 context.Context, arg1 db.CreateBoardOperationParams) (db.BoardOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardOperation", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardOperation indicates an expected call of CreateBoardOperation.
+func (mr *MockStoreMockRecorder) CreateBoardOperation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardOperation", reflect.TypeOf((*MockStore)(nil).CreateBoardOperation), arg0, arg1)
+}
+
 // CreateBoardPage mocks base method.
 func (m *MockStore) CreateBoardPage(arg0 context.Context, arg1 db.CreateBoardPageParams) (db.BoardPage, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +276,51 @@ func (mr *MockStoreMockRecorder) CreateBoardPage(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardPage", reflect.TypeOf((*MockStore)(nil).CreateBoardPage), arg0, arg1)
 }
 
+// CreateBoardPdfExport mocks base method.
+func (m *MockStore) CreateBoardPdfExport(arg0 context.Context, arg1 db.CreateBoardPdfExportParams) (db.BoardExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardPdfExport", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardPdfExport indicates an expected call of CreateBoardPdfExport.
+func (mr *MockStoreMockRecorder) CreateBoardPdfExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardPdfExport", reflect.TypeOf((*MockStore)(nil).CreateBoardPdfExport), arg0, arg1)
+}
+
+// CreateBoardShareLink mocks base method.
+func (m *MockStore) CreateBoardShareLink(arg0 context.Context, arg1 db.CreateBoardShareLinkParams) (db.BoardShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardShareLink", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardShareLink indicates an expected call of CreateBoardShareLink.
+func (mr *MockStoreMockRecorder) CreateBoardShareLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardShareLink", reflect.TypeOf((*MockStore)(nil).CreateBoardShareLink), arg0, arg1)
+}
+
+// CreateBoardSnapshot mocks base method.
+func (m *MockStore) CreateBoardSnapshot(arg0 context.Context, arg1 db.CreateBoardSnapshotParams) (db.BoardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardSnapshot indicates an expected call of CreateBoardSnapshot.
+func (mr *MockStoreMockRecorder) CreateBoardSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardSnapshot", reflect.TypeOf((*MockStore)(nil).CreateBoardSnapshot), arg0, arg1)
+}
+
 // CreateBoardTx mocks base method.
 func (m *MockStore) CreateBoardTx(arg0 context.Context, arg1 db.CreateBoardTxParams) (db.CreateBoardTxResult, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +336,36 @@ func (mr *MockStoreMockRecorder) CreateBoardTx(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardTx", reflect.TypeOf((*MockStore)(nil).CreateBoardTx), arg0, arg1)
 }
 
+// CreateElementTx mocks base method.
+func (m *MockStore) CreateElementTx(arg0 context.Context, arg1 db.CreateElementTxParams) (db.ElementOperationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateElementTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ElementOperationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateElementTx indicates an expected call of CreateElementTx.
+func (mr *MockStoreMockRecorder) CreateElementTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateElementTx", reflect.TypeOf((*MockStore)(nil).CreateElementTx), arg0, arg1)
+}
+
+// CreatePageTx mocks base method.
+func (m *MockStore) CreatePageTx(arg0 context.Context, arg1 db.CreatePageTxParams) (db.PageOperationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePageTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PageOperationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePageTx indicates an expected call of CreatePageTx.
+func (mr *MockStoreMockRecorder) CreatePageTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePageTx", reflect.TypeOf((*MockStore)(nil).CreatePageTx), arg0, arg1)
+}
+
 // CreatePermission mocks base method.
 func (m *MockStore) CreatePermission(arg0 context.Context, arg1 string) (db.Permission, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +381,21 @@ func (mr *MockStoreMockRecorder) CreatePermission(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePermission", reflect.TypeOf((*MockStore)(nil).CreatePermission), arg0, arg1)
 }
 
+// CreateSnapshotTx mocks base method.
+func (m *MockStore) CreateSnapshotTx(arg0 context.Context, arg1 string) (db.CreateSnapshotTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSnapshotTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateSnapshotTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSnapshotTx indicates an expected call of CreateSnapshotTx.
+func (mr *MockStoreMockRecorder) CreateSnapshotTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSnapshotTx", reflect.TypeOf((*MockStore)(nil).CreateSnapshotTx), arg0, arg1)
+}
+
 // CreateToken mocks base method.
 func (m *MockStore) CreateToken(arg0 context.Context, arg1 db.CreateTokenParams) (db.Token, error) {
 	m.ctrl.T.Helper()
@@ -185,166 +426,1318 @@ func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
 }
 
-// DeleteTokensForUser mocks base method.
-func (m *MockStore) DeleteTokensForUser(arg0 context.Context, arg1 db.DeleteTokensForUserParams) error {
+// CreateWorkspace mocks base method.
+func (m *MockStore) CreateWorkspace(arg0 context.Context, arg1 db.CreateWorkspaceParams) (db.Workspace, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTokensForUser", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteTokensForUser indicates an expected call of DeleteTokensForUser.
-func (mr *MockStoreMockRecorder) DeleteTokensForUser(arg0, arg1 interface{}) *gomock.Call {
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockStoreMockRecorder) CreateWorkspace(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTokensForUser", reflect.TypeOf((*MockStore)(nil).DeleteTokensForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockStore)(nil).CreateWorkspace), arg0, arg1)
 }
 
-// GetAllBoardsForUser mocks base method.
-func (m *MockStore) GetAllBoardsForUser(arg0 context.Context, arg1 int64) ([]db.GetAllBoardsForUserRow, error) {
+// CreateWorkspaceTx mocks base method.
+func (m *MockStore) CreateWorkspaceTx(arg0 context.Context, arg1 db.CreateWorkspaceTxParams) (db.CreateWorkspaceTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllBoardsForUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetAllBoardsForUserRow)
+	ret := m.ctrl.Call(m, "CreateWorkspaceTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateWorkspaceTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllBoardsForUser indicates an expected call of GetAllBoardsForUser.
-func (mr *MockStoreMockRecorder) GetAllBoardsForUser(arg0, arg1 interface{}) *gomock.Call {
+// CreateWorkspaceTx indicates an expected call of CreateWorkspaceTx.
+func (mr *MockStoreMockRecorder) CreateWorkspaceTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBoardsForUser", reflect.TypeOf((*MockStore)(nil).GetAllBoardsForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceTx", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceTx), arg0, arg1)
 }
 
-// GetAllPermissionsForUser mocks base method.
-func (m *MockStore) GetAllPermissionsForUser(arg0 context.Context, arg1 int32) ([]string, error) {
+// DeleteBoardElement mocks base method.
+func (m *MockStore) DeleteBoardElement(arg0 context.Context, arg1 db.DeleteBoardElementParams) (db.BoardElement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPermissionsForUser", arg0, arg1)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "DeleteBoardElement", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardElement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllPermissionsForUser indicates an expected call of GetAllPermissionsForUser.
-func (mr *MockStoreMockRecorder) GetAllPermissionsForUser(arg0, arg1 interface{}) *gomock.Call {
+// DeleteBoardElement indicates an expected call of DeleteBoardElement.
+func (mr *MockStoreMockRecorder) DeleteBoardElement(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPermissionsForUser", reflect.TypeOf((*MockStore)(nil).GetAllPermissionsForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardElement", reflect.TypeOf((*MockStore)(nil).DeleteBoardElement), arg0, arg1)
 }
 
-// GetBoardById mocks base method.
-func (m *MockStore) GetBoardById(arg0 context.Context, arg1 int32) (db.Board, error) {
+// DeleteBoardFolder mocks base method.
+func (m *MockStore) DeleteBoardFolder(arg0 context.Context, arg1 db.DeleteBoardFolderParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardById", arg0, arg1)
-	ret0, _ := ret[0].(db.Board)
+	ret := m.ctrl.Call(m, "DeleteBoardFolder", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardById indicates an expected call of GetBoardById.
-func (mr *MockStoreMockRecorder) GetBoardById(arg0, arg1 interface{}) *gomock.Call {
+// DeleteBoardFolder indicates an expected call of DeleteBoardFolder.
+func (mr *MockStoreMockRecorder) DeleteBoardFolder(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardById", reflect.TypeOf((*MockStore)(nil).GetBoardById), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardFolder", reflect.TypeOf((*MockStore)(nil).DeleteBoardFolder), arg0, arg1)
 }
 
-// GetBoardBySlugId mocks base method.
-func (m *MockStore) GetBoardBySlugId(arg0 context.Context, arg1 db.GetBoardBySlugIdParams) (db.GetBoardBySlugIdRow, error) {
+// DeleteBoardOperationsUntilRevision mocks base method.
+func (m *MockStore) DeleteBoardOperationsUntilRevision(arg0 context.Context, arg1 db.DeleteBoardOperationsUntilRevisionParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardBySlugId", arg0, arg1)
-	ret0, _ := ret[0].(db.GetBoardBySlugIdRow)
+	ret := m.ctrl.Call(m, "DeleteBoardOperationsUntilRevision", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardBySlugId indicates an expected call of GetBoardBySlugId.
-func (mr *MockStoreMockRecorder) GetBoardBySlugId(arg0, arg1 interface{}) *gomock.Call {
+// DeleteBoardOperationsUntilRevision indicates an expected call of DeleteBoardOperationsUntilRevision.
+func (mr *MockStoreMockRecorder) DeleteBoardOperationsUntilRevision(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardBySlugId", reflect.TypeOf((*MockStore)(nil).GetBoardBySlugId), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardOperationsUntilRevision", reflect.TypeOf((*MockStore)(nil).DeleteBoardOperationsUntilRevision), arg0, arg1)
 }
 
-// GetBoardPageByBoardId mocks base method.
-func (m *MockStore) GetBoardPageByBoardId(arg0 context.Context, arg1 int64) ([]db.GetBoardPageByBoardIdRow, error) {
+// DeleteBoardPage mocks base method.
+func (m *MockStore) DeleteBoardPage(arg0 context.Context, arg1 db.DeleteBoardPageParams) (db.BoardPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardPageByBoardId", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetBoardPageByBoardIdRow)
+	ret := m.ctrl.Call(m, "DeleteBoardPage", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardPageByBoardId indicates an expected call of GetBoardPageByBoardId.
-func (mr *MockStoreMockRecorder) GetBoardPageByBoardId(arg0, arg1 interface{}) *gomock.Call {
+// DeleteBoardPage indicates an expected call of DeleteBoardPage.
+func (mr *MockStoreMockRecorder) DeleteBoardPage(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardPageByBoardId", reflect.TypeOf((*MockStore)(nil).GetBoardPageByBoardId), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardPage", reflect.TypeOf((*MockStore)(nil).DeleteBoardPage), arg0, arg1)
 }
 
-// GetBoardUsers mocks base method.
-func (m *MockStore) GetBoardUsers(arg0 context.Context, arg1 int64) ([]db.GetBoardUsersRow, error) {
+// DeleteBoardUser mocks base method.
+func (m *MockStore) DeleteBoardUser(arg0 context.Context, arg1 db.DeleteBoardUserParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBoardUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetBoardUsersRow)
+	ret := m.ctrl.Call(m, "DeleteBoardUser", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBoardUsers indicates an expected call of GetBoardUsers.
-func (mr *MockStoreMockRecorder) GetBoardUsers(arg0, arg1 interface{}) *gomock.Call {
+// DeleteBoardUser indicates an expected call of DeleteBoardUser.
+func (mr *MockStoreMockRecorder) DeleteBoardUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardUsers", reflect.TypeOf((*MockStore)(nil).GetBoardUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardUser", reflect.TypeOf((*MockStore)(nil).DeleteBoardUser), arg0, arg1)
 }
 
-// GetForToken mocks base method.
-func (m *MockStore) GetForToken(arg0 context.Context, arg1 db.GetForTokenParams) (db.GetForTokenRow, error) {
+// DeleteElementTx mocks base method.
+func (m *MockStore) DeleteElementTx(arg0 context.Context, arg1 db.DeleteElementTxParams) (db.ElementOperationTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetForToken", arg0, arg1)
-	ret0, _ := ret[0].(db.GetForTokenRow)
+	ret := m.ctrl.Call(m, "DeleteElementTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ElementOperationTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetForToken indicates an expected call of GetForToken.
-func (mr *MockStoreMockRecorder) GetForToken(arg0, arg1 interface{}) *gomock.Call {
+// DeleteElementTx indicates an expected call of DeleteElementTx.
+func (mr *MockStoreMockRecorder) DeleteElementTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForToken", reflect.TypeOf((*MockStore)(nil).GetForToken), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteElementTx", reflect.TypeOf((*MockStore)(nil).DeleteElementTx), arg0, arg1)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+// DeletePageTx mocks base method.
+func (m *MockStore) DeletePageTx(arg0 context.Context, arg1 db.DeletePageTxParams) (db.PageOperationTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "DeletePageTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PageOperationTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+// DeletePageTx indicates an expected call of DeletePageTx.
+func (mr *MockStoreMockRecorder) DeletePageTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePageTx", reflect.TypeOf((*MockStore)(nil).DeletePageTx), arg0, arg1)
 }
 
-// RegisterUserTx mocks base method.
-func (m *MockStore) RegisterUserTx(arg0 context.Context, arg1 db.RegisterUserTxParams) (db.RegisterUserTxResult, error) {
+// DeleteTokensForUser mocks base method.
+func (m *MockStore) DeleteTokensForUser(arg0 context.Context, arg1 db.DeleteTokensForUserParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterUserTx", arg0, arg1)
-	ret0, _ := ret[0].(db.RegisterUserTxResult)
+	ret := m.ctrl.Call(m, "DeleteTokensForUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTokensForUser indicates an expected call of DeleteTokensForUser.
+func (mr *MockStoreMockRecorder) DeleteTokensForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTokensForUser", reflect.TypeOf((*MockStore)(nil).DeleteTokensForUser), arg0, arg1)
+}
+
+// DeleteWorkspace mocks base method.
+func (m *MockStore) DeleteWorkspace(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RegisterUserTx indicates an expected call of RegisterUserTx.
-func (mr *MockStoreMockRecorder) RegisterUserTx(arg0, arg1 interface{}) *gomock.Call {
+// DeleteWorkspace indicates an expected call of DeleteWorkspace.
+func (mr *MockStoreMockRecorder) DeleteWorkspace(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUserTx", reflect.TypeOf((*MockStore)(nil).RegisterUserTx), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockStore)(nil).DeleteWorkspace), arg0, arg1)
 }
 
-// UpdateUser mocks base method.
-func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
+// DeleteWorkspaceMember mocks base method.
+func (m *MockStore) DeleteWorkspaceMember(arg0 context.Context, arg1 db.DeleteWorkspaceMemberParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "DeleteWorkspaceMember", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call {
+// DeleteWorkspaceMember indicates an expected call of DeleteWorkspaceMember.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceMember(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceMember", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceMember), arg0, arg1)
+}
+
+// DuplicateBoardPageTx mocks base method.
+func (m *MockStore) DuplicateBoardPageTx(arg0 context.Context, arg1 db.DuplicateBoardPageTxParams) (db.DuplicateBoardPageTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuplicateBoardPageTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DuplicateBoardPageTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuplicateBoardPageTx indicates an expected call of DuplicateBoardPageTx.
+func (mr *MockStoreMockRecorder) DuplicateBoardPageTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoardPageTx", reflect.TypeOf((*MockStore)(nil).DuplicateBoardPageTx), arg0, arg1)
+}
+
+// DuplicateBoardTx mocks base method.
+func (m *MockStore) DuplicateBoardTx(arg0 context.Context, arg1 db.DuplicateBoardTxParams) (db.DuplicateBoardTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DuplicateBoardTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DuplicateBoardTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DuplicateBoardTx indicates an expected call of DuplicateBoardTx.
+func (mr *MockStoreMockRecorder) DuplicateBoardTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoardTx", reflect.TypeOf((*MockStore)(nil).DuplicateBoardTx), arg0, arg1)
+}
+
+// FailBoardExport mocks base method.
+func (m *MockStore) FailBoardExport(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailBoardExport", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailBoardExport indicates an expected call of FailBoardExport.
+func (mr *MockStoreMockRecorder) FailBoardExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailBoardExport", reflect.TypeOf((*MockStore)(nil).FailBoardExport), arg0, arg1)
+}
+
+// GetAllBoardsForUser mocks base method.
+func (m *MockStore) GetAllBoardsForUser(arg0 context.Context, arg1 db.GetAllBoardsForUserParams) ([]db.GetAllBoardsForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllBoardsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetAllBoardsForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllBoardsForUser indicates an expected call of GetAllBoardsForUser.
+func (mr *MockStoreMockRecorder) GetAllBoardsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBoardsForUser", reflect.TypeOf((*MockStore)(nil).GetAllBoardsForUser), arg0, arg1)
+}
+
+// GetAllPermissionsForUser mocks base method.
+func (m *MockStore) GetAllPermissionsForUser(arg0 context.Context, arg1 int32) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPermissionsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPermissionsForUser indicates an expected call of GetAllPermissionsForUser.
+func (mr *MockStoreMockRecorder) GetAllPermissionsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPermissionsForUser", reflect.TypeOf((*MockStore)(nil).GetAllPermissionsForUser), arg0, arg1)
+}
+
+// GetBoardById mocks base method.
+func (m *MockStore) GetBoardById(arg0 context.Context, arg1 int32) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardById", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardById indicates an expected call of GetBoardById.
+func (mr *MockStoreMockRecorder) GetBoardById(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardById", reflect.TypeOf((*MockStore)(nil).GetBoardById), arg0, arg1)
+}
+
+// GetBoardByIdForUser mocks base method.
+func (m *MockStore) GetBoardByIdForUser(arg0 context.Context, arg1 db.GetBoardByIdForUserParams) (db.GetBoardByIdForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardByIdForUser", arg0, arg1)
+	ret0, _ := ret[0].(db.GetBoardByIdForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardByIdForUser indicates an expected call of GetBoardByIdForUser.
+func (mr *MockStoreMockRecorder) GetBoardByIdForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardByIdForUser", reflect.TypeOf((*MockStore)(nil).GetBoardByIdForUser), arg0, arg1)
+}
+
+// GetBoardByShareLink mocks base method.
+func (m *MockStore) GetBoardByShareLink(arg0 context.Context, arg1 db.GetBoardByShareLinkParams) (db.GetBoardByShareLinkRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardByShareLink", arg0, arg1)
+	ret0, _ := ret[0].(db.GetBoardByShareLinkRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardByShareLink indicates an expected call of GetBoardByShareLink.
+func (mr *MockStoreMockRecorder) GetBoardByShareLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardByShareLink", reflect.TypeOf((*MockStore)(nil).GetBoardByShareLink), arg0, arg1)
+}
+
+// GetBoardBySlugId mocks base method.
+func (m *MockStore) GetBoardBySlugId(arg0 context.Context, arg1 db.GetBoardBySlugIdParams) (db.GetBoardBySlugIdRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardBySlugId", arg0, arg1)
+	ret0, _ := ret[0].(db.GetBoardBySlugIdRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardBySlugId indicates an expected call of GetBoardBySlugId.
+func (mr *MockStoreMockRecorder) GetBoardBySlugId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardBySlugId", reflect.TypeOf((*MockStore)(nil).GetBoardBySlugId), arg0, arg1)
+}
+
+// GetBoardElementBySlugId mocks base method.
+func (m *MockStore) GetBoardElementBySlugId(arg0 context.Context, arg1 db.GetBoardElementBySlugIdParams) (db.BoardElement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardElementBySlugId", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardElement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardElementBySlugId indicates an expected call of GetBoardElementBySlugId.
+func (mr *MockStoreMockRecorder) GetBoardElementBySlugId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardElementBySlugId", reflect.TypeOf((*MockStore)(nil).GetBoardElementBySlugId), arg0, arg1)
+}
+
+// GetBoardElementsByBoardId mocks base method.
+func (m *MockStore) GetBoardElementsByBoardId(arg0 context.Context, arg1 int64) ([]db.BoardElement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardElementsByBoardId", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardElement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardElementsByBoardId indicates an expected call of GetBoardElementsByBoardId.
+func (mr *MockStoreMockRecorder) GetBoardElementsByBoardId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardElementsByBoardId", reflect.TypeOf((*MockStore)(nil).GetBoardElementsByBoardId), arg0, arg1)
+}
+
+// GetBoardElementsByPageId mocks base method.
+func (m *MockStore) GetBoardElementsByPageId(arg0 context.Context, arg1 int64) ([]db.BoardElement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardElementsByPageId", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardElement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardElementsByPageId indicates an expected call of GetBoardElementsByPageId.
+func (mr *MockStoreMockRecorder) GetBoardElementsByPageId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardElementsByPageId", reflect.TypeOf((*MockStore)(nil).GetBoardElementsByPageId), arg0, arg1)
+}
+
+// GetBoardExport mocks base method.
+func (m *MockStore) GetBoardExport(arg0 context.Context, arg1 int32) (db.BoardExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardExport", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardExport indicates an expected call of GetBoardExport.
+func (mr *MockStoreMockRecorder) GetBoardExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardExport", reflect.TypeOf((*MockStore)(nil).GetBoardExport), arg0, arg1)
+}
+
+// GetBoardExportForUser mocks base method.
+func (m *MockStore) GetBoardExportForUser(arg0 context.Context, arg1 db.GetBoardExportForUserParams) (db.BoardExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardExportForUser", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardExportForUser indicates an expected call of GetBoardExportForUser.
+func (mr *MockStoreMockRecorder) GetBoardExportForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardExportForUser", reflect.TypeOf((*MockStore)(nil).GetBoardExportForUser), arg0, arg1)
+}
+
+// GetBoardFoldersForUser mocks base method.
+func (m *MockStore) GetBoardFoldersForUser(arg0 context.Context, arg1 int64) ([]db.BoardFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardFoldersForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardFoldersForUser indicates an expected call of GetBoardFoldersForUser.
+func (mr *MockStoreMockRecorder) GetBoardFoldersForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardFoldersForUser", reflect.TypeOf((*MockStore)(nil).GetBoardFoldersForUser), arg0, arg1)
+}
+
+// GetBoardForSnapshot mocks base method.
+func (m *MockStore) GetBoardForSnapshot(arg0 context.Context, arg1 string) (db.GetBoardForSnapshotRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardForSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(db.GetBoardForSnapshotRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardForSnapshot indicates an expected call of GetBoardForSnapshot.
+func (mr *MockStoreMockRecorder) GetBoardForSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardForSnapshot", reflect.TypeOf((*MockStore)(nil).GetBoardForSnapshot), arg0, arg1)
+}
+
+// GetBoardOperationsAfterRevision mocks base method.
+func (m *MockStore) GetBoardOperationsAfterRevision(arg0 context.Context, arg1 db.GetBoardOperationsAfterRevisionParams) ([]db.BoardOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardOperationsAfterRevision", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardOperationsAfterRevision indicates an expected call of GetBoardOperationsAfterRevision.
+func (mr *MockStoreMockRecorder) GetBoardOperationsAfterRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardOperationsAfterRevision", reflect.TypeOf((*MockStore)(nil).GetBoardOperationsAfterRevision), arg0, arg1)
+}
+
+// GetBoardOperationsBetweenRevisions mocks base method.
+func (m *MockStore) GetBoardOperationsBetweenRevisions(arg0 context.Context, arg1 db.GetBoardOperationsBetweenRevisionsParams) ([]db.BoardOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardOperationsBetweenRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardOperationsBetweenRevisions indicates an expected call of GetBoardOperationsBetweenRevisions.
+func (mr *MockStoreMockRecorder) GetBoardOperationsBetweenRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardOperationsBetweenRevisions", reflect.TypeOf((*MockStore)(nil).GetBoardOperationsBetweenRevisions), arg0, arg1)
+}
+
+// GetBoardOperationsWithAuthors mocks base method.
+func (m *MockStore) GetBoardOperationsWithAuthors(arg0 context.Context, arg1 db.GetBoardOperationsWithAuthorsParams) ([]db.GetBoardOperationsWithAuthorsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardOperationsWithAuthors", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetBoardOperationsWithAuthorsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardOperationsWithAuthors indicates an expected call of GetBoardOperationsWithAuthors.
+func (mr *MockStoreMockRecorder) GetBoardOperationsWithAuthors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardOperationsWithAuthors", reflect.TypeOf((*MockStore)(nil).GetBoardOperationsWithAuthors), arg0, arg1)
+}
+
+// GetBoardPage mocks base method.
+func (m *MockStore) GetBoardPage(arg0 context.Context, arg1 db.GetBoardPageParams) (db.BoardPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardPage", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardPage indicates an expected call of GetBoardPage.
+func (mr *MockStoreMockRecorder) GetBoardPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardPage", reflect.TypeOf((*MockStore)(nil).GetBoardPage), arg0, arg1)
+}
+
+// GetBoardPageByBoardId mocks base method.
+func (m *MockStore) GetBoardPageByBoardId(arg0 context.Context, arg1 int64) ([]db.GetBoardPageByBoardIdRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardPageByBoardId", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetBoardPageByBoardIdRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardPageByBoardId indicates an expected call of GetBoardPageByBoardId.
+func (mr *MockStoreMockRecorder) GetBoardPageByBoardId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardPageByBoardId", reflect.TypeOf((*MockStore)(nil).GetBoardPageByBoardId), arg0, arg1)
+}
+
+// GetBoardPageBySlugId mocks base method.
+func (m *MockStore) GetBoardPageBySlugId(arg0 context.Context, arg1 db.GetBoardPageBySlugIdParams) (db.GetBoardPageBySlugIdRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardPageBySlugId", arg0, arg1)
+	ret0, _ := ret[0].(db.GetBoardPageBySlugIdRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardPageBySlugId indicates an expected call of GetBoardPageBySlugId.
+func (mr *MockStoreMockRecorder) GetBoardPageBySlugId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardPageBySlugId", reflect.TypeOf((*MockStore)(nil).GetBoardPageBySlugId), arg0, arg1)
+}
+
+// GetBoardRevisionBySlugId mocks base method.
+func (m *MockStore) GetBoardRevisionBySlugId(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardRevisionBySlugId", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardRevisionBySlugId indicates an expected call of GetBoardRevisionBySlugId.
+func (mr *MockStoreMockRecorder) GetBoardRevisionBySlugId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardRevisionBySlugId", reflect.TypeOf((*MockStore)(nil).GetBoardRevisionBySlugId), arg0, arg1)
+}
+
+// GetBoardShareLinks mocks base method.
+func (m *MockStore) GetBoardShareLinks(arg0 context.Context, arg1 int64) ([]db.BoardShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardShareLinks", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardShareLinks indicates an expected call of GetBoardShareLinks.
+func (mr *MockStoreMockRecorder) GetBoardShareLinks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardShareLinks", reflect.TypeOf((*MockStore)(nil).GetBoardShareLinks), arg0, arg1)
+}
+
+// GetBoardSnapshotRevisions mocks base method.
+func (m *MockStore) GetBoardSnapshotRevisions(arg0 context.Context, arg1 string) ([]db.GetBoardSnapshotRevisionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardSnapshotRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetBoardSnapshotRevisionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardSnapshotRevisions indicates an expected call of GetBoardSnapshotRevisions.
+func (mr *MockStoreMockRecorder) GetBoardSnapshotRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardSnapshotRevisions", reflect.TypeOf((*MockStore)(nil).GetBoardSnapshotRevisions), arg0, arg1)
+}
+
+// GetBoardThumbnail mocks base method.
+func (m *MockStore) GetBoardThumbnail(arg0 context.Context, arg1 int64) (db.BoardThumbnail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardThumbnail", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardThumbnail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardThumbnail indicates an expected call of GetBoardThumbnail.
+func (mr *MockStoreMockRecorder) GetBoardThumbnail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardThumbnail", reflect.TypeOf((*MockStore)(nil).GetBoardThumbnail), arg0, arg1)
+}
+
+// GetBoardThumbnailSource mocks base method.
+func (m *MockStore) GetBoardThumbnailSource(arg0 context.Context, arg1 string) (db.GetBoardThumbnailSourceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardThumbnailSource", arg0, arg1)
+	ret0, _ := ret[0].(db.GetBoardThumbnailSourceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardThumbnailSource indicates an expected call of GetBoardThumbnailSource.
+func (mr *MockStoreMockRecorder) GetBoardThumbnailSource(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardThumbnailSource", reflect.TypeOf((*MockStore)(nil).GetBoardThumbnailSource), arg0, arg1)
+}
+
+// GetBoardUsers mocks base method.
+func (m *MockStore) GetBoardUsers(arg0 context.Context, arg1 int64) ([]db.GetBoardUsersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetBoardUsersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardUsers indicates an expected call of GetBoardUsers.
+func (mr *MockStoreMockRecorder) GetBoardUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardUsers", reflect.TypeOf((*MockStore)(nil).GetBoardUsers), arg0, arg1)
+}
+
+// GetDeletedBoardsForOwner mocks base method.
+func (m *MockStore) GetDeletedBoardsForOwner(arg0 context.Context, arg1 int64) ([]db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedBoardsForOwner", arg0, arg1)
+	ret0, _ := ret[0].([]db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedBoardsForOwner indicates an expected call of GetDeletedBoardsForOwner.
+func (mr *MockStoreMockRecorder) GetDeletedBoardsForOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedBoardsForOwner", reflect.TypeOf((*MockStore)(nil).GetDeletedBoardsForOwner), arg0, arg1)
+}
+
+// GetForToken mocks base method.
+func (m *MockStore) GetForToken(arg0 context.Context, arg1 db.GetForTokenParams) (db.GetForTokenRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForToken", arg0, arg1)
+	ret0, _ := ret[0].(db.GetForTokenRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForToken indicates an expected call of GetForToken.
+func (mr *MockStoreMockRecorder) GetForToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForToken", reflect.TypeOf((*MockStore)(nil).GetForToken), arg0, arg1)
+}
+
+// GetLatestBoardSnapshotRevision mocks base method.
+func (m *MockStore) GetLatestBoardSnapshotRevision(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestBoardSnapshotRevision", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestBoardSnapshotRevision indicates an expected call of GetLatestBoardSnapshotRevision.
+func (mr *MockStoreMockRecorder) GetLatestBoardSnapshotRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBoardSnapshotRevision", reflect.TypeOf((*MockStore)(nil).GetLatestBoardSnapshotRevision), arg0, arg1)
+}
+
+// GetLatestBoardSnapshots mocks base method.
+func (m *MockStore) GetLatestBoardSnapshots(arg0 context.Context, arg1 string) ([]db.BoardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestBoardSnapshots", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestBoardSnapshots indicates an expected call of GetLatestBoardSnapshots.
+func (mr *MockStoreMockRecorder) GetLatestBoardSnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBoardSnapshots", reflect.TypeOf((*MockStore)(nil).GetLatestBoardSnapshots), arg0, arg1)
+}
+
+// GetPageCreateRevision mocks base method.
+func (m *MockStore) GetPageCreateRevision(arg0 context.Context, arg1 db.GetPageCreateRevisionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageCreateRevision", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageCreateRevision indicates an expected call of GetPageCreateRevision.
+func (mr *MockStoreMockRecorder) GetPageCreateRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageCreateRevision", reflect.TypeOf((*MockStore)(nil).GetPageCreateRevision), arg0, arg1)
+}
+
+// GetPageSnapshotAtRevision mocks base method.
+func (m *MockStore) GetPageSnapshotAtRevision(arg0 context.Context, arg1 db.GetPageSnapshotAtRevisionParams) (db.BoardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageSnapshotAtRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageSnapshotAtRevision indicates an expected call of GetPageSnapshotAtRevision.
+func (mr *MockStoreMockRecorder) GetPageSnapshotAtRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageSnapshotAtRevision", reflect.TypeOf((*MockStore)(nil).GetPageSnapshotAtRevision), arg0, arg1)
+}
+
+// GetRecentBoardsForUser mocks base method.
+func (m *MockStore) GetRecentBoardsForUser(arg0 context.Context, arg1 db.GetRecentBoardsForUserParams) ([]db.GetRecentBoardsForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentBoardsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetRecentBoardsForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentBoardsForUser indicates an expected call of GetRecentBoardsForUser.
+func (mr *MockStoreMockRecorder) GetRecentBoardsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentBoardsForUser", reflect.TypeOf((*MockStore)(nil).GetRecentBoardsForUser), arg0, arg1)
+}
+
+// GetTemplateForUser mocks base method.
+func (m *MockStore) GetTemplateForUser(arg0 context.Context, arg1 db.GetTemplateForUserParams) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateForUser", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateForUser indicates an expected call of GetTemplateForUser.
+func (mr *MockStoreMockRecorder) GetTemplateForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateForUser", reflect.TypeOf((*MockStore)(nil).GetTemplateForUser), arg0, arg1)
+}
+
+// GetTemplatesForUser mocks base method.
+func (m *MockStore) GetTemplatesForUser(arg0 context.Context, arg1 int64) ([]db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatesForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatesForUser indicates an expected call of GetTemplatesForUser.
+func (mr *MockStoreMockRecorder) GetTemplatesForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesForUser", reflect.TypeOf((*MockStore)(nil).GetTemplatesForUser), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetWorkspaceBoards mocks base method.
+func (m *MockStore) GetWorkspaceBoards(arg0 context.Context, arg1 pgtype.Int8) ([]db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBoards", arg0, arg1)
+	ret0, _ := ret[0].([]db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBoards indicates an expected call of GetWorkspaceBoards.
+func (mr *MockStoreMockRecorder) GetWorkspaceBoards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBoards", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBoards), arg0, arg1)
+}
+
+// GetWorkspaceMemberRole mocks base method.
+func (m *MockStore) GetWorkspaceMemberRole(arg0 context.Context, arg1 db.GetWorkspaceMemberRoleParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceMemberRole", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceMemberRole indicates an expected call of GetWorkspaceMemberRole.
+func (mr *MockStoreMockRecorder) GetWorkspaceMemberRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceMemberRole", reflect.TypeOf((*MockStore)(nil).GetWorkspaceMemberRole), arg0, arg1)
+}
+
+// GetWorkspaceMembers mocks base method.
+func (m *MockStore) GetWorkspaceMembers(arg0 context.Context, arg1 int64) ([]db.GetWorkspaceMembersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceMembers", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetWorkspaceMembersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceMembers indicates an expected call of GetWorkspaceMembers.
+func (mr *MockStoreMockRecorder) GetWorkspaceMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceMembers", reflect.TypeOf((*MockStore)(nil).GetWorkspaceMembers), arg0, arg1)
+}
+
+// GetWorkspacesForUser mocks base method.
+func (m *MockStore) GetWorkspacesForUser(arg0 context.Context, arg1 int64) ([]db.GetWorkspacesForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspacesForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetWorkspacesForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspacesForUser indicates an expected call of GetWorkspacesForUser.
+func (mr *MockStoreMockRecorder) GetWorkspacesForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspacesForUser", reflect.TypeOf((*MockStore)(nil).GetWorkspacesForUser), arg0, arg1)
+}
+
+// ImportBoardTx mocks base method.
+func (m *MockStore) ImportBoardTx(arg0 context.Context, arg1 db.ImportBoardTxParams) (db.ImportBoardTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBoardTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportBoardTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBoardTx indicates an expected call of ImportBoardTx.
+func (mr *MockStoreMockRecorder) ImportBoardTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBoardTx", reflect.TypeOf((*MockStore)(nil).ImportBoardTx), arg0, arg1)
+}
+
+// IncrementBoardRevision mocks base method.
+func (m *MockStore) IncrementBoardRevision(arg0 context.Context, arg1 string) (db.IncrementBoardRevisionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementBoardRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.IncrementBoardRevisionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementBoardRevision indicates an expected call of IncrementBoardRevision.
+func (mr *MockStoreMockRecorder) IncrementBoardRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementBoardRevision", reflect.TypeOf((*MockStore)(nil).IncrementBoardRevision), arg0, arg1)
+}
+
+// LockBoard mocks base method.
+func (m *MockStore) LockBoard(arg0 context.Context, arg1 int32) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockBoard", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockBoard indicates an expected call of LockBoard.
+func (mr *MockStoreMockRecorder) LockBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockBoard", reflect.TypeOf((*MockStore)(nil).LockBoard), arg0, arg1)
+}
+
+// MarkBoardExportEmailed mocks base method.
+func (m *MockStore) MarkBoardExportEmailed(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBoardExportEmailed", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkBoardExportEmailed indicates an expected call of MarkBoardExportEmailed.
+func (mr *MockStoreMockRecorder) MarkBoardExportEmailed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBoardExportEmailed", reflect.TypeOf((*MockStore)(nil).MarkBoardExportEmailed), arg0, arg1)
+}
+
+// PurgeBoardExports mocks base method.
+func (m *MockStore) PurgeBoardExports(arg0 context.Context, arg1 pgtype.Timestamptz) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBoardExports", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeBoardExports indicates an expected call of PurgeBoardExports.
+func (mr *MockStoreMockRecorder) PurgeBoardExports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBoardExports", reflect.TypeOf((*MockStore)(nil).PurgeBoardExports), arg0, arg1)
+}
+
+// PurgeDeletedBoards mocks base method.
+func (m *MockStore) PurgeDeletedBoards(arg0 context.Context, arg1 pgtype.Timestamptz) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBoards", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBoards indicates an expected call of PurgeDeletedBoards.
+func (mr *MockStoreMockRecorder) PurgeDeletedBoards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBoards", reflect.TypeOf((*MockStore)(nil).PurgeDeletedBoards), arg0, arg1)
+}
+
+// RegisterUserTx mocks base method.
+func (m *MockStore) RegisterUserTx(arg0 context.Context, arg1 db.RegisterUserTxParams) (db.RegisterUserTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUserTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RegisterUserTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterUserTx indicates an expected call of RegisterUserTx.
+func (mr *MockStoreMockRecorder) RegisterUserTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUserTx", reflect.TypeOf((*MockStore)(nil).RegisterUserTx), arg0, arg1)
+}
+
+// RenameBoard mocks base method.
+func (m *MockStore) RenameBoard(arg0 context.Context, arg1 db.RenameBoardParams) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameBoard", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameBoard indicates an expected call of RenameBoard.
+func (mr *MockStoreMockRecorder) RenameBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameBoard", reflect.TypeOf((*MockStore)(nil).RenameBoard), arg0, arg1)
+}
+
+// RenameBoardFolder mocks base method.
+func (m *MockStore) RenameBoardFolder(arg0 context.Context, arg1 db.RenameBoardFolderParams) (db.BoardFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameBoardFolder", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameBoardFolder indicates an expected call of RenameBoardFolder.
+func (mr *MockStoreMockRecorder) RenameBoardFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameBoardFolder", reflect.TypeOf((*MockStore)(nil).RenameBoardFolder), arg0, arg1)
+}
+
+// RenameBoardPage mocks base method.
+func (m *MockStore) RenameBoardPage(arg0 context.Context, arg1 db.RenameBoardPageParams) (db.BoardPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameBoardPage", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameBoardPage indicates an expected call of RenameBoardPage.
+func (mr *MockStoreMockRecorder) RenameBoardPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameBoardPage", reflect.TypeOf((*MockStore)(nil).RenameBoardPage), arg0, arg1)
+}
+
+// RenamePageTx mocks base method.
+func (m *MockStore) RenamePageTx(arg0 context.Context, arg1 db.RenamePageTxParams) (db.PageOperationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenamePageTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PageOperationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenamePageTx indicates an expected call of RenamePageTx.
+func (mr *MockStoreMockRecorder) RenamePageTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenamePageTx", reflect.TypeOf((*MockStore)(nil).RenamePageTx), arg0, arg1)
+}
+
+// RenameWorkspace mocks base method.
+func (m *MockStore) RenameWorkspace(arg0 context.Context, arg1 db.RenameWorkspaceParams) (db.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameWorkspace indicates an expected call of RenameWorkspace.
+func (mr *MockStoreMockRecorder) RenameWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameWorkspace", reflect.TypeOf((*MockStore)(nil).RenameWorkspace), arg0, arg1)
+}
+
+// ReorderBoardPages mocks base method.
+func (m *MockStore) ReorderBoardPages(arg0 context.Context, arg1 db.ReorderBoardPagesParams) ([]db.BoardPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderBoardPages", arg0, arg1)
+	ret0, _ := ret[0].([]db.BoardPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderBoardPages indicates an expected call of ReorderBoardPages.
+func (mr *MockStoreMockRecorder) ReorderBoardPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderBoardPages", reflect.TypeOf((*MockStore)(nil).ReorderBoardPages), arg0, arg1)
+}
+
+// ReorderPagesTx mocks base method.
+func (m *MockStore) ReorderPagesTx(arg0 context.Context, arg1 db.ReorderPagesTxParams) (db.ReorderPagesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderPagesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReorderPagesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderPagesTx indicates an expected call of ReorderPagesTx.
+func (mr *MockStoreMockRecorder) ReorderPagesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderPagesTx", reflect.TypeOf((*MockStore)(nil).ReorderPagesTx), arg0, arg1)
+}
+
+// RestoreBoardElement mocks base method.
+func (m *MockStore) RestoreBoardElement(arg0 context.Context, arg1 db.RestoreBoardElementParams) (db.BoardElement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBoardElement", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardElement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBoardElement indicates an expected call of RestoreBoardElement.
+func (mr *MockStoreMockRecorder) RestoreBoardElement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBoardElement", reflect.TypeOf((*MockStore)(nil).RestoreBoardElement), arg0, arg1)
+}
+
+// RestoreDeletedBoard mocks base method.
+func (m *MockStore) RestoreDeletedBoard(arg0 context.Context, arg1 db.RestoreDeletedBoardParams) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDeletedBoard", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreDeletedBoard indicates an expected call of RestoreDeletedBoard.
+func (mr *MockStoreMockRecorder) RestoreDeletedBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDeletedBoard", reflect.TypeOf((*MockStore)(nil).RestoreDeletedBoard), arg0, arg1)
+}
+
+// RestoreElementTx mocks base method.
+func (m *MockStore) RestoreElementTx(arg0 context.Context, arg1 db.RestoreElementTxParams) (db.ElementOperationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreElementTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ElementOperationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreElementTx indicates an expected call of RestoreElementTx.
+func (mr *MockStoreMockRecorder) RestoreElementTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreElementTx", reflect.TypeOf((*MockStore)(nil).RestoreElementTx), arg0, arg1)
+}
+
+// RestorePageTx mocks base method.
+func (m *MockStore) RestorePageTx(arg0 context.Context, arg1 db.RestorePageTxParams) (db.RestorePageTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePageTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RestorePageTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestorePageTx indicates an expected call of RestorePageTx.
+func (mr *MockStoreMockRecorder) RestorePageTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePageTx", reflect.TypeOf((*MockStore)(nil).RestorePageTx), arg0, arg1)
+}
+
+// RevokeBoardShareLink mocks base method.
+func (m *MockStore) RevokeBoardShareLink(arg0 context.Context, arg1 db.RevokeBoardShareLinkParams) (db.BoardShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeBoardShareLink", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeBoardShareLink indicates an expected call of RevokeBoardShareLink.
+func (mr *MockStoreMockRecorder) RevokeBoardShareLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeBoardShareLink", reflect.TypeOf((*MockStore)(nil).RevokeBoardShareLink), arg0, arg1)
+}
+
+// SaveBoardThumbnail mocks base method.
+func (m *MockStore) SaveBoardThumbnail(arg0 context.Context, arg1 db.SaveBoardThumbnailParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBoardThumbnail", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveBoardThumbnail indicates an expected call of SaveBoardThumbnail.
+func (mr *MockStoreMockRecorder) SaveBoardThumbnail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBoardThumbnail", reflect.TypeOf((*MockStore)(nil).SaveBoardThumbnail), arg0, arg1)
+}
+
+// SoftDeleteBoard mocks base method.
+func (m *MockStore) SoftDeleteBoard(arg0 context.Context, arg1 int32) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteBoard", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteBoard indicates an expected call of SoftDeleteBoard.
+func (mr *MockStoreMockRecorder) SoftDeleteBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteBoard", reflect.TypeOf((*MockStore)(nil).SoftDeleteBoard), arg0, arg1)
+}
+
+// StarBoard mocks base method.
+func (m *MockStore) StarBoard(arg0 context.Context, arg1 db.StarBoardParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StarBoard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StarBoard indicates an expected call of StarBoard.
+func (mr *MockStoreMockRecorder) StarBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StarBoard", reflect.TypeOf((*MockStore)(nil).StarBoard), arg0, arg1)
+}
+
+// TransferBoardOwnershipTx mocks base method.
+func (m *MockStore) TransferBoardOwnershipTx(arg0 context.Context, arg1 db.TransferBoardOwnershipTxParams) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferBoardOwnershipTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferBoardOwnershipTx indicates an expected call of TransferBoardOwnershipTx.
+func (mr *MockStoreMockRecorder) TransferBoardOwnershipTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferBoardOwnershipTx", reflect.TypeOf((*MockStore)(nil).TransferBoardOwnershipTx), arg0, arg1)
+}
+
+// UnstarBoard mocks base method.
+func (m *MockStore) UnstarBoard(arg0 context.Context, arg1 db.UnstarBoardParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnstarBoard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnstarBoard indicates an expected call of UnstarBoard.
+func (mr *MockStoreMockRecorder) UnstarBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarBoard", reflect.TypeOf((*MockStore)(nil).UnstarBoard), arg0, arg1)
+}
+
+// UpdateBoardElement mocks base method.
+func (m *MockStore) UpdateBoardElement(arg0 context.Context, arg1 db.UpdateBoardElementParams) (db.BoardElement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardElement", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardElement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardElement indicates an expected call of UpdateBoardElement.
+func (mr *MockStoreMockRecorder) UpdateBoardElement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardElement", reflect.TypeOf((*MockStore)(nil).UpdateBoardElement), arg0, arg1)
+}
+
+// UpdateBoardOwner mocks base method.
+func (m *MockStore) UpdateBoardOwner(arg0 context.Context, arg1 db.UpdateBoardOwnerParams) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardOwner", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardOwner indicates an expected call of UpdateBoardOwner.
+func (mr *MockStoreMockRecorder) UpdateBoardOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardOwner", reflect.TypeOf((*MockStore)(nil).UpdateBoardOwner), arg0, arg1)
+}
+
+// UpdateBoardTemplateScope mocks base method.
+func (m *MockStore) UpdateBoardTemplateScope(arg0 context.Context, arg1 db.UpdateBoardTemplateScopeParams) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardTemplateScope", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardTemplateScope indicates an expected call of UpdateBoardTemplateScope.
+func (mr *MockStoreMockRecorder) UpdateBoardTemplateScope(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardTemplateScope", reflect.TypeOf((*MockStore)(nil).UpdateBoardTemplateScope), arg0, arg1)
+}
+
+// UpdateBoardUserFolder mocks base method.
+func (m *MockStore) UpdateBoardUserFolder(arg0 context.Context, arg1 db.UpdateBoardUserFolderParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardUserFolder", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardUserFolder indicates an expected call of UpdateBoardUserFolder.
+func (mr *MockStoreMockRecorder) UpdateBoardUserFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardUserFolder", reflect.TypeOf((*MockStore)(nil).UpdateBoardUserFolder), arg0, arg1)
+}
+
+// UpdateBoardUserLastOpened mocks base method.
+func (m *MockStore) UpdateBoardUserLastOpened(arg0 context.Context, arg1 db.UpdateBoardUserLastOpenedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardUserLastOpened", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBoardUserLastOpened indicates an expected call of UpdateBoardUserLastOpened.
+func (mr *MockStoreMockRecorder) UpdateBoardUserLastOpened(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardUserLastOpened", reflect.TypeOf((*MockStore)(nil).UpdateBoardUserLastOpened), arg0, arg1)
+}
+
+// UpdateBoardUserRole mocks base method.
+func (m *MockStore) UpdateBoardUserRole(arg0 context.Context, arg1 db.UpdateBoardUserRoleParams) (db.BoardUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardUserRole", arg0, arg1)
+	ret0, _ := ret[0].(db.BoardUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardUserRole indicates an expected call of UpdateBoardUserRole.
+func (mr *MockStoreMockRecorder) UpdateBoardUserRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardUserRole", reflect.TypeOf((*MockStore)(nil).UpdateBoardUserRole), arg0, arg1)
+}
+
+// UpdateBoardWorkspace mocks base method.
+func (m *MockStore) UpdateBoardWorkspace(arg0 context.Context, arg1 db.UpdateBoardWorkspaceParams) (db.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardWorkspace indicates an expected call of UpdateBoardWorkspace.
+func (mr *MockStoreMockRecorder) UpdateBoardWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateBoardWorkspace), arg0, arg1)
+}
+
+// UpdateElementTx mocks base method.
+func (m *MockStore) UpdateElementTx(arg0 context.Context, arg1 db.UpdateElementTxParams) (db.ElementOperationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateElementTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ElementOperationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateElementTx indicates an expected call of UpdateElementTx.
+func (mr *MockStoreMockRecorder) UpdateElementTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateElementTx", reflect.TypeOf((*MockStore)(nil).UpdateElementTx), arg0, arg1)
+}
+
+// UpdateUser mocks base method.
+func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
+}
+
+// UpdateWorkspaceMemberRole mocks base method.
+func (m *MockStore) UpdateWorkspaceMemberRole(arg0 context.Context, arg1 db.UpdateWorkspaceMemberRoleParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceMemberRole", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkspaceMemberRole indicates an expected call of UpdateWorkspaceMemberRole.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceMemberRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceMemberRole", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceMemberRole), arg0, arg1)
 }
@@ -12,10 +12,12 @@ INSERT INTO "boards" (
 -- name: CreateBoardPage :one
 INSERT INTO "board_pages" (
     board_id,
-    name
+    name,
+    position
 ) VALUES (
      $1,
-     $2
+     $2,
+     (SELECT COALESCE(MAX(position) + 1, 0) FROM board_pages WHERE board_id = $1)
 ) RETURNING *;
 
 -- name: AddToBoardUsers :one
@@ -30,34 +32,163 @@ FROM boards
 WHERE id = $1;
 
 -- name: GetAllBoardsForUser :many
-SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name,
+-- boards are listed with keyset pagination, the cursor is the sort value and the id of the last board of the previous page
+SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name, b.last_activity_at,
        CASE
-           WHEN b.owner_id = $1 THEN TRUE
+           WHEN b.owner_id = @user_id THEN TRUE
            ELSE FALSE
-           END AS is_owner
+           END AS is_owner,
+       bu.role, bu.last_opened_at, bu.folder_id,
+       EXISTS (SELECT 1 FROM board_stars bs WHERE bs.board_id = b.id AND bs.user_id = @user_id) AS is_starred,
+       bt.updated_at AS thumbnail_updated_at
 FROM boards b
- JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
+ JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = @user_id
+ LEFT JOIN board_thumbnails bt ON bt.board_id = b.id
 WHERE b.is_deleted = FALSE
-ORDER BY b.created_at DESC;
+  AND (
+      @ownership::text = 'all'
+      OR (@ownership = 'owned' AND b.owner_id = @user_id)
+      OR (@ownership = 'shared' AND b.owner_id <> @user_id)
+  )
+  AND (@search::text = '' OR b.name ILIKE '%' || @search || '%')
+  AND (@folder_id::bigint = 0 OR bu.folder_id = @folder_id)
+  AND (NOT @starred::bool OR EXISTS (SELECT 1 FROM board_stars bs WHERE bs.board_id = b.id AND bs.user_id = @user_id))
+  AND (
+      NOT @has_cursor::bool
+      OR (@sort::text = 'name' AND (lower(b.name), b.id) > (@cursor_name::text, @cursor_id::int))
+      OR (@sort = '-name' AND (lower(b.name), b.id) < (@cursor_name, @cursor_id))
+      OR (@sort = 'created_at' AND (b.created_at, b.id) > (@cursor_time::timestamptz, @cursor_id))
+      OR (@sort = '-created_at' AND (b.created_at, b.id) < (@cursor_time, @cursor_id))
+      OR (@sort = 'last_activity' AND (b.last_activity_at, b.id) > (@cursor_time, @cursor_id))
+      OR (@sort = '-last_activity' AND (b.last_activity_at, b.id) < (@cursor_time, @cursor_id))
+  )
+ORDER BY
+    CASE WHEN @sort = 'name' THEN lower(b.name) END,
+    CASE WHEN @sort = '-name' THEN lower(b.name) END DESC,
+    CASE WHEN @sort = 'created_at' THEN b.created_at END,
+    CASE WHEN @sort = '-created_at' THEN b.created_at END DESC,
+    CASE WHEN @sort = 'last_activity' THEN b.last_activity_at END,
+    CASE WHEN @sort = '-last_activity' THEN b.last_activity_at END DESC,
+    CASE WHEN @sort IN ('name', 'created_at', 'last_activity') THEN b.id END,
+    b.id DESC
+LIMIT @page_size::int;
 
 -- name: GetBoardBySlugId :one 
+-- workspace members inherit the access to the boards of the workspace, the stronger role is used
 SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name,
        CASE
            WHEN b.owner_id = $1 THEN TRUE
            ELSE FALSE
-           END AS is_owner
+           END AS is_owner,
+       CASE
+           WHEN bu.role = 'editor' OR wm.role IN ('admin', 'editor') THEN 'editor'
+           ELSE 'viewer'
+           END AS role,
+       b.workspace_id
 FROM boards b
-JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
-WHERE b.is_deleted = FALSE and b.slug_id = $2;
+LEFT JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
+LEFT JOIN workspace_members wm ON wm.workspace_id = b.workspace_id AND wm.user_id = $1
+WHERE b.is_deleted = FALSE and b.slug_id = $2 AND (bu.user_id IS NOT NULL OR wm.user_id IS NOT NULL);
+
+
+-- name: GetBoardByIdForUser :one
+-- same as GetBoardBySlugId, for the endpoints that refer to the board by its id
+SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name,
+       CASE
+           WHEN b.owner_id = $1 THEN TRUE
+           ELSE FALSE
+           END AS is_owner,
+       CASE
+           WHEN bu.role = 'editor' OR wm.role IN ('admin', 'editor') THEN 'editor'
+           ELSE 'viewer'
+           END AS role,
+       b.workspace_id
+FROM boards b
+LEFT JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
+LEFT JOIN workspace_members wm ON wm.workspace_id = b.workspace_id AND wm.user_id = $1
+WHERE b.is_deleted = FALSE and b.id = $2 AND (bu.user_id IS NOT NULL OR wm.user_id IS NOT NULL);
 
 
 -- name: GetBoardPageByBoardId :many
-SELECT name, id, created_at
+SELECT name, id, created_at, position
 FROM board_pages
-WHERE board_id = $1 and is_deleted = false;
+WHERE board_id = $1 and is_deleted = false
+ORDER BY position, id;
 
 
 -- name: GetBoardUsers :many
 SELECT u.id, u.full_name, u.email, bu.role FROM users u
 LEFT JOIN board_users bu ON bu.user_id = u.id
-WHERE bu.board_id = $1;
\ No newline at end of file
+WHERE bu.board_id = $1;
+
+-- name: UpdateBoardUserRole :one
+UPDATE "board_users"
+SET role = $3
+WHERE board_id = $1 AND user_id = $2
+RETURNING *;
+
+
+-- name: DeleteBoardUser :execrows
+DELETE FROM "board_users"
+WHERE board_id = $1 AND user_id = $2;
+
+
+-- name: UpdateBoardOwner :one
+UPDATE "boards"
+SET owner_id = $2
+WHERE id = $1 AND is_deleted = FALSE
+RETURNING *;
+
+
+-- name: RenameBoard :one
+UPDATE "boards"
+SET name = $2
+WHERE id = $1 AND is_deleted = FALSE
+RETURNING *;
+
+
+-- name: SoftDeleteBoard :one
+UPDATE "boards"
+SET is_deleted = TRUE, deleted_at = now()
+WHERE id = $1 AND is_deleted = FALSE
+RETURNING *;
+
+
+-- name: RestoreDeletedBoard :one
+UPDATE "boards"
+SET is_deleted = FALSE, deleted_at = NULL
+WHERE slug_id = $1 AND owner_id = $2 AND is_deleted = TRUE
+RETURNING *;
+
+
+-- name: GetDeletedBoardsForOwner :many
+SELECT *
+FROM boards
+WHERE owner_id = $1 AND is_deleted = TRUE
+ORDER BY deleted_at DESC;
+
+
+-- name: PurgeDeletedBoards :execrows
+DELETE FROM "boards"
+WHERE is_deleted = TRUE AND deleted_at < $1;
+
+
+-- name: UpdateBoardUserLastOpened :exec
+UPDATE "board_users"
+SET last_opened_at = now()
+WHERE board_id = $1 AND user_id = $2;
+
+
+-- name: GetRecentBoardsForUser :many
+SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name, b.last_activity_at,
+       CASE
+           WHEN b.owner_id = $1 THEN TRUE
+           ELSE FALSE
+           END AS is_owner,
+       bu.role, bu.last_opened_at, bu.folder_id,
+       EXISTS (SELECT 1 FROM board_stars bs WHERE bs.board_id = b.id AND bs.user_id = $1) AS is_starred
+FROM boards b
+JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
+WHERE b.is_deleted = FALSE AND bu.last_opened_at IS NOT NULL
+ORDER BY bu.last_opened_at DESC
+LIMIT $2;
@@ -0,0 +1,144 @@
+-- name: CreateBoardElement :one
+INSERT INTO "board_elements" (
+    page_id,
+    type,
+    x,
+    y,
+    width,
+    height,
+    z_index,
+    stroke_color,
+    fill_color,
+    stroke_width,
+    border_radius,
+    text,
+    font_size
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13
+) RETURNING *;
+
+
+-- name: GetBoardPageBySlugId :one
+SELECT bp.id, bp.board_id, bp.name, bp.created_at
+FROM board_pages bp
+JOIN boards b ON b.id = bp.board_id
+WHERE bp.id = $1 AND b.slug_id = $2 AND bp.is_deleted = FALSE AND b.is_deleted = FALSE;
+
+
+-- name: GetBoardElementBySlugId :one
+SELECT be.*
+FROM board_elements be
+JOIN board_pages bp ON bp.id = be.page_id
+JOIN boards b ON b.id = bp.board_id
+WHERE be.id = $1 AND b.slug_id = $2 AND be.is_deleted = FALSE;
+
+
+-- name: GetBoardElementsByPageId :many
+SELECT *
+FROM board_elements
+WHERE page_id = $1 AND is_deleted = FALSE
+ORDER BY z_index, id;
+
+
+-- name: GetBoardElementsByBoardId :many
+SELECT *
+FROM board_elements
+WHERE is_deleted = FALSE AND page_id IN (
+    SELECT id FROM board_pages WHERE board_id = $1 AND is_deleted = FALSE
+)
+ORDER BY page_id, z_index, id;
+
+
+-- name: UpdateBoardElement :one
+UPDATE "board_elements"
+SET
+    x = COALESCE(sqlc.narg(x), x),
+    y = COALESCE(sqlc.narg(y), y),
+    width = COALESCE(sqlc.narg(width), width),
+    height = COALESCE(sqlc.narg(height), height),
+    z_index = COALESCE(sqlc.narg(z_index), z_index),
+    stroke_color = COALESCE(sqlc.narg(stroke_color), stroke_color),
+    fill_color = COALESCE(sqlc.narg(fill_color), fill_color),
+    stroke_width = COALESCE(sqlc.narg(stroke_width), stroke_width),
+    border_radius = COALESCE(sqlc.narg(border_radius), border_radius),
+    text = COALESCE(sqlc.narg(text), text),
+    font_size = COALESCE(sqlc.narg(font_size), font_size),
+    version = version + 1,
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version) AND is_deleted = FALSE AND page_id IN (
+    SELECT bp.id FROM board_pages bp
+    JOIN boards b ON b.id = bp.board_id
+    WHERE b.slug_id = sqlc.arg(board_slug_id)
+)
+RETURNING *;
+
+
+-- name: DeleteBoardElement :one
+-- the version is only checked when it is given
+UPDATE "board_elements"
+SET
+    is_deleted = TRUE,
+    version = version + 1,
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND is_deleted = FALSE
+  AND (sqlc.narg(version)::int IS NULL OR version = sqlc.narg(version)::int)
+  AND page_id IN (
+    SELECT bp.id FROM board_pages bp
+    JOIN boards b ON b.id = bp.board_id
+    WHERE b.slug_id = sqlc.arg(board_slug_id)
+)
+RETURNING *;
+
+
+-- name: RestoreBoardElement :one
+UPDATE "board_elements"
+SET
+    x = $3,
+    y = $4,
+    width = $5,
+    height = $6,
+    z_index = $7,
+    stroke_color = $8,
+    fill_color = $9,
+    stroke_width = $10,
+    border_radius = $11,
+    text = $12,
+    font_size = $13,
+    is_deleted = FALSE,
+    version = version + 1,
+    updated_at = now()
+WHERE id = $1 AND page_id = $2
+RETURNING *;
+
+-- name: CopyBoardPageElements :execrows
+INSERT INTO "board_elements" (
+    page_id,
+    type,
+    x,
+    y,
+    width,
+    height,
+    z_index,
+    stroke_color,
+    fill_color,
+    stroke_width,
+    border_radius,
+    text,
+    font_size
+)
+SELECT @target_page_id::bigint, type, x, y, width, height, z_index, stroke_color, fill_color,
+       stroke_width, border_radius, text, font_size
+FROM board_elements
+WHERE page_id = @source_page_id AND is_deleted = FALSE;
@@ -0,0 +1,67 @@
+-- name: CreateBoardExport :one
+-- the page must be a page of the board that is not deleted
+INSERT INTO "board_exports" (
+    board_id,
+    page_id,
+    user_id,
+    format,
+    scale,
+    region_x,
+    region_y,
+    region_width,
+    region_height
+)
+SELECT p.board_id, p.id, @user_id::bigint, @format::varchar, @scale::float8,
+       sqlc.narg(region_x)::float8, sqlc.narg(region_y)::float8, sqlc.narg(region_width)::float8, sqlc.narg(region_height)::float8
+FROM board_pages p
+WHERE p.id = @page_id AND p.board_id = @board_id AND p.is_deleted = FALSE
+RETURNING *;
+
+
+-- name: CreateBoardPdfExport :one
+-- pdf exports contain all the pages of the board, the email is set when the export is sent by email
+INSERT INTO "board_exports" (
+    board_id,
+    user_id,
+    format,
+    email
+)
+SELECT b.id, @user_id::bigint, 'pdf', sqlc.narg(email)::citext
+FROM boards b
+WHERE b.id = @board_id AND b.is_deleted = FALSE
+RETURNING *;
+
+
+-- name: GetBoardExport :one
+SELECT *
+FROM board_exports
+WHERE id = $1;
+
+
+-- name: GetBoardExportForUser :one
+SELECT *
+FROM board_exports
+WHERE id = $1 AND user_id = $2;
+
+
+-- name: CompleteBoardExport :execrows
+UPDATE "board_exports"
+SET status = 'completed', content = $2, completed_at = now()
+WHERE id = $1 AND status = 'pending';
+
+
+-- name: FailBoardExport :execrows
+UPDATE "board_exports"
+SET status = 'failed', completed_at = now()
+WHERE id = $1 AND status = 'pending';
+
+
+-- name: MarkBoardExportEmailed :execrows
+UPDATE "board_exports"
+SET emailed_at = now()
+WHERE id = $1 AND email IS NOT NULL AND emailed_at IS NULL;
+
+
+-- name: PurgeBoardExports :execrows
+DELETE FROM board_exports
+WHERE created_at < $1;
@@ -0,0 +1,33 @@
+-- name: CreateBoardFolder :one
+INSERT INTO "board_folders" (user_id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+
+-- name: GetBoardFoldersForUser :many
+SELECT *
+FROM board_folders
+WHERE user_id = $1
+ORDER BY lower(name), id;
+
+
+-- name: RenameBoardFolder :one
+UPDATE "board_folders"
+SET name = $3
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+
+-- name: DeleteBoardFolder :execrows
+DELETE FROM "board_folders"
+WHERE id = $1 AND user_id = $2;
+
+
+-- name: UpdateBoardUserFolder :execrows
+-- members can only put the board into their own folders, a null folder takes the board out of its folder
+UPDATE "board_users"
+SET folder_id = sqlc.narg(folder_id)
+WHERE board_id = @board_id AND user_id = @user_id AND (
+    sqlc.narg(folder_id)::bigint IS NULL
+    OR EXISTS (SELECT 1 FROM board_folders WHERE id = sqlc.narg(folder_id) AND user_id = @user_id)
+);
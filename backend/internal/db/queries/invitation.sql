@@ -0,0 +1,51 @@
+-- name: CreateBoardInvitation :one
+INSERT INTO "board_invitations" (
+    hash,
+    board_id,
+    email,
+    role,
+    invited_by,
+    expiry
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+ON CONFLICT (board_id, email) DO UPDATE
+SET hash = EXCLUDED.hash,
+    role = EXCLUDED.role,
+    invited_by = EXCLUDED.invited_by,
+    expiry = EXCLUDED.expiry,
+    created_at = now()
+RETURNING *;
+
+
+-- name: AcceptBoardInvitations :many
+WITH accepted AS (
+    DELETE FROM "board_invitations"
+    WHERE email = sqlc.arg(email)
+    RETURNING board_id, role, expiry
+)
+INSERT INTO "board_users" (board_id, user_id, role)
+SELECT board_id, sqlc.arg(user_id)::bigint, role
+FROM accepted
+WHERE expiry > now()
+ON CONFLICT (board_id, user_id) DO NOTHING
+RETURNING *;
+
+
+-- name: AcceptBoardInvitationByHash :one
+-- members who are already in the board keep their role
+WITH accepted AS (
+    DELETE FROM "board_invitations"
+    WHERE hash = sqlc.arg(hash) AND expiry > now()
+    RETURNING board_id, role
+)
+INSERT INTO "board_users" (board_id, user_id, role)
+SELECT board_id, sqlc.arg(user_id)::bigint, role
+FROM accepted
+ON CONFLICT (board_id, user_id) DO UPDATE SET role = board_users.role
+RETURNING *;
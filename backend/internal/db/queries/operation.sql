@@ -0,0 +1,62 @@
+-- name: IncrementBoardRevision :one
+UPDATE "boards"
+SET revision = revision + 1, last_activity_at = now()
+WHERE slug_id = $1 AND is_deleted = FALSE
+RETURNING id, revision;
+
+
+-- name: GetBoardRevisionBySlugId :one
+SELECT revision
+FROM boards
+WHERE slug_id = $1 AND is_deleted = FALSE;
+
+
+-- name: CreateBoardOperation :one
+INSERT INTO "board_operations" (
+    board_id,
+    revision,
+    user_id,
+    type,
+    page_id,
+    element_id,
+    data
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+) RETURNING *;
+
+
+-- name: GetBoardOperationsAfterRevision :many
+SELECT *
+FROM board_operations
+WHERE board_id = (SELECT id FROM boards WHERE slug_id = $1) AND revision > $2
+ORDER BY revision
+LIMIT $3;
+
+
+-- name: GetBoardOperationsWithAuthors :many
+SELECT o.revision, o.type, o.page_id, o.element_id, o.user_id, u.full_name, o.created_at
+FROM board_operations o
+LEFT JOIN users u ON u.id = o.user_id
+WHERE o.board_id = (SELECT id FROM boards WHERE slug_id = $1) AND o.revision < $2
+ORDER BY o.revision DESC
+LIMIT $3;
+
+
+-- name: GetBoardOperationsBetweenRevisions :many
+SELECT *
+FROM board_operations
+WHERE board_id = (SELECT id FROM boards WHERE slug_id = $1) AND revision > $2 AND revision <= sqlc.arg(until_revision)
+ORDER BY revision;
+
+
+-- name: GetPageCreateRevision :one
+-- pages created after the latest snapshot are not in any snapshot, their history starts with their creation
+SELECT revision
+FROM board_operations
+WHERE board_id = $1 AND page_id = $2 AND type = 'page.create';
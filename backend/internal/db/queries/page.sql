@@ -0,0 +1,37 @@
+-- name: GetBoardPage :one
+SELECT *
+FROM board_pages
+WHERE id = $1 AND board_id = $2 AND is_deleted = FALSE;
+
+
+-- name: LockBoard :one
+-- locking the board serializes the changes of its pages
+SELECT slug_id
+FROM boards
+WHERE id = $1 AND is_deleted = FALSE
+FOR UPDATE;
+
+
+-- name: RenameBoardPage :one
+UPDATE "board_pages"
+SET name = $3
+WHERE id = $1 AND board_id = $2 AND is_deleted = FALSE
+RETURNING *;
+
+
+-- name: ReorderBoardPages :many
+UPDATE "board_pages" bp
+SET position = o.position - 1
+FROM unnest(@page_ids::int[]) WITH ORDINALITY AS o(id, position)
+WHERE bp.id = o.id AND bp.board_id = @board_id AND bp.is_deleted = FALSE
+RETURNING bp.*;
+
+
+-- name: DeleteBoardPage :one
+-- the last page of a board cannot be deleted
+UPDATE "board_pages"
+SET is_deleted = TRUE
+WHERE id = $1 AND board_id = $2 AND is_deleted = FALSE AND (
+    SELECT count(*) FROM board_pages WHERE board_id = $2 AND is_deleted = FALSE
+) > 1
+RETURNING *;
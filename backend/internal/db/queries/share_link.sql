@@ -0,0 +1,35 @@
+-- name: CreateBoardShareLink :one
+INSERT INTO "board_share_links" (
+    hash,
+    board_id,
+    role,
+    created_by,
+    expiry
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+) RETURNING *;
+
+-- name: GetBoardShareLinks :many
+SELECT *
+FROM board_share_links
+WHERE board_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeBoardShareLink :one
+UPDATE "board_share_links"
+SET is_revoked = TRUE
+WHERE id = $1 AND board_id = $2
+RETURNING *;
+
+-- name: GetBoardByShareLink :one
+SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name,
+       sl.id AS share_link_id,
+       sl.role
+FROM boards b
+JOIN board_share_links sl ON sl.board_id = b.id
+WHERE b.is_deleted = FALSE AND b.slug_id = $1 AND sl.hash = $2
+  AND sl.is_revoked = FALSE AND (sl.expiry IS NULL OR sl.expiry > now());
@@ -0,0 +1,56 @@
+-- name: GetBoardForSnapshot :one
+SELECT id, revision
+FROM boards
+WHERE slug_id = $1 AND is_deleted = FALSE
+FOR UPDATE;
+
+
+-- name: CreateBoardSnapshot :one
+INSERT INTO "board_snapshots" (
+    board_id,
+    page_id,
+    revision,
+    data
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4
+) RETURNING *;
+
+
+-- name: GetLatestBoardSnapshotRevision :one
+SELECT COALESCE(MAX(revision), 0)::bigint
+FROM board_snapshots
+WHERE board_id = $1;
+
+
+-- name: GetLatestBoardSnapshots :many
+SELECT s.*
+FROM board_snapshots s
+JOIN boards b ON b.id = s.board_id
+WHERE b.slug_id = $1 AND s.revision = (
+    SELECT MAX(revision) FROM board_snapshots WHERE board_id = b.id
+)
+ORDER BY s.page_id;
+
+
+-- name: DeleteBoardOperationsUntilRevision :execrows
+DELETE FROM board_operations
+WHERE board_id = $1 AND revision <= $2;
+
+
+-- name: GetBoardSnapshotRevisions :many
+SELECT revision, MIN(created_at)::timestamptz AS created_at
+FROM board_snapshots
+WHERE board_id = (SELECT id FROM boards WHERE slug_id = $1)
+GROUP BY revision
+ORDER BY revision DESC;
+
+
+-- name: GetPageSnapshotAtRevision :one
+SELECT *
+FROM board_snapshots
+WHERE page_id = $1 AND revision <= $2
+ORDER BY revision DESC
+LIMIT 1;
@@ -0,0 +1,9 @@
+-- name: StarBoard :exec
+INSERT INTO "board_stars" (user_id, board_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, board_id) DO NOTHING;
+
+
+-- name: UnstarBoard :exec
+DELETE FROM "board_stars"
+WHERE user_id = $1 AND board_id = $2;
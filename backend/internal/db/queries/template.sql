@@ -0,0 +1,31 @@
+-- name: GetTemplatesForUser :many
+-- system templates are listed to everyone, personal templates only to their owners and workspace templates to the workspace members
+SELECT *
+FROM boards
+WHERE is_deleted = false
+  AND (
+    template_scope = 'system'
+    OR (template_scope = 'personal' AND owner_id = @user_id)
+    OR (template_scope = 'workspace' AND workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = @user_id))
+  )
+ORDER BY template_scope = 'system' DESC, lower(name), id;
+
+
+-- name: GetTemplateForUser :one
+SELECT *
+FROM boards
+WHERE id = @id
+  AND is_deleted = false
+  AND (
+    template_scope = 'system'
+    OR (template_scope = 'personal' AND owner_id = @user_id)
+    OR (template_scope = 'workspace' AND workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = @user_id))
+  );
+
+
+-- name: UpdateBoardTemplateScope :one
+-- a null scope stops the board from being a template
+UPDATE "boards"
+SET template_scope = sqlc.narg(template_scope)
+WHERE id = @id AND is_deleted = false
+RETURNING *;
@@ -0,0 +1,29 @@
+-- name: GetBoardThumbnailSource :one
+-- the thumbnail shows the first page of the board, thumbnail_revision is null if the board has no thumbnail yet
+SELECT b.id AS board_id, b.revision, p.id AS page_id, t.revision AS thumbnail_revision
+FROM boards b
+JOIN board_pages p ON p.board_id = b.id AND p.is_deleted = FALSE
+LEFT JOIN board_thumbnails t ON t.board_id = b.id
+WHERE b.slug_id = $1 AND b.is_deleted = FALSE
+ORDER BY p.position, p.id
+LIMIT 1;
+
+
+-- name: GetBoardThumbnail :one
+SELECT *
+FROM board_thumbnails
+WHERE board_id = $1;
+
+
+-- name: SaveBoardThumbnail :execrows
+-- thumbnails of older revisions do not replace the newer ones
+INSERT INTO "board_thumbnails" (
+    board_id,
+    revision,
+    content
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (board_id) DO UPDATE
+SET revision = EXCLUDED.revision, content = EXCLUDED.content, updated_at = now()
+WHERE board_thumbnails.revision < EXCLUDED.revision;
@@ -0,0 +1,82 @@
+-- name: CreateWorkspace :one
+INSERT INTO "workspaces" (name, created_by)
+VALUES ($1, $2)
+RETURNING *;
+
+
+-- name: GetWorkspacesForUser :many
+SELECT w.id, w.name, w.created_by, w.created_at, wm.role
+FROM workspaces w
+JOIN workspace_members wm ON wm.workspace_id = w.id AND wm.user_id = $1
+ORDER BY lower(w.name), w.id;
+
+
+-- name: RenameWorkspace :one
+UPDATE "workspaces"
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+
+-- name: DeleteWorkspace :execrows
+DELETE FROM "workspaces"
+WHERE id = $1;
+
+
+-- name: AddWorkspaceMember :one
+INSERT INTO "workspace_members" (workspace_id, user_id, role)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+
+-- name: GetWorkspaceMemberRole :one
+SELECT role
+FROM workspace_members
+WHERE workspace_id = $1 AND user_id = $2;
+
+
+-- name: GetWorkspaceMembers :many
+SELECT u.id, u.full_name, u.email, wm.role, wm.created_at
+FROM workspace_members wm
+JOIN users u ON u.id = wm.user_id
+WHERE wm.workspace_id = $1
+ORDER BY wm.created_at, u.id;
+
+
+-- name: UpdateWorkspaceMemberRole :execrows
+-- the last admin of the workspace cannot be demoted
+UPDATE "workspace_members"
+SET role = @role
+WHERE workspace_id = @workspace_id AND user_id = @user_id AND (
+    @role::varchar = 'admin'
+    OR role <> 'admin'
+    OR EXISTS (SELECT 1 FROM workspace_members WHERE workspace_id = @workspace_id AND user_id <> @user_id AND role = 'admin')
+);
+
+
+-- name: DeleteWorkspaceMember :execrows
+-- the last admin of the workspace cannot be removed
+DELETE FROM "workspace_members"
+WHERE workspace_id = @workspace_id AND user_id = @user_id AND (
+    role <> 'admin'
+    OR EXISTS (SELECT 1 FROM workspace_members WHERE workspace_id = @workspace_id AND user_id <> @user_id AND role = 'admin')
+);
+
+
+-- name: GetWorkspaceBoards :many
+SELECT *
+FROM boards
+WHERE workspace_id = $1 AND is_deleted = false
+ORDER BY lower(name), id;
+
+
+-- name: UpdateBoardWorkspace :one
+-- workspace templates stop being templates when they leave the workspace
+UPDATE "boards"
+SET workspace_id = sqlc.narg(workspace_id),
+    template_scope = CASE
+        WHEN template_scope = 'workspace' AND workspace_id IS DISTINCT FROM sqlc.narg(workspace_id) THEN NULL
+        ELSE template_scope
+        END
+WHERE id = @id AND is_deleted = false
+RETURNING *;
@@ -31,13 +31,35 @@ CREATE TABLE IF NOT EXISTS "user_permissions" (
     PRIMARY KEY (user_id, permission_id)
 );
 
+CREATE TABLE IF NOT EXISTS "workspaces" (
+    id SERIAL PRIMARY KEY,
+    name varchar(50) NOT NULL,
+    created_by BIGINT REFERENCES users ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "workspace_members" (
+    workspace_id BIGINT NOT NULL REFERENCES workspaces ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
+    role varchar(20) NOT NULL DEFAULT 'viewer',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (workspace_id, user_id),
+    CONSTRAINT chk_workspace_members_role CHECK ( role IN ('admin', 'editor', 'viewer') )
+);
+
 CREATE TABLE IF NOT EXISTS "boards" (
     id SERIAL PRIMARY KEY,
     slug_id varchar(25) NOT NULL UNIQUE,
     name varchar(100) NOT NULL,
     owner_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
     created_at timestamp with time zone NOT NULL DEFAULT now(),
-    is_deleted bool NOT NULL DEFAULT FALSE
+    is_deleted bool NOT NULL DEFAULT FALSE,
+    revision bigint NOT NULL DEFAULT 0,
+    deleted_at timestamp with time zone,
+    last_activity_at timestamp with time zone NOT NULL DEFAULT now(),
+    template_scope varchar(20),
+    workspace_id BIGINT REFERENCES workspaces ON DELETE SET NULL,
+    CONSTRAINT chk_boards_template_scope CHECK ( template_scope IN ('personal', 'workspace', 'system') )
 );
 
 CREATE TABLE IF NOT EXISTS "board_pages" (
@@ -45,7 +67,16 @@ CREATE TABLE IF NOT EXISTS "board_pages" (
     board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
     name varchar(100) NOT NULL,
     created_at timestamp with time zone NOT NULL DEFAULT now(),
-    is_deleted bool NOT NULL DEFAULT FALSE
+    is_deleted bool NOT NULL DEFAULT FALSE,
+    position integer NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS "board_folders" (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
+    name varchar(50) NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (user_id, name)
 );
 
 CREATE TABLE IF NOT EXISTS "board_users" (
@@ -53,6 +84,119 @@ CREATE TABLE IF NOT EXISTS "board_users" (
     user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
     created_at timestamp with time zone NOT NULL DEFAULT now(),
     role varchar(20) NOT NULL DEFAULT 'viewer',
+    last_opened_at timestamp with time zone,
+    folder_id BIGINT REFERENCES board_folders ON DELETE SET NULL,
     PRIMARY KEY (board_id, user_id),
     CONSTRAINT chk_boards_users_role CHECK ( role IN ('editor', 'viewer') )
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS "board_elements" (
+    id SERIAL PRIMARY KEY,
+    page_id BIGINT NOT NULL REFERENCES board_pages ON DELETE CASCADE,
+    type varchar(20) NOT NULL,
+    x double precision NOT NULL DEFAULT 0,
+    y double precision NOT NULL DEFAULT 0,
+    width double precision NOT NULL DEFAULT 0,
+    height double precision NOT NULL DEFAULT 0,
+    z_index integer NOT NULL DEFAULT 0,
+    stroke_color bigint NOT NULL DEFAULT 255,
+    fill_color bigint NOT NULL DEFAULT 0,
+    stroke_width double precision NOT NULL DEFAULT 2,
+    border_radius double precision NOT NULL DEFAULT 0,
+    text text NOT NULL DEFAULT '',
+    font_size integer NOT NULL DEFAULT 14,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    is_deleted bool NOT NULL DEFAULT FALSE,
+    version integer NOT NULL DEFAULT 1,
+    CONSTRAINT chk_board_elements_type CHECK ( type IN ('rectangle', 'ellipse', 'triangle', 'text_box') )
+);
+
+
+
+CREATE TABLE IF NOT EXISTS "board_operations" (
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    user_id BIGINT REFERENCES users ON DELETE SET NULL,
+    type varchar(30) NOT NULL,
+    page_id BIGINT NOT NULL,
+    element_id BIGINT NOT NULL,
+    data jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (board_id, revision)
+);
+
+
+CREATE TABLE IF NOT EXISTS "board_snapshots" (
+    id SERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    page_id BIGINT NOT NULL REFERENCES board_pages ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    data jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (page_id, revision)
+);
+
+
+CREATE TABLE IF NOT EXISTS "board_invitations" (
+    hash bytea PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    email CITEXT NOT NULL,
+    role varchar(20) NOT NULL DEFAULT 'editor',
+    invited_by BIGINT REFERENCES users ON DELETE SET NULL,
+    expiry timestamp(0) with time zone NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (board_id, email),
+    CONSTRAINT chk_board_invitations_role CHECK ( role IN ('editor', 'viewer') )
+);
+
+
+CREATE TABLE IF NOT EXISTS "board_share_links" (
+    id SERIAL PRIMARY KEY,
+    hash bytea NOT NULL UNIQUE,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    role varchar(20) NOT NULL DEFAULT 'viewer',
+    created_by BIGINT REFERENCES users ON DELETE SET NULL,
+    expiry timestamp(0) with time zone,
+    is_revoked bool NOT NULL DEFAULT FALSE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT chk_board_share_links_role CHECK ( role IN ('editor', 'viewer') )
+);
+
+
+CREATE TABLE IF NOT EXISTS "board_stars" (
+    user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, board_id)
+);
+
+
+CREATE TABLE IF NOT EXISTS "board_exports" (
+    id SERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards ON DELETE CASCADE,
+    page_id BIGINT REFERENCES board_pages ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users ON DELETE CASCADE,
+    format varchar(10) NOT NULL,
+    scale double precision NOT NULL DEFAULT 1,
+    region_x double precision,
+    region_y double precision,
+    region_width double precision,
+    region_height double precision,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    content bytea,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    completed_at timestamp with time zone,
+    email CITEXT,
+    emailed_at timestamp with time zone,
+    CONSTRAINT chk_board_exports_format CHECK ( format IN ('png', 'pdf') ),
+    CONSTRAINT chk_board_exports_status CHECK ( status IN ('pending', 'completed', 'failed') )
+);
+
+
+CREATE TABLE IF NOT EXISTS "board_thumbnails" (
+    board_id BIGINT PRIMARY KEY REFERENCES boards ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    content bytea NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
@@ -14,7 +14,7 @@ import (
 const addToBoardUsers = `-- name: AddToBoardUsers :one
 INSERT INTO "board_users" (board_id, user_id, role)
 VALUES ($1, $2, $3)
-RETURNING board_id, user_id, created_at, role
+RETURNING board_id, user_id, created_at, role, last_opened_at, folder_id
 `
 
 type AddToBoardUsersParams struct {
@@ -31,6 +31,8 @@ func (q *Queries) AddToBoardUsers(ctx context.Context, arg AddToBoardUsersParams
 		&i.UserID,
 		&i.CreatedAt,
 		&i.Role,
+		&i.LastOpenedAt,
+		&i.FolderID,
 	)
 	return i, err
 }
@@ -44,7 +46,7 @@ INSERT INTO "boards" (
     $1,
     $2,
     $3
-) RETURNING id, slug_id, name, owner_id, created_at, is_deleted
+) RETURNING id, slug_id, name, owner_id, created_at, is_deleted, revision, deleted_at, last_activity_at, template_scope, workspace_id
 `
 
 type CreateBoardParams struct {
@@ -63,6 +65,11 @@ func (q *Queries) CreateBoard(ctx context.Context, arg CreateBoardParams) (Board
 		&i.OwnerID,
 		&i.CreatedAt,
 		&i.IsDeleted,
+		&i.Revision,
+		&i.DeletedAt,
+		&i.LastActivityAt,
+		&i.TemplateScope,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
@@ -70,11 +77,13 @@ func (q *Queries) CreateBoard(ctx context.Context, arg CreateBoardParams) (Board
 const createBoardPage = `-- name: CreateBoardPage :one
 INSERT INTO "board_pages" (
     board_id,
-    name
+    name,
+    position
 ) VALUES (
      $1,
-     $2
-) RETURNING id, board_id, name, created_at, is_deleted
+     $2,
+     (SELECT COALESCE(MAX(position) + 1, 0) FROM board_pages WHERE board_id = $1)
+) RETURNING id, board_id, name, created_at, is_deleted, position
 `
 
 type CreateBoardPageParams struct {
@@ -91,34 +100,116 @@ func (q *Queries) CreateBoardPage(ctx context.Context, arg CreateBoardPageParams
 		&i.Name,
 		&i.CreatedAt,
 		&i.IsDeleted,
+		&i.Position,
 	)
 	return i, err
 }
 
+const deleteBoardUser = `-- name: DeleteBoardUser :execrows
+DELETE FROM "board_users"
+WHERE board_id = $1 AND user_id = $2
+`
+
+type DeleteBoardUserParams struct {
+	BoardID int64 `json:"board_id"`
+	UserID  int64 `json:"user_id"`
+}
+
+func (q *Queries) DeleteBoardUser(ctx context.Context, arg DeleteBoardUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBoardUser, arg.BoardID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getAllBoardsForUser = `-- name: GetAllBoardsForUser :many
-SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name,
+SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name, b.last_activity_at,
        CASE
            WHEN b.owner_id = $1 THEN TRUE
            ELSE FALSE
-           END AS is_owner
+           END AS is_owner,
+       bu.role, bu.last_opened_at, bu.folder_id,
+       EXISTS (SELECT 1 FROM board_stars bs WHERE bs.board_id = b.id AND bs.user_id = $1) AS is_starred,
+       bt.updated_at AS thumbnail_updated_at
 FROM boards b
  JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
+ LEFT JOIN board_thumbnails bt ON bt.board_id = b.id
 WHERE b.is_deleted = FALSE
-ORDER BY b.created_at DESC
+  AND (
+      $2::text = 'all'
+      OR ($2 = 'owned' AND b.owner_id = $1)
+      OR ($2 = 'shared' AND b.owner_id <> $1)
+  )
+  AND ($3::text = '' OR b.name ILIKE '%' || $3 || '%')
+  AND ($4::bigint = 0 OR bu.folder_id = $4)
+  AND (NOT $5::bool OR EXISTS (SELECT 1 FROM board_stars bs WHERE bs.board_id = b.id AND bs.user_id = $1))
+  AND (
+      NOT $6::bool
+      OR ($7::text = 'name' AND (lower(b.name), b.id) > ($8::text, $9::int))
+      OR ($7 = '-name' AND (lower(b.name), b.id) < ($8, $9))
+      OR ($7 = 'created_at' AND (b.created_at, b.id) > ($10::timestamptz, $9))
+      OR ($7 = '-created_at' AND (b.created_at, b.id) < ($10, $9))
+      OR ($7 = 'last_activity' AND (b.last_activity_at, b.id) > ($10, $9))
+      OR ($7 = '-last_activity' AND (b.last_activity_at, b.id) < ($10, $9))
+  )
+ORDER BY
+    CASE WHEN $7 = 'name' THEN lower(b.name) END,
+    CASE WHEN $7 = '-name' THEN lower(b.name) END DESC,
+    CASE WHEN $7 = 'created_at' THEN b.created_at END,
+    CASE WHEN $7 = '-created_at' THEN b.created_at END DESC,
+    CASE WHEN $7 = 'last_activity' THEN b.last_activity_at END,
+    CASE WHEN $7 = '-last_activity' THEN b.last_activity_at END DESC,
+    CASE WHEN $7 IN ('name', 'created_at', 'last_activity') THEN b.id END,
+    b.id DESC
+LIMIT $11::int
 `
 
+type GetAllBoardsForUserParams struct {
+	UserID     int64              `json:"user_id"`
+	Ownership  string             `json:"ownership"`
+	Search     string             `json:"search"`
+	FolderID   int64              `json:"folder_id"`
+	Starred    bool               `json:"starred"`
+	HasCursor  bool               `json:"has_cursor"`
+	Sort       string             `json:"sort"`
+	CursorName string             `json:"cursor_name"`
+	CursorID   int32              `json:"cursor_id"`
+	CursorTime pgtype.Timestamptz `json:"cursor_time"`
+	PageSize   int32              `json:"page_size"`
+}
+
 type GetAllBoardsForUserRow struct {
-	ID        int32              `json:"id"`
-	SlugID    string             `json:"slug_id"`
-	OwnerID   int64              `json:"owner_id"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	IsDeleted bool               `json:"is_deleted"`
-	Name      string             `json:"name"`
-	IsOwner   bool               `json:"is_owner"`
+	ID                 int32              `json:"id"`
+	SlugID             string             `json:"slug_id"`
+	OwnerID            int64              `json:"owner_id"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	IsDeleted          bool               `json:"is_deleted"`
+	Name               string             `json:"name"`
+	LastActivityAt     pgtype.Timestamptz `json:"last_activity_at"`
+	IsOwner            bool               `json:"is_owner"`
+	Role               string             `json:"role"`
+	LastOpenedAt       pgtype.Timestamptz `json:"last_opened_at"`
+	FolderID           pgtype.Int8        `json:"folder_id"`
+	IsStarred          bool               `json:"is_starred"`
+	ThumbnailUpdatedAt pgtype.Timestamptz `json:"thumbnail_updated_at"`
 }
 
-func (q *Queries) GetAllBoardsForUser(ctx context.Context, ownerID int64) ([]GetAllBoardsForUserRow, error) {
-	rows, err := q.db.Query(ctx, getAllBoardsForUser, ownerID)
+// boards are listed with keyset pagination, the cursor is the sort value and the id of the last board of the previous page
+func (q *Queries) GetAllBoardsForUser(ctx context.Context, arg GetAllBoardsForUserParams) ([]GetAllBoardsForUserRow, error) {
+	rows, err := q.db.Query(ctx, getAllBoardsForUser,
+		arg.UserID,
+		arg.Ownership,
+		arg.Search,
+		arg.FolderID,
+		arg.Starred,
+		arg.HasCursor,
+		arg.Sort,
+		arg.CursorName,
+		arg.CursorID,
+		arg.CursorTime,
+		arg.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +224,13 @@ func (q *Queries) GetAllBoardsForUser(ctx context.Context, ownerID int64) ([]Get
 			&i.CreatedAt,
 			&i.IsDeleted,
 			&i.Name,
+			&i.LastActivityAt,
 			&i.IsOwner,
+			&i.Role,
+			&i.LastOpenedAt,
+			&i.FolderID,
+			&i.IsStarred,
+			&i.ThumbnailUpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -146,7 +243,7 @@ func (q *Queries) GetAllBoardsForUser(ctx context.Context, ownerID int64) ([]Get
 }
 
 const getBoardById = `-- name: GetBoardById :one
-SELECT id, slug_id, name, owner_id, created_at, is_deleted
+SELECT id, slug_id, name, owner_id, created_at, is_deleted, revision, deleted_at, last_activity_at, template_scope, workspace_id
 FROM boards
 WHERE id = $1
 `
@@ -161,6 +258,63 @@ func (q *Queries) GetBoardById(ctx context.Context, id int32) (Board, error) {
 		&i.OwnerID,
 		&i.CreatedAt,
 		&i.IsDeleted,
+		&i.Revision,
+		&i.DeletedAt,
+		&i.LastActivityAt,
+		&i.TemplateScope,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const getBoardByIdForUser = `-- name: GetBoardByIdForUser :one
+SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name,
+       CASE
+           WHEN b.owner_id = $1 THEN TRUE
+           ELSE FALSE
+           END AS is_owner,
+       CASE
+           WHEN bu.role = 'editor' OR wm.role IN ('admin', 'editor') THEN 'editor'
+           ELSE 'viewer'
+           END AS role,
+       b.workspace_id
+FROM boards b
+LEFT JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
+LEFT JOIN workspace_members wm ON wm.workspace_id = b.workspace_id AND wm.user_id = $1
+WHERE b.is_deleted = FALSE and b.id = $2 AND (bu.user_id IS NOT NULL OR wm.user_id IS NOT NULL)
+`
+
+type GetBoardByIdForUserParams struct {
+	OwnerID int64 `json:"owner_id"`
+	ID      int32 `json:"id"`
+}
+
+type GetBoardByIdForUserRow struct {
+	ID          int32              `json:"id"`
+	SlugID      string             `json:"slug_id"`
+	OwnerID     int64              `json:"owner_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	IsDeleted   bool               `json:"is_deleted"`
+	Name        string             `json:"name"`
+	IsOwner     bool               `json:"is_owner"`
+	Role        string             `json:"role"`
+	WorkspaceID pgtype.Int8        `json:"workspace_id"`
+}
+
+// same as GetBoardBySlugId, for the endpoints that refer to the board by its id
+func (q *Queries) GetBoardByIdForUser(ctx context.Context, arg GetBoardByIdForUserParams) (GetBoardByIdForUserRow, error) {
+	row := q.db.QueryRow(ctx, getBoardByIdForUser, arg.OwnerID, arg.ID)
+	var i GetBoardByIdForUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.SlugID,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.IsDeleted,
+		&i.Name,
+		&i.IsOwner,
+		&i.Role,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
@@ -170,10 +324,16 @@ SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name,
        CASE
            WHEN b.owner_id = $1 THEN TRUE
            ELSE FALSE
-           END AS is_owner
+           END AS is_owner,
+       CASE
+           WHEN bu.role = 'editor' OR wm.role IN ('admin', 'editor') THEN 'editor'
+           ELSE 'viewer'
+           END AS role,
+       b.workspace_id
 FROM boards b
-JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
-WHERE b.is_deleted = FALSE and b.slug_id = $2
+LEFT JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
+LEFT JOIN workspace_members wm ON wm.workspace_id = b.workspace_id AND wm.user_id = $1
+WHERE b.is_deleted = FALSE and b.slug_id = $2 AND (bu.user_id IS NOT NULL OR wm.user_id IS NOT NULL)
 `
 
 type GetBoardBySlugIdParams struct {
@@ -182,15 +342,18 @@ type GetBoardBySlugIdParams struct {
 }
 
 type GetBoardBySlugIdRow struct {
-	ID        int32              `json:"id"`
-	SlugID    string             `json:"slug_id"`
-	OwnerID   int64              `json:"owner_id"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	IsDeleted bool               `json:"is_deleted"`
-	Name      string             `json:"name"`
-	IsOwner   bool               `json:"is_owner"`
+	ID          int32              `json:"id"`
+	SlugID      string             `json:"slug_id"`
+	OwnerID     int64              `json:"owner_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	IsDeleted   bool               `json:"is_deleted"`
+	Name        string             `json:"name"`
+	IsOwner     bool               `json:"is_owner"`
+	Role        string             `json:"role"`
+	WorkspaceID pgtype.Int8        `json:"workspace_id"`
 }
 
+// workspace members inherit the access to the boards of the workspace, the stronger role is used
 func (q *Queries) GetBoardBySlugId(ctx context.Context, arg GetBoardBySlugIdParams) (GetBoardBySlugIdRow, error) {
 	row := q.db.QueryRow(ctx, getBoardBySlugId, arg.OwnerID, arg.SlugID)
 	var i GetBoardBySlugIdRow
@@ -202,20 +365,24 @@ func (q *Queries) GetBoardBySlugId(ctx context.Context, arg GetBoardBySlugIdPara
 		&i.IsDeleted,
 		&i.Name,
 		&i.IsOwner,
+		&i.Role,
+		&i.WorkspaceID,
 	)
 	return i, err
 }
 
 const getBoardPageByBoardId = `-- name: GetBoardPageByBoardId :many
-SELECT name, id, created_at
+SELECT name, id, created_at, position
 FROM board_pages
 WHERE board_id = $1 and is_deleted = false
+ORDER BY position, id
 `
 
 type GetBoardPageByBoardIdRow struct {
 	Name      string             `json:"name"`
 	ID        int32              `json:"id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Position  int32              `json:"position"`
 }
 
 func (q *Queries) GetBoardPageByBoardId(ctx context.Context, boardID int64) ([]GetBoardPageByBoardIdRow, error) {
@@ -227,7 +394,12 @@ func (q *Queries) GetBoardPageByBoardId(ctx context.Context, boardID int64) ([]G
 	items := []GetBoardPageByBoardIdRow{}
 	for rows.Next() {
 		var i GetBoardPageByBoardIdRow
-		if err := rows.Scan(&i.Name, &i.ID, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.Name,
+			&i.ID,
+			&i.CreatedAt,
+			&i.Position,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -275,3 +447,285 @@ func (q *Queries) GetBoardUsers(ctx context.Context, boardID int64) ([]GetBoardU
 	}
 	return items, nil
 }
+
+const getDeletedBoardsForOwner = `-- name: GetDeletedBoardsForOwner :many
+SELECT id, slug_id, name, owner_id, created_at, is_deleted, revision, deleted_at, last_activity_at, template_scope, workspace_id
+FROM boards
+WHERE owner_id = $1 AND is_deleted = TRUE
+ORDER BY deleted_at DESC
+`
+
+func (q *Queries) GetDeletedBoardsForOwner(ctx context.Context, ownerID int64) ([]Board, error) {
+	rows, err := q.db.Query(ctx, getDeletedBoardsForOwner, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Board{}
+	for rows.Next() {
+		var i Board
+		if err := rows.Scan(
+			&i.ID,
+			&i.SlugID,
+			&i.Name,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.IsDeleted,
+			&i.Revision,
+			&i.DeletedAt,
+			&i.LastActivityAt,
+			&i.TemplateScope,
+			&i.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRecentBoardsForUser = `-- name: GetRecentBoardsForUser :many
+SELECT b.id, b.slug_id, b.owner_id, b.created_at, b.is_deleted, b.name, b.last_activity_at,
+       CASE
+           WHEN b.owner_id = $1 THEN TRUE
+           ELSE FALSE
+           END AS is_owner,
+       bu.role, bu.last_opened_at, bu.folder_id,
+       EXISTS (SELECT 1 FROM board_stars bs WHERE bs.board_id = b.id AND bs.user_id = $1) AS is_starred
+FROM boards b
+JOIN board_users bu ON bu.board_id = b.id AND bu.user_id = $1
+WHERE b.is_deleted = FALSE AND bu.last_opened_at IS NOT NULL
+ORDER BY bu.last_opened_at DESC
+LIMIT $2
+`
+
+type GetRecentBoardsForUserParams struct {
+	OwnerID int64 `json:"owner_id"`
+	Limit   int32 `json:"limit"`
+}
+
+type GetRecentBoardsForUserRow struct {
+	ID             int32              `json:"id"`
+	SlugID         string             `json:"slug_id"`
+	OwnerID        int64              `json:"owner_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	IsDeleted      bool               `json:"is_deleted"`
+	Name           string             `json:"name"`
+	LastActivityAt pgtype.Timestamptz `json:"last_activity_at"`
+	IsOwner        bool               `json:"is_owner"`
+	Role           string             `json:"role"`
+	LastOpenedAt   pgtype.Timestamptz `json:"last_opened_at"`
+	FolderID       pgtype.Int8        `json:"folder_id"`
+	IsStarred      bool               `json:"is_starred"`
+}
+
+func (q *Queries) GetRecentBoardsForUser(ctx context.Context, arg GetRecentBoardsForUserParams) ([]GetRecentBoardsForUserRow, error) {
+	rows, err := q.db.Query(ctx, getRecentBoardsForUser, arg.OwnerID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRecentBoardsForUserRow{}
+	for rows.Next() {
+		var i GetRecentBoardsForUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SlugID,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.IsDeleted,
+			&i.Name,
+			&i.LastActivityAt,
+			&i.IsOwner,
+			&i.Role,
+			&i.LastOpenedAt,
+			&i.FolderID,
+			&i.IsStarred,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedBoards = `-- name: PurgeDeletedBoards :execrows
+DELETE FROM "boards"
+WHERE is_deleted = TRUE AND deleted_at < $1
+`
+
+func (q *Queries) PurgeDeletedBoards(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedBoards, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const renameBoard = `-- name: RenameBoard :one
+UPDATE "boards"
+SET name = $2
+WHERE id = $1 AND is_deleted = FALSE
+RETURNING id, slug_id, name, owner_id, created_at, is_deleted, revision, deleted_at, last_activity_at, template_scope, workspace_id
+`
+
+type RenameBoardParams struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) RenameBoard(ctx context.Context, arg RenameBoardParams) (Board, error) {
+	row := q.db.QueryRow(ctx, renameBoard, arg.ID, arg.Name)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.SlugID,
+		&i.Name,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.IsDeleted,
+		&i.Revision,
+		&i.DeletedAt,
+		&i.LastActivityAt,
+		&i.TemplateScope,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const restoreDeletedBoard = `-- name: RestoreDeletedBoard :one
+UPDATE "boards"
+SET is_deleted = FALSE, deleted_at = NULL
+WHERE slug_id = $1 AND owner_id = $2 AND is_deleted = TRUE
+RETURNING id, slug_id, name, owner_id, created_at, is_deleted, revision, deleted_at, last_activity_at, template_scope, workspace_id
+`
+
+type RestoreDeletedBoardParams struct {
+	SlugID  string `json:"slug_id"`
+	OwnerID int64  `json:"owner_id"`
+}
+
+func (q *Queries) RestoreDeletedBoard(ctx context.Context, arg RestoreDeletedBoardParams) (Board, error) {
+	row := q.db.QueryRow(ctx, restoreDeletedBoard, arg.SlugID, arg.OwnerID)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.SlugID,
+		&i.Name,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.IsDeleted,
+		&i.Revision,
+		&i.DeletedAt,
+		&i.LastActivityAt,
+		&i.TemplateScope,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const softDeleteBoard = `-- name: SoftDeleteBoard :one
+UPDATE "boards"
+SET is_deleted = TRUE, deleted_at = now()
+WHERE id = $1 AND is_deleted = FALSE
+RETURNING id, slug_id, name, owner_id, created_at, is_deleted, revision, deleted_at, last_activity_at, template_scope, workspace_id
+`
+
+func (q *Queries) SoftDeleteBoard(ctx context.Context, id int32) (Board, error) {
+	row := q.db.QueryRow(ctx, softDeleteBoard, id)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.SlugID,
+		&i.Name,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.IsDeleted,
+		&i.Revision,
+		&i.DeletedAt,
+		&i.LastActivityAt,
+		&i.TemplateScope,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const updateBoardOwner = `-- name: UpdateBoardOwner :one
+UPDATE "boards"
+SET owner_id = $2
+WHERE id = $1 AND is_deleted = FALSE
+RETURNING id, slug_id, name, owner_id, created_at, is_deleted, revision, deleted_at, last_activity_at, template_scope, workspace_id
+`
+
+type UpdateBoardOwnerParams struct {
+	ID      int32 `json:"id"`
+	OwnerID int64 `json:"owner_id"`
+}
+
+func (q *Queries) UpdateBoardOwner(ctx context.Context, arg UpdateBoardOwnerParams) (Board, error) {
+	row := q.db.QueryRow(ctx, updateBoardOwner, arg.ID, arg.OwnerID)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.SlugID,
+		&i.Name,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.IsDeleted,
+		&i.Revision,
+		&i.DeletedAt,
+		&i.LastActivityAt,
+		&i.TemplateScope,
+		&i.WorkspaceID,
+	)
+	return i, err
+}
+
+const updateBoardUserLastOpened = `-- name: UpdateBoardUserLastOpened :exec
+UPDATE "board_users"
+SET last_opened_at = now()
+WHERE board_id = $1 AND user_id = $2
+`
+
+type UpdateBoardUserLastOpenedParams struct {
+	BoardID int64 `json:"board_id"`
+	UserID  int64 `json:"user_id"`
+}
+
+func (q *Queries) UpdateBoardUserLastOpened(ctx context.Context, arg UpdateBoardUserLastOpenedParams) error {
+	_, err := q.db.Exec(ctx, updateBoardUserLastOpened, arg.BoardID, arg.UserID)
+	return err
+}
+
+const updateBoardUserRole = `-- name: UpdateBoardUserRole :one
+UPDATE "board_users"
+SET role = $3
+WHERE board_id = $1 AND user_id = $2
+RETURNING board_id, user_id, created_at, role, last_opened_at, folder_id
+`
+
+type UpdateBoardUserRoleParams struct {
+	BoardID int64  `json:"board_id"`
+	UserID  int64  `json:"user_id"`
+	Role    string `json:"role"`
+}
+
+func (q *Queries) UpdateBoardUserRole(ctx context.Context, arg UpdateBoardUserRoleParams) (BoardUser, error) {
+	row := q.db.QueryRow(ctx, updateBoardUserRole, arg.BoardID, arg.UserID, arg.Role)
+	var i BoardUser
+	err := row.Scan(
+		&i.BoardID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.Role,
+		&i.LastOpenedAt,
+		&i.FolderID,
+	)
+	return i, err
+}
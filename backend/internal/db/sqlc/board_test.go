@@ -3,6 +3,8 @@ package db
 import (
 	"context"
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -235,9 +237,64 @@ func TestGetAllBoardsForUer(t *testing.T) {
 				require.Equal(t, boardUserResult.UserID, userId)
 
 				// fetch all boards for the first user
-				result, err := testStore.GetAllBoardsForUser(context.Background(), int64(user.ID))
+				result, err := testStore.GetAllBoardsForUser(context.Background(), GetAllBoardsForUserParams{
+					UserID:    userId,
+					Ownership: "all",
+					Sort:      "-created_at",
+					PageSize:  10,
+				})
 				require.NoError(t, err)
 				require.Equal(t, len(result), 2)
+
+				// only the boards of the others
+				result, err = testStore.GetAllBoardsForUser(context.Background(), GetAllBoardsForUserParams{
+					UserID:    userId,
+					Ownership: "shared",
+					Sort:      "-created_at",
+					PageSize:  10,
+				})
+				require.NoError(t, err)
+				require.Equal(t, len(result), 1)
+				require.Equal(t, boardResult2.Board.ID, result[0].ID)
+				require.False(t, result[0].IsOwner)
+			},
+		},
+		{
+			name: "Search and paginate",
+			handler: func(t *testing.T) {
+				user := createTestUser(t)
+				userId := int64(user.ID)
+
+				names := []string{"Roadmap b", "roadmap A", "Retro", "Roadmap C"}
+				for _, name := range names {
+					_, err := testStore.CreateBoardTx(context.Background(), CreateBoardTxParams{
+						Name:    name,
+						SlugId:  gofakeit.LetterN(10),
+						OwnerId: userId,
+					})
+					require.NoError(t, err)
+				}
+
+				params := GetAllBoardsForUserParams{
+					UserID:    userId,
+					Ownership: "owned",
+					Search:    "roadmap",
+					Sort:      "name",
+					PageSize:  2,
+				}
+				firstPage, err := testStore.GetAllBoardsForUser(context.Background(), params)
+				require.NoError(t, err)
+				require.Len(t, firstPage, 2)
+				require.Equal(t, "roadmap A", firstPage[0].Name)
+				require.Equal(t, "Roadmap b", firstPage[1].Name)
+
+				params.HasCursor = true
+				params.CursorName = "roadmap b"
+				params.CursorID = firstPage[1].ID
+				secondPage, err := testStore.GetAllBoardsForUser(context.Background(), params)
+				require.NoError(t, err)
+				require.Len(t, secondPage, 1)
+				require.Equal(t, "Roadmap C", secondPage[0].Name)
 			},
 		},
 	}
@@ -281,6 +338,7 @@ func TestGetBoardBySlugId(t *testing.T) {
 
 	require.NoError(t, err)
 	require.Equal(t, board2.SlugID, createdBoard.SlugID)
+	require.Equal(t, BoardRoleEditor, board2.Role)
 
 	// should return an error since the slug id is not correct
 	board3, err := testStore.GetBoardBySlugId(context.Background(), GetBoardBySlugIdParams{
@@ -292,6 +350,36 @@ func TestGetBoardBySlugId(t *testing.T) {
 	require.Empty(t, board3)
 }
 
+// TestGetBoardByIdForUser tests retrieving a board with its id for a member
+func TestGetBoardByIdForUser(t *testing.T) {
+	user := createTestUser(t)
+	createdBoard := createTestingBoard(t, user)
+	otherUser := createTestUser(t)
+
+	_, err := testStore.AddToBoardUsers(context.Background(), AddToBoardUsersParams{
+		UserID:  int64(user.ID),
+		BoardID: int64(createdBoard.ID),
+		Role:    BoardRoleEditor,
+	})
+	require.NoError(t, err)
+
+	board, err := testStore.GetBoardByIdForUser(context.Background(), GetBoardByIdForUserParams{
+		OwnerID: int64(user.ID),
+		ID:      createdBoard.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, createdBoard.SlugID, board.SlugID)
+	require.True(t, board.IsOwner)
+
+	// the board is not found for the users who are not members
+	board2, err := testStore.GetBoardByIdForUser(context.Background(), GetBoardByIdForUserParams{
+		OwnerID: int64(otherUser.ID),
+		ID:      createdBoard.ID,
+	})
+	require.Error(t, err)
+	require.Empty(t, board2)
+}
+
 // TestGetBoardPageByBoardId tests fetching board page data by board id
 func TestGetBoardPageByBoardId(t *testing.T) {
 	user := createTestUser(t)
@@ -333,3 +421,100 @@ func TestGetBoardUsers(t *testing.T) {
 	require.NotEmpty(t, allUsers2)
 	require.Equal(t, 2, len(allUsers3))
 }
+
+// TestBoardTrash tests moving boards to the trash, restoring and purging them
+func TestBoardTrash(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+
+	deleted, err := testStore.SoftDeleteBoard(context.Background(), board.ID)
+	require.NoError(t, err)
+	require.True(t, deleted.IsDeleted)
+	require.True(t, deleted.DeletedAt.Valid)
+	require.WithinDuration(t, time.Now(), deleted.DeletedAt.Time, time.Second)
+
+	// a board in the trash cannot be deleted again
+	_, err = testStore.SoftDeleteBoard(context.Background(), board.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	deletedBoards, err := testStore.GetDeletedBoardsForOwner(context.Background(), int64(user.ID))
+	require.NoError(t, err)
+	require.Len(t, deletedBoards, 1)
+	require.Equal(t, board.ID, deletedBoards[0].ID)
+
+	// only the owner can restore the board
+	otherUser := createTestUser(t)
+	_, err = testStore.RestoreDeletedBoard(context.Background(), RestoreDeletedBoardParams{
+		SlugID:  board.SlugID,
+		OwnerID: int64(otherUser.ID),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	restored, err := testStore.RestoreDeletedBoard(context.Background(), RestoreDeletedBoardParams{
+		SlugID:  board.SlugID,
+		OwnerID: int64(user.ID),
+	})
+	require.NoError(t, err)
+	require.False(t, restored.IsDeleted)
+	require.False(t, restored.DeletedAt.Valid)
+
+	_, err = testStore.SoftDeleteBoard(context.Background(), board.ID)
+	require.NoError(t, err)
+
+	purged, err := testStore.PurgeDeletedBoards(context.Background(), pgtype.Timestamptz{
+		Time:  time.Now().Add(time.Minute),
+		Valid: true,
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, purged, int64(1))
+
+	_, err = testStore.GetBoardById(context.Background(), board.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+// TestRecentBoards tests recording the boards that the users open
+func TestRecentBoards(t *testing.T) {
+	user := createTestUser(t)
+	userId := int64(user.ID)
+
+	var boards []Board
+	for range 3 {
+		result, err := testStore.CreateBoardTx(context.Background(), CreateBoardTxParams{
+			Name:    gofakeit.LetterN(10),
+			SlugId:  gofakeit.LetterN(10),
+			OwnerId: userId,
+		})
+		require.NoError(t, err)
+		boards = append(boards, result.Board)
+	}
+
+	// boards that are never opened are not listed
+	recent, err := testStore.GetRecentBoardsForUser(context.Background(), GetRecentBoardsForUserParams{OwnerID: userId, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, recent)
+
+	for _, i := range []int{1, 0} {
+		err = testStore.UpdateBoardUserLastOpened(context.Background(), UpdateBoardUserLastOpenedParams{
+			BoardID: int64(boards[i].ID),
+			UserID:  userId,
+		})
+		require.NoError(t, err)
+	}
+
+	recent, err = testStore.GetRecentBoardsForUser(context.Background(), GetRecentBoardsForUserParams{OwnerID: userId, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	require.Equal(t, boards[0].ID, recent[0].ID)
+	require.Equal(t, boards[1].ID, recent[1].ID)
+	require.True(t, recent[0].LastOpenedAt.Valid)
+	require.WithinDuration(t, time.Now(), recent[0].LastOpenedAt.Time, time.Second)
+
+	// changes on the board update its last activity
+	before := boards[2].LastActivityAt.Time
+	_, err = testStore.IncrementBoardRevision(context.Background(), boards[2].SlugID)
+	require.NoError(t, err)
+
+	updated, err := testStore.GetBoardById(context.Background(), boards[2].ID)
+	require.NoError(t, err)
+	require.True(t, updated.LastActivityAt.Time.After(before))
+}
@@ -4,3 +4,22 @@ const DefaultBoardPageName = "Page 1"
 
 const BoardRoleEditor = "editor"
 const BoardRoleViewer = "viewer"
+
+// Types of the operations that are logged in board_operations
+const (
+	OperationElementCreate  = "element.create"
+	OperationElementUpdate  = "element.update"
+	OperationElementDelete  = "element.delete"
+	OperationElementReorder = "element.reorder"
+	OperationPageCreate     = "page.create"
+	OperationPageRename     = "page.rename"
+	OperationPageReorder    = "page.reorder"
+	OperationPageDelete     = "page.delete"
+)
+
+// Roles of the users in a workspace. Admins manage the workspace and its members.
+const (
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleEditor = "editor"
+	WorkspaceRoleViewer = "viewer"
+)
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createTestingBoardElement(t *testing.T, page *BoardPage) *BoardElement {
+	args := CreateBoardElementParams{
+		PageID:      int64(page.ID),
+		Type:        "rectangle",
+		X:           gofakeit.Float64Range(-500, 500),
+		Y:           gofakeit.Float64Range(-500, 500),
+		Width:       gofakeit.Float64Range(1, 500),
+		Height:      gofakeit.Float64Range(1, 500),
+		StrokeColor: 255,
+		StrokeWidth: 2,
+		FontSize:    14,
+	}
+
+	element, err := testStore.CreateBoardElement(context.Background(), args)
+	require.NoError(t, err)
+	require.NotEmpty(t, element)
+	require.Equal(t, args.PageID, element.PageID)
+	require.Equal(t, args.Type, element.Type)
+	require.Equal(t, args.X, element.X)
+	require.Equal(t, args.Width, element.Width)
+	require.False(t, element.IsDeleted)
+	require.Equal(t, int32(1), element.Version)
+	require.WithinDuration(t, element.CreatedAt.Time, time.Now(), time.Second)
+
+	return &element
+}
+
+// TestCreateBoardElement tests creating elements with valid and invalid types
+func TestCreateBoardElement(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+
+	createTestingBoardElement(t, page)
+
+	element, err := testStore.CreateBoardElement(context.Background(), CreateBoardElementParams{
+		PageID: int64(page.ID),
+		Type:   "circle",
+	})
+	require.Error(t, err)
+	require.Empty(t, element)
+}
+
+// TestGetBoardPageBySlugId tests fetching a page only with its own board's slug id
+func TestGetBoardPageBySlugId(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	otherBoard := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+
+	fetchedPage, err := testStore.GetBoardPageBySlugId(context.Background(), GetBoardPageBySlugIdParams{
+		ID:     page.ID,
+		SlugID: board.SlugID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, page.ID, fetchedPage.ID)
+
+	_, err = testStore.GetBoardPageBySlugId(context.Background(), GetBoardPageBySlugIdParams{
+		ID:     page.ID,
+		SlugID: otherBoard.SlugID,
+	})
+	require.True(t, IsErrNoRows(err))
+}
+
+// TestUpdateBoardElement tests updating only the given fields of an element
+func TestUpdateBoardElement(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	otherBoard := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+	element := createTestingBoardElement(t, page)
+
+	updated, err := testStore.UpdateBoardElement(context.Background(), UpdateBoardElementParams{
+		ID:          element.ID,
+		Version:     element.Version,
+		BoardSlugID: board.SlugID,
+		X:           pgtype.Float8{Float64: 42, Valid: true},
+		FillColor:   pgtype.Int8{Int64: 0xFF0000FF, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, float64(42), updated.X)
+	require.Equal(t, int64(0xFF0000FF), updated.FillColor)
+	require.Equal(t, element.Y, updated.Y)
+	require.Equal(t, element.Width, updated.Width)
+	require.Equal(t, element.Version+1, updated.Version)
+
+	// element cannot be updated through another board
+	_, err = testStore.UpdateBoardElement(context.Background(), UpdateBoardElementParams{
+		ID:          element.ID,
+		Version:     updated.Version,
+		BoardSlugID: otherBoard.SlugID,
+		X:           pgtype.Float8{Float64: 1, Valid: true},
+	})
+	require.True(t, IsErrNoRows(err))
+
+	// element cannot be updated with a stale version
+	_, err = testStore.UpdateBoardElement(context.Background(), UpdateBoardElementParams{
+		ID:          element.ID,
+		Version:     element.Version,
+		BoardSlugID: board.SlugID,
+		X:           pgtype.Float8{Float64: 1, Valid: true},
+	})
+	require.True(t, IsErrNoRows(err))
+
+	current, err := testStore.GetBoardElementBySlugId(context.Background(), GetBoardElementBySlugIdParams{
+		ID:     element.ID,
+		SlugID: board.SlugID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, updated.Version, current.Version)
+	require.Equal(t, float64(42), current.X)
+}
+
+// TestDeleteBoardElement tests soft deleting elements
+func TestDeleteBoardElement(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+	element := createTestingBoardElement(t, page)
+	createTestingBoardElement(t, page)
+
+	// the element is not deleted if it has changed since the given version
+	_, err := testStore.DeleteBoardElement(context.Background(), DeleteBoardElementParams{
+		ID:          element.ID,
+		Version:     pgtype.Int4{Int32: element.Version + 1, Valid: true},
+		BoardSlugID: board.SlugID,
+	})
+	require.True(t, IsErrNoRows(err))
+
+	deleted, err := testStore.DeleteBoardElement(context.Background(), DeleteBoardElementParams{
+		ID:          element.ID,
+		Version:     pgtype.Int4{Int32: element.Version, Valid: true},
+		BoardSlugID: board.SlugID,
+	})
+	require.NoError(t, err)
+	require.True(t, deleted.IsDeleted)
+
+	// deleting twice should not find the element
+	_, err = testStore.DeleteBoardElement(context.Background(), DeleteBoardElementParams{
+		ID:          element.ID,
+		BoardSlugID: board.SlugID,
+	})
+	require.True(t, IsErrNoRows(err))
+
+	elements, err := testStore.GetBoardElementsByPageId(context.Background(), int64(page.ID))
+	require.NoError(t, err)
+	require.Len(t, elements, 1)
+
+	elements, err = testStore.GetBoardElementsByBoardId(context.Background(), int64(board.ID))
+	require.NoError(t, err)
+	require.Len(t, elements, 1)
+}
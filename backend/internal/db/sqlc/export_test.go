@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// TestBoardExports tests creating the exports of the pages and storing their rendered files
+func TestBoardExports(t *testing.T) {
+	user := createTestUser(t)
+	otherUser := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+	otherPage := createTestingBoardPage(t, createTestingBoard(t, user))
+
+	// pages of the other boards cannot be exported
+	_, err := testStore.CreateBoardExport(context.Background(), CreateBoardExportParams{
+		UserID:  int64(user.ID),
+		Format:  "png",
+		Scale:   1,
+		PageID:  otherPage.ID,
+		BoardID: int64(board.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+
+	export, err := testStore.CreateBoardExport(context.Background(), CreateBoardExportParams{
+		UserID:       int64(user.ID),
+		Format:       "png",
+		Scale:        2,
+		RegionX:      pgtype.Float8{Float64: 10, Valid: true},
+		RegionY:      pgtype.Float8{Float64: 20, Valid: true},
+		RegionWidth:  pgtype.Float8{Float64: 300, Valid: true},
+		RegionHeight: pgtype.Float8{Float64: 200, Valid: true},
+		PageID:       page.ID,
+		BoardID:      int64(board.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(page.ID), export.PageID.Int64)
+	require.False(t, export.Email.Valid)
+	require.Equal(t, "pending", export.Status)
+	require.Equal(t, 300.0, export.RegionWidth.Float64)
+	require.Nil(t, export.Content)
+	require.False(t, export.CompletedAt.Valid)
+
+	// only the user who requested the export can get it
+	_, err = testStore.GetBoardExportForUser(context.Background(), GetBoardExportForUserParams{
+		ID:     export.ID,
+		UserID: int64(otherUser.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+
+	rowsAffected, err := testStore.CompleteBoardExport(context.Background(), CompleteBoardExportParams{
+		ID:      export.ID,
+		Content: []byte("image"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsAffected)
+
+	// completed exports cannot fail
+	rowsAffected, err = testStore.FailBoardExport(context.Background(), export.ID)
+	require.NoError(t, err)
+	require.Zero(t, rowsAffected)
+
+	completed, err := testStore.GetBoardExportForUser(context.Background(), GetBoardExportForUserParams{
+		ID:     export.ID,
+		UserID: int64(user.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "completed", completed.Status)
+	require.Equal(t, []byte("image"), completed.Content)
+	require.True(t, completed.CompletedAt.Valid)
+
+	_, err = testStore.PurgeBoardExports(context.Background(), pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true})
+	require.NoError(t, err)
+
+	_, err = testStore.GetBoardExport(context.Background(), export.ID)
+	require.True(t, IsErrNoRows(err))
+}
+
+// TestBoardPdfExports tests creating the pdf exports of the boards and recording their emails
+func TestBoardPdfExports(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+
+	export, err := testStore.CreateBoardPdfExport(context.Background(), CreateBoardPdfExportParams{
+		UserID:  int64(user.ID),
+		Email:   pgtype.Text{String: user.Email, Valid: true},
+		BoardID: int64(board.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pdf", export.Format)
+	require.False(t, export.PageID.Valid)
+	require.Equal(t, user.Email, export.Email.String)
+	require.False(t, export.EmailedAt.Valid)
+
+	// exports downloaded with the link are not sent by email
+	linkExport, err := testStore.CreateBoardPdfExport(context.Background(), CreateBoardPdfExportParams{
+		UserID:  int64(user.ID),
+		BoardID: int64(board.ID),
+	})
+	require.NoError(t, err)
+
+	rowsAffected, err := testStore.MarkBoardExportEmailed(context.Background(), linkExport.ID)
+	require.NoError(t, err)
+	require.Zero(t, rowsAffected)
+
+	rowsAffected, err = testStore.MarkBoardExportEmailed(context.Background(), export.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsAffected)
+
+	// the export is sent only once
+	rowsAffected, err = testStore.MarkBoardExportEmailed(context.Background(), export.ID)
+	require.NoError(t, err)
+	require.Zero(t, rowsAffected)
+
+	emailed, err := testStore.GetBoardExport(context.Background(), export.ID)
+	require.NoError(t, err)
+	require.True(t, emailed.EmailedAt.Valid)
+
+	// deleted boards cannot be exported
+	_, err = testStore.SoftDeleteBoard(context.Background(), board.ID)
+	require.NoError(t, err)
+
+	_, err = testStore.CreateBoardPdfExport(context.Background(), CreateBoardPdfExportParams{
+		UserID:  int64(user.ID),
+		BoardID: int64(board.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createTestingBoardFolder(t *testing.T, user *User, name string) *BoardFolder {
+	folder, err := testStore.CreateBoardFolder(context.Background(), CreateBoardFolderParams{
+		UserID: int64(user.ID),
+		Name:   name,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, folder.ID)
+	require.Equal(t, int64(user.ID), folder.UserID)
+	require.Equal(t, name, folder.Name)
+
+	return &folder
+}
+
+// TestBoardFolders tests that the folders are personal and their names are unique for a user
+func TestBoardFolders(t *testing.T) {
+	user := createTestUser(t)
+	otherUser := createTestUser(t)
+
+	folder1 := createTestingBoardFolder(t, user, "work")
+	folder2 := createTestingBoardFolder(t, user, "Archive")
+	// other users can use the same name
+	createTestingBoardFolder(t, otherUser, "work")
+
+	_, err := testStore.CreateBoardFolder(context.Background(), CreateBoardFolderParams{
+		UserID: int64(user.ID),
+		Name:   "work",
+	})
+	require.True(t, IsErrUniqueViolation(err))
+
+	folders, err := testStore.GetBoardFoldersForUser(context.Background(), int64(user.ID))
+	require.NoError(t, err)
+	require.Len(t, folders, 2)
+	require.Equal(t, folder2.ID, folders[0].ID)
+	require.Equal(t, folder1.ID, folders[1].ID)
+
+	renamed, err := testStore.RenameBoardFolder(context.Background(), RenameBoardFolderParams{
+		ID:     folder1.ID,
+		UserID: int64(user.ID),
+		Name:   "projects",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "projects", renamed.Name)
+
+	// folders of the other users cannot be renamed
+	_, err = testStore.RenameBoardFolder(context.Background(), RenameBoardFolderParams{
+		ID:     folder1.ID,
+		UserID: int64(otherUser.ID),
+		Name:   "mine",
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	rows, err := testStore.DeleteBoardFolder(context.Background(), DeleteBoardFolderParams{
+		ID:     folder2.ID,
+		UserID: int64(otherUser.ID),
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testStore.DeleteBoardFolder(context.Background(), DeleteBoardFolderParams{
+		ID:     folder2.ID,
+		UserID: int64(user.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+// TestBoardFoldersAndStars tests filtering the board list by the folders and the stars of the user
+func TestBoardFoldersAndStars(t *testing.T) {
+	user := createTestUser(t)
+	otherUser := createTestUser(t)
+	userId := int64(user.ID)
+
+	var boards []Board
+	for range 2 {
+		result, err := testStore.CreateBoardTx(context.Background(), CreateBoardTxParams{
+			Name:    gofakeit.LetterN(10),
+			SlugId:  gofakeit.LetterN(10),
+			OwnerId: userId,
+		})
+		require.NoError(t, err)
+		boards = append(boards, result.Board)
+	}
+
+	folder := createTestingBoardFolder(t, user, "work")
+	otherFolder := createTestingBoardFolder(t, otherUser, "work")
+
+	// boards cannot be moved to the folders of the others
+	rows, err := testStore.UpdateBoardUserFolder(context.Background(), UpdateBoardUserFolderParams{
+		FolderID: pgtype.Int8{Int64: int64(otherFolder.ID), Valid: true},
+		BoardID:  int64(boards[0].ID),
+		UserID:   userId,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testStore.UpdateBoardUserFolder(context.Background(), UpdateBoardUserFolderParams{
+		FolderID: pgtype.Int8{Int64: int64(folder.ID), Valid: true},
+		BoardID:  int64(boards[0].ID),
+		UserID:   userId,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// starring twice is not an error
+	for range 2 {
+		err = testStore.StarBoard(context.Background(), StarBoardParams{UserID: userId, BoardID: int64(boards[1].ID)})
+		require.NoError(t, err)
+	}
+
+	params := GetAllBoardsForUserParams{
+		UserID:    userId,
+		Ownership: "all",
+		Sort:      "-created_at",
+		PageSize:  10,
+		FolderID:  int64(folder.ID),
+	}
+	result, err := testStore.GetAllBoardsForUser(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, boards[0].ID, result[0].ID)
+	require.Equal(t, int64(folder.ID), result[0].FolderID.Int64)
+	require.False(t, result[0].IsStarred)
+
+	params.FolderID = 0
+	params.Starred = true
+	result, err = testStore.GetAllBoardsForUser(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, boards[1].ID, result[0].ID)
+	require.True(t, result[0].IsStarred)
+
+	err = testStore.UnstarBoard(context.Background(), UnstarBoardParams{UserID: userId, BoardID: int64(boards[1].ID)})
+	require.NoError(t, err)
+	result, err = testStore.GetAllBoardsForUser(context.Background(), params)
+	require.NoError(t, err)
+	require.Empty(t, result)
+
+	// deleting the folder takes the boards out of it
+	_, err = testStore.DeleteBoardFolder(context.Background(), DeleteBoardFolderParams{ID: folder.ID, UserID: userId})
+	require.NoError(t, err)
+
+	params.Starred = false
+	result, err = testStore.GetAllBoardsForUser(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	for _, board := range result {
+		require.False(t, board.FolderID.Valid)
+	}
+}
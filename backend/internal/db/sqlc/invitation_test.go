@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createTestingBoardInvitation(t *testing.T, board *Board, email string, expiry time.Time) *BoardInvitation {
+	args := CreateBoardInvitationParams{
+		Hash:      []byte(gofakeit.UUID()),
+		BoardID:   int64(board.ID),
+		Email:     email,
+		Role:      BoardRoleViewer,
+		InvitedBy: pgtype.Int8{Int64: board.OwnerID, Valid: true},
+		Expiry:    pgtype.Timestamptz{Time: expiry, Valid: true},
+	}
+
+	invitation, err := testStore.CreateBoardInvitation(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, args.Hash, invitation.Hash)
+	require.Equal(t, args.BoardID, invitation.BoardID)
+	require.Equal(t, args.Email, invitation.Email)
+	require.Equal(t, args.Role, invitation.Role)
+	require.WithinDuration(t, expiry, invitation.Expiry.Time, time.Second)
+
+	return &invitation
+}
+
+// TestCreateBoardInvitation tests that inviting the same email again renews the invitation
+func TestCreateBoardInvitation(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	email := gofakeit.Email()
+
+	first := createTestingBoardInvitation(t, board, email, time.Now().Add(time.Hour))
+	second := createTestingBoardInvitation(t, board, email, time.Now().Add(2*time.Hour))
+	require.True(t, second.Expiry.Time.After(first.Expiry.Time))
+	require.NotEqual(t, first.Hash, second.Hash)
+}
+
+// TestAcceptBoardInvitations tests that only unexpired invitations become board memberships
+func TestAcceptBoardInvitations(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	expiredBoard := createTestingBoard(t, user)
+	invitedUser := createTestUser(t)
+
+	createTestingBoardInvitation(t, board, invitedUser.Email, time.Now().Add(time.Hour))
+	createTestingBoardInvitation(t, expiredBoard, invitedUser.Email, time.Now().Add(-time.Hour))
+
+	boardUsers, err := testStore.AcceptBoardInvitations(context.Background(), AcceptBoardInvitationsParams{
+		Email:  invitedUser.Email,
+		UserID: int64(invitedUser.ID),
+	})
+	require.NoError(t, err)
+	require.Len(t, boardUsers, 1)
+	require.Equal(t, int64(board.ID), boardUsers[0].BoardID)
+	require.Equal(t, BoardRoleViewer, boardUsers[0].Role)
+
+	// accepted and expired invitations are removed
+	boardUsers, err = testStore.AcceptBoardInvitations(context.Background(), AcceptBoardInvitationsParams{
+		Email:  invitedUser.Email,
+		UserID: int64(invitedUser.ID),
+	})
+	require.NoError(t, err)
+	require.Empty(t, boardUsers)
+}
+
+// TestAcceptBoardInvitationByHash tests accepting a single invitation with its token
+func TestAcceptBoardInvitationByHash(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	invitedUser := createTestUser(t)
+
+	expired := createTestingBoardInvitation(t, board, gofakeit.Email(), time.Now().Add(-time.Hour))
+	_, err := testStore.AcceptBoardInvitationByHash(context.Background(), AcceptBoardInvitationByHashParams{
+		Hash:   expired.Hash,
+		UserID: int64(invitedUser.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+
+	// the token can be used by an account with another email
+	invitation := createTestingBoardInvitation(t, board, gofakeit.Email(), time.Now().Add(time.Hour))
+	boardUser, err := testStore.AcceptBoardInvitationByHash(context.Background(), AcceptBoardInvitationByHashParams{
+		Hash:   invitation.Hash,
+		UserID: int64(invitedUser.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(board.ID), boardUser.BoardID)
+	require.Equal(t, int64(invitedUser.ID), boardUser.UserID)
+	require.Equal(t, BoardRoleViewer, boardUser.Role)
+
+	// the token is used once
+	_, err = testStore.AcceptBoardInvitationByHash(context.Background(), AcceptBoardInvitationByHashParams{
+		Hash:   invitation.Hash,
+		UserID: int64(invitedUser.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+
+	// members keep their role
+	editor := createTestUser(t)
+	_, err = testStore.AddToBoardUsers(context.Background(), AddToBoardUsersParams{
+		BoardID: int64(board.ID),
+		UserID:  int64(editor.ID),
+		Role:    BoardRoleEditor,
+	})
+	require.NoError(t, err)
+
+	invitation = createTestingBoardInvitation(t, board, gofakeit.Email(), time.Now().Add(time.Hour))
+	boardUser, err = testStore.AcceptBoardInvitationByHash(context.Background(), AcceptBoardInvitationByHashParams{
+		Hash:   invitation.Hash,
+		UserID: int64(editor.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, BoardRoleEditor, boardUser.Role)
+}
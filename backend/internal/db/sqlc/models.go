@@ -9,12 +9,118 @@ import (
 )
 
 type Board struct {
+	ID             int32              `json:"id"`
+	SlugID         string             `json:"slug_id"`
+	Name           string             `json:"name"`
+	OwnerID        int64              `json:"owner_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	IsDeleted      bool               `json:"is_deleted"`
+	Revision       int64              `json:"revision"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
+	LastActivityAt pgtype.Timestamptz `json:"last_activity_at"`
+	TemplateScope  pgtype.Text        `json:"template_scope"`
+	WorkspaceID    pgtype.Int8        `json:"workspace_id"`
+}
+
+type BoardElement struct {
+	ID           int32              `json:"id"`
+	PageID       int64              `json:"page_id"`
+	Type         string             `json:"type"`
+	X            float64            `json:"x"`
+	Y            float64            `json:"y"`
+	Width        float64            `json:"width"`
+	Height       float64            `json:"height"`
+	ZIndex       int32              `json:"z_index"`
+	StrokeColor  int64              `json:"stroke_color"`
+	FillColor    int64              `json:"fill_color"`
+	StrokeWidth  float64            `json:"stroke_width"`
+	BorderRadius float64            `json:"border_radius"`
+	Text         string             `json:"text"`
+	FontSize     int32              `json:"font_size"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	IsDeleted    bool               `json:"is_deleted"`
+	Version      int32              `json:"version"`
+}
+
+type BoardExport struct {
+	ID           int32              `json:"id"`
+	BoardID      int64              `json:"board_id"`
+	PageID       pgtype.Int8        `json:"page_id"`
+	UserID       int64              `json:"user_id"`
+	Format       string             `json:"format"`
+	Scale        float64            `json:"scale"`
+	RegionX      pgtype.Float8      `json:"region_x"`
+	RegionY      pgtype.Float8      `json:"region_y"`
+	RegionWidth  pgtype.Float8      `json:"region_width"`
+	RegionHeight pgtype.Float8      `json:"region_height"`
+	Status       string             `json:"status"`
+	Content      []byte             `json:"content"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+	Email        pgtype.Text        `json:"email"`
+	EmailedAt    pgtype.Timestamptz `json:"emailed_at"`
+}
+
+type BoardFolder struct {
 	ID        int32              `json:"id"`
-	SlugID    string             `json:"slug_id"`
+	UserID    int64              `json:"user_id"`
 	Name      string             `json:"name"`
-	OwnerID   int64              `json:"owner_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	IsDeleted bool               `json:"is_deleted"`
+}
+
+type BoardInvitation struct {
+	Hash      []byte             `json:"hash"`
+	BoardID   int64              `json:"board_id"`
+	Email     string             `json:"email"`
+	Role      string             `json:"role"`
+	InvitedBy pgtype.Int8        `json:"invited_by"`
+	Expiry    pgtype.Timestamptz `json:"expiry"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type BoardOperation struct {
+	BoardID   int64              `json:"board_id"`
+	Revision  int64              `json:"revision"`
+	UserID    pgtype.Int8        `json:"user_id"`
+	Type      string             `json:"type"`
+	PageID    int64              `json:"page_id"`
+	ElementID int64              `json:"element_id"`
+	Data      []byte             `json:"data"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type BoardShareLink struct {
+	ID        int32              `json:"id"`
+	Hash      []byte             `json:"hash"`
+	BoardID   int64              `json:"board_id"`
+	Role      string             `json:"role"`
+	CreatedBy pgtype.Int8        `json:"created_by"`
+	Expiry    pgtype.Timestamptz `json:"expiry"`
+	IsRevoked bool               `json:"is_revoked"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type BoardSnapshot struct {
+	ID        int32              `json:"id"`
+	BoardID   int64              `json:"board_id"`
+	PageID    int64              `json:"page_id"`
+	Revision  int64              `json:"revision"`
+	Data      []byte             `json:"data"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type BoardStar struct {
+	UserID    int64              `json:"user_id"`
+	BoardID   int64              `json:"board_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type BoardThumbnail struct {
+	BoardID   int64              `json:"board_id"`
+	Revision  int64              `json:"revision"`
+	Content   []byte             `json:"content"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
 type BoardPage struct {
@@ -23,13 +129,16 @@ type BoardPage struct {
 	Name      string             `json:"name"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	IsDeleted bool               `json:"is_deleted"`
+	Position  int32              `json:"position"`
 }
 
 type BoardUser struct {
-	BoardID   int64              `json:"board_id"`
-	UserID    int64              `json:"user_id"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	Role      string             `json:"role"`
+	BoardID      int64              `json:"board_id"`
+	UserID       int64              `json:"user_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	Role         string             `json:"role"`
+	LastOpenedAt pgtype.Timestamptz `json:"last_opened_at"`
+	FolderID     pgtype.Int8        `json:"folder_id"`
 }
 
 type Permission struct {
@@ -60,3 +169,17 @@ type UserPermission struct {
 	UserID       int64 `json:"user_id"`
 	PermissionID int64 `json:"permission_id"`
 }
+
+type Workspace struct {
+	ID        int32              `json:"id"`
+	Name      string             `json:"name"`
+	CreatedBy pgtype.Int8        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int64              `json:"workspace_id"`
+	UserID      int64              `json:"user_id"`
+	Role        string             `json:"role"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
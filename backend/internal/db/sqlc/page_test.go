@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestBoardPagePositions tests that the new pages are added to the end and the pages can be reordered
+func TestBoardPagePositions(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page1 := createTestingBoardPage(t, board)
+	page2 := createTestingBoardPage(t, board)
+	require.Equal(t, int32(0), page1.Position)
+	require.Equal(t, int32(1), page2.Position)
+
+	reordered, err := testStore.ReorderBoardPages(context.Background(), ReorderBoardPagesParams{
+		PageIds: []int32{page2.ID, page1.ID},
+		BoardID: int64(board.ID),
+	})
+	require.NoError(t, err)
+	require.Len(t, reordered, 2)
+
+	pages, err := testStore.GetBoardPageByBoardId(context.Background(), int64(board.ID))
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	require.Equal(t, page2.ID, pages[0].ID)
+	require.Equal(t, int32(0), pages[0].Position)
+	require.Equal(t, page1.ID, pages[1].ID)
+	require.Equal(t, int32(1), pages[1].Position)
+
+	// pages of other boards are not changed
+	otherBoard := createTestingBoard(t, user)
+	reordered, err = testStore.ReorderBoardPages(context.Background(), ReorderBoardPagesParams{
+		PageIds: []int32{page1.ID},
+		BoardID: int64(otherBoard.ID),
+	})
+	require.NoError(t, err)
+	require.Empty(t, reordered)
+}
+
+// TestRenameAndDeleteBoardPage tests renaming pages and that the last page of a board cannot be deleted
+func TestRenameAndDeleteBoardPage(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page1 := createTestingBoardPage(t, board)
+	page2 := createTestingBoardPage(t, board)
+
+	renamed, err := testStore.RenameBoardPage(context.Background(), RenameBoardPageParams{
+		ID:      page1.ID,
+		BoardID: int64(board.ID),
+		Name:    "renamed",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "renamed", renamed.Name)
+
+	deleted, err := testStore.DeleteBoardPage(context.Background(), DeleteBoardPageParams{
+		ID:      page1.ID,
+		BoardID: int64(board.ID),
+	})
+	require.NoError(t, err)
+	require.True(t, deleted.IsDeleted)
+
+	_, err = testStore.GetBoardPage(context.Background(), GetBoardPageParams{
+		ID:      page1.ID,
+		BoardID: int64(board.ID),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = testStore.DeleteBoardPage(context.Background(), DeleteBoardPageParams{
+		ID:      page2.ID,
+		BoardID: int64(board.ID),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
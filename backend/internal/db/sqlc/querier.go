@@ -6,27 +6,125 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	// members who are already in the board keep their role
+	AcceptBoardInvitationByHash(ctx context.Context, arg AcceptBoardInvitationByHashParams) (BoardUser, error)
+	AcceptBoardInvitations(ctx context.Context, arg AcceptBoardInvitationsParams) ([]BoardUser, error)
 	AddForUserWithCode(ctx context.Context, arg AddForUserWithCodeParams) ([]UserPermission, error)
 	AddPermissionForUser(ctx context.Context, arg AddPermissionForUserParams) (UserPermission, error)
 	AddToBoardUsers(ctx context.Context, arg AddToBoardUsersParams) (BoardUser, error)
+	AddWorkspaceMember(ctx context.Context, arg AddWorkspaceMemberParams) (WorkspaceMember, error)
+	CompleteBoardExport(ctx context.Context, arg CompleteBoardExportParams) (int64, error)
+	CopyBoardPageElements(ctx context.Context, arg CopyBoardPageElementsParams) (int64, error)
 	CreateBoard(ctx context.Context, arg CreateBoardParams) (Board, error)
+	CreateBoardElement(ctx context.Context, arg CreateBoardElementParams) (BoardElement, error)
+	// the page must be a page of the board that is not deleted
+	CreateBoardExport(ctx context.Context, arg CreateBoardExportParams) (BoardExport, error)
+	CreateBoardFolder(ctx context.Context, arg CreateBoardFolderParams) (BoardFolder, error)
+	CreateBoardInvitation(ctx context.Context, arg CreateBoardInvitationParams) (BoardInvitation, error)
+	CreateBoardOperation(ctx context.Context, arg CreateBoardOperationParams) (BoardOperation, error)
 	CreateBoardPage(ctx context.Context, arg CreateBoardPageParams) (BoardPage, error)
+	// pdf exports contain all the pages of the board, the email is set when the export is sent by email
+	CreateBoardPdfExport(ctx context.Context, arg CreateBoardPdfExportParams) (BoardExport, error)
+	CreateBoardShareLink(ctx context.Context, arg CreateBoardShareLinkParams) (BoardShareLink, error)
+	CreateBoardSnapshot(ctx context.Context, arg CreateBoardSnapshotParams) (BoardSnapshot, error)
 	CreatePermission(ctx context.Context, code string) (Permission, error)
 	CreateToken(ctx context.Context, arg CreateTokenParams) (Token, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams) (Workspace, error)
+	// the version is only checked when it is given
+	DeleteBoardElement(ctx context.Context, arg DeleteBoardElementParams) (BoardElement, error)
+	DeleteBoardFolder(ctx context.Context, arg DeleteBoardFolderParams) (int64, error)
+	DeleteBoardOperationsUntilRevision(ctx context.Context, arg DeleteBoardOperationsUntilRevisionParams) (int64, error)
+	// the last page of a board cannot be deleted
+	DeleteBoardPage(ctx context.Context, arg DeleteBoardPageParams) (BoardPage, error)
+	DeleteBoardUser(ctx context.Context, arg DeleteBoardUserParams) (int64, error)
 	DeleteTokensForUser(ctx context.Context, arg DeleteTokensForUserParams) error
-	GetAllBoardsForUser(ctx context.Context, ownerID int64) ([]GetAllBoardsForUserRow, error)
+	DeleteWorkspace(ctx context.Context, id int32) (int64, error)
+	// the last admin of the workspace cannot be removed
+	DeleteWorkspaceMember(ctx context.Context, arg DeleteWorkspaceMemberParams) (int64, error)
+	FailBoardExport(ctx context.Context, id int32) (int64, error)
+	// boards are listed with keyset pagination, the cursor is the sort value and the id of the last board of the previous page
+	GetAllBoardsForUser(ctx context.Context, arg GetAllBoardsForUserParams) ([]GetAllBoardsForUserRow, error)
 	GetAllPermissionsForUser(ctx context.Context, id int32) ([]string, error)
 	GetBoardById(ctx context.Context, id int32) (Board, error)
+	// same as GetBoardBySlugId, for the endpoints that refer to the board by its id
+	GetBoardByIdForUser(ctx context.Context, arg GetBoardByIdForUserParams) (GetBoardByIdForUserRow, error)
+	GetBoardByShareLink(ctx context.Context, arg GetBoardByShareLinkParams) (GetBoardByShareLinkRow, error)
+	// workspace members inherit the access to the boards of the workspace, the stronger role is used
 	GetBoardBySlugId(ctx context.Context, arg GetBoardBySlugIdParams) (GetBoardBySlugIdRow, error)
+	GetBoardElementBySlugId(ctx context.Context, arg GetBoardElementBySlugIdParams) (BoardElement, error)
+	GetBoardElementsByBoardId(ctx context.Context, boardID int64) ([]BoardElement, error)
+	GetBoardElementsByPageId(ctx context.Context, pageID int64) ([]BoardElement, error)
+	GetBoardExport(ctx context.Context, id int32) (BoardExport, error)
+	GetBoardExportForUser(ctx context.Context, arg GetBoardExportForUserParams) (BoardExport, error)
+	GetBoardFoldersForUser(ctx context.Context, userID int64) ([]BoardFolder, error)
+	GetBoardForSnapshot(ctx context.Context, slugID string) (GetBoardForSnapshotRow, error)
+	GetBoardOperationsAfterRevision(ctx context.Context, arg GetBoardOperationsAfterRevisionParams) ([]BoardOperation, error)
+	GetBoardOperationsBetweenRevisions(ctx context.Context, arg GetBoardOperationsBetweenRevisionsParams) ([]BoardOperation, error)
+	GetBoardOperationsWithAuthors(ctx context.Context, arg GetBoardOperationsWithAuthorsParams) ([]GetBoardOperationsWithAuthorsRow, error)
+	GetBoardPage(ctx context.Context, arg GetBoardPageParams) (BoardPage, error)
 	GetBoardPageByBoardId(ctx context.Context, boardID int64) ([]GetBoardPageByBoardIdRow, error)
+	GetBoardPageBySlugId(ctx context.Context, arg GetBoardPageBySlugIdParams) (GetBoardPageBySlugIdRow, error)
+	GetBoardRevisionBySlugId(ctx context.Context, slugID string) (int64, error)
+	GetBoardShareLinks(ctx context.Context, boardID int64) ([]BoardShareLink, error)
+	GetBoardSnapshotRevisions(ctx context.Context, slugID string) ([]GetBoardSnapshotRevisionsRow, error)
+	GetBoardThumbnail(ctx context.Context, boardID int64) (BoardThumbnail, error)
+	// the thumbnail shows the first page of the board, thumbnail_revision is null if the board has no thumbnail yet
+	GetBoardThumbnailSource(ctx context.Context, slugID string) (GetBoardThumbnailSourceRow, error)
 	GetBoardUsers(ctx context.Context, boardID int64) ([]GetBoardUsersRow, error)
+	GetDeletedBoardsForOwner(ctx context.Context, ownerID int64) ([]Board, error)
 	GetForToken(ctx context.Context, arg GetForTokenParams) (GetForTokenRow, error)
+	GetLatestBoardSnapshotRevision(ctx context.Context, boardID int64) (int64, error)
+	GetLatestBoardSnapshots(ctx context.Context, slugID string) ([]BoardSnapshot, error)
+	// pages created after the latest snapshot are not in any snapshot, their history starts with their creation
+	GetPageCreateRevision(ctx context.Context, arg GetPageCreateRevisionParams) (int64, error)
+	GetPageSnapshotAtRevision(ctx context.Context, arg GetPageSnapshotAtRevisionParams) (BoardSnapshot, error)
+	GetRecentBoardsForUser(ctx context.Context, arg GetRecentBoardsForUserParams) ([]GetRecentBoardsForUserRow, error)
+	GetTemplateForUser(ctx context.Context, arg GetTemplateForUserParams) (Board, error)
+	// system templates are listed to everyone, personal templates only to their owners and workspace templates to the workspace members
+	GetTemplatesForUser(ctx context.Context, userID int64) ([]Board, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetWorkspaceBoards(ctx context.Context, workspaceID pgtype.Int8) ([]Board, error)
+	GetWorkspaceMemberRole(ctx context.Context, arg GetWorkspaceMemberRoleParams) (string, error)
+	GetWorkspaceMembers(ctx context.Context, workspaceID int64) ([]GetWorkspaceMembersRow, error)
+	GetWorkspacesForUser(ctx context.Context, userID int64) ([]GetWorkspacesForUserRow, error)
+	IncrementBoardRevision(ctx context.Context, slugID string) (IncrementBoardRevisionRow, error)
+	// locking the board serializes the changes of its pages
+	LockBoard(ctx context.Context, id int32) (string, error)
+	MarkBoardExportEmailed(ctx context.Context, id int32) (int64, error)
+	PurgeBoardExports(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	PurgeDeletedBoards(ctx context.Context, deletedAt pgtype.Timestamptz) (int64, error)
+	RenameBoard(ctx context.Context, arg RenameBoardParams) (Board, error)
+	RenameBoardFolder(ctx context.Context, arg RenameBoardFolderParams) (BoardFolder, error)
+	RenameBoardPage(ctx context.Context, arg RenameBoardPageParams) (BoardPage, error)
+	RenameWorkspace(ctx context.Context, arg RenameWorkspaceParams) (Workspace, error)
+	ReorderBoardPages(ctx context.Context, arg ReorderBoardPagesParams) ([]BoardPage, error)
+	RestoreBoardElement(ctx context.Context, arg RestoreBoardElementParams) (BoardElement, error)
+	RestoreDeletedBoard(ctx context.Context, arg RestoreDeletedBoardParams) (Board, error)
+	RevokeBoardShareLink(ctx context.Context, arg RevokeBoardShareLinkParams) (BoardShareLink, error)
+	// thumbnails of older revisions do not replace the newer ones
+	SaveBoardThumbnail(ctx context.Context, arg SaveBoardThumbnailParams) (int64, error)
+	SoftDeleteBoard(ctx context.Context, id int32) (Board, error)
+	StarBoard(ctx context.Context, arg StarBoardParams) error
+	UnstarBoard(ctx context.Context, arg UnstarBoardParams) error
+	UpdateBoardElement(ctx context.Context, arg UpdateBoardElementParams) (BoardElement, error)
+	UpdateBoardOwner(ctx context.Context, arg UpdateBoardOwnerParams) (Board, error)
+	// a null scope stops the board from being a template
+	UpdateBoardTemplateScope(ctx context.Context, arg UpdateBoardTemplateScopeParams) (Board, error)
+	// members can only put the board into their own folders, a null folder takes the board out of its folder
+	UpdateBoardUserFolder(ctx context.Context, arg UpdateBoardUserFolderParams) (int64, error)
+	UpdateBoardUserLastOpened(ctx context.Context, arg UpdateBoardUserLastOpenedParams) error
+	UpdateBoardUserRole(ctx context.Context, arg UpdateBoardUserRoleParams) (BoardUser, error)
+	// workspace templates stop being templates when they leave the workspace
+	UpdateBoardWorkspace(ctx context.Context, arg UpdateBoardWorkspaceParams) (Board, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	// the last admin of the workspace cannot be demoted
+	UpdateWorkspaceMemberRole(ctx context.Context, arg UpdateWorkspaceMemberRoleParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createTestingBoardShareLink(t *testing.T, board *Board, expiry pgtype.Timestamptz) *BoardShareLink {
+	args := CreateBoardShareLinkParams{
+		Hash:      []byte(gofakeit.UUID()),
+		BoardID:   int64(board.ID),
+		Role:      BoardRoleViewer,
+		CreatedBy: pgtype.Int8{Int64: board.OwnerID, Valid: true},
+		Expiry:    expiry,
+	}
+
+	link, err := testStore.CreateBoardShareLink(context.Background(), args)
+	require.NoError(t, err)
+	require.NotZero(t, link.ID)
+	require.Equal(t, args.Hash, link.Hash)
+	require.Equal(t, args.BoardID, link.BoardID)
+	require.Equal(t, args.Role, link.Role)
+	require.Equal(t, args.Expiry.Valid, link.Expiry.Valid)
+	require.False(t, link.IsRevoked)
+
+	return &link
+}
+
+// TestGetBoardByShareLink tests that only the valid links of the board give access to it
+func TestGetBoardByShareLink(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	otherBoard := createTestingBoard(t, user)
+
+	link := createTestingBoardShareLink(t, board, pgtype.Timestamptz{})
+	expiredLink := createTestingBoardShareLink(t, board, pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true})
+
+	row, err := testStore.GetBoardByShareLink(context.Background(), GetBoardByShareLinkParams{
+		SlugID: board.SlugID,
+		Hash:   link.Hash,
+	})
+	require.NoError(t, err)
+	require.Equal(t, board.ID, row.ID)
+	require.Equal(t, link.ID, row.ShareLinkID)
+	require.Equal(t, BoardRoleViewer, row.Role)
+
+	// link of a board cannot be used for another one
+	_, err = testStore.GetBoardByShareLink(context.Background(), GetBoardByShareLinkParams{
+		SlugID: otherBoard.SlugID,
+		Hash:   link.Hash,
+	})
+	require.True(t, IsErrNoRows(err))
+
+	_, err = testStore.GetBoardByShareLink(context.Background(), GetBoardByShareLinkParams{
+		SlugID: board.SlugID,
+		Hash:   expiredLink.Hash,
+	})
+	require.True(t, IsErrNoRows(err))
+
+	// links of another board cannot be revoked
+	_, err = testStore.RevokeBoardShareLink(context.Background(), RevokeBoardShareLinkParams{
+		ID:      link.ID,
+		BoardID: int64(otherBoard.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+
+	revoked, err := testStore.RevokeBoardShareLink(context.Background(), RevokeBoardShareLinkParams{
+		ID:      link.ID,
+		BoardID: int64(board.ID),
+	})
+	require.NoError(t, err)
+	require.True(t, revoked.IsRevoked)
+
+	_, err = testStore.GetBoardByShareLink(context.Background(), GetBoardByShareLinkParams{
+		SlugID: board.SlugID,
+		Hash:   link.Hash,
+	})
+	require.True(t, IsErrNoRows(err))
+
+	links, err := testStore.GetBoardShareLinks(context.Background(), int64(board.ID))
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+}
@@ -13,6 +13,21 @@ type Store interface {
 	RegisterUserTx(ctx context.Context, params RegisterUserTxParams) (RegisterUserTxResult, error)
 	CreateBoardTx(ctx context.Context, params CreateBoardTxParams) (CreateBoardTxResult, error)
 	ActivateUserTx(ctx context.Context, plainToken string) (ActivateUserTxResult, error)
+	CreateElementTx(ctx context.Context, params CreateElementTxParams) (ElementOperationTxResult, error)
+	UpdateElementTx(ctx context.Context, params UpdateElementTxParams) (ElementOperationTxResult, error)
+	DeleteElementTx(ctx context.Context, params DeleteElementTxParams) (ElementOperationTxResult, error)
+	RestoreElementTx(ctx context.Context, params RestoreElementTxParams) (ElementOperationTxResult, error)
+	CreateSnapshotTx(ctx context.Context, boardSlugId string) (CreateSnapshotTxResult, error)
+	RestorePageTx(ctx context.Context, params RestorePageTxParams) (RestorePageTxResult, error)
+	TransferBoardOwnershipTx(ctx context.Context, params TransferBoardOwnershipTxParams) (Board, error)
+	DuplicateBoardTx(ctx context.Context, params DuplicateBoardTxParams) (DuplicateBoardTxResult, error)
+	DuplicateBoardPageTx(ctx context.Context, params DuplicateBoardPageTxParams) (DuplicateBoardPageTxResult, error)
+	CreatePageTx(ctx context.Context, params CreatePageTxParams) (PageOperationTxResult, error)
+	RenamePageTx(ctx context.Context, params RenamePageTxParams) (PageOperationTxResult, error)
+	ReorderPagesTx(ctx context.Context, params ReorderPagesTxParams) (ReorderPagesTxResult, error)
+	DeletePageTx(ctx context.Context, params DeletePageTxParams) (PageOperationTxResult, error)
+	CreateWorkspaceTx(ctx context.Context, params CreateWorkspaceTxParams) (CreateWorkspaceTxResult, error)
+	ImportBoardTx(ctx context.Context, params ImportBoardTxParams) (ImportBoardTxResult, error)
 }
 
 type SQLStore struct {
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestBoardTemplates tests that the personal templates are only listed to their owners
+func TestBoardTemplates(t *testing.T) {
+	user := createTestUser(t)
+	otherUser := createTestUser(t)
+	board := createTestingBoard(t, user)
+
+	marked, err := testStore.UpdateBoardTemplateScope(context.Background(), UpdateBoardTemplateScopeParams{
+		TemplateScope: pgtype.Text{String: "personal", Valid: true},
+		ID:            board.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "personal", marked.TemplateScope.String)
+
+	// unknown scopes are rejected by the constraint
+	_, err = testStore.UpdateBoardTemplateScope(context.Background(), UpdateBoardTemplateScopeParams{
+		TemplateScope: pgtype.Text{String: "unknown", Valid: true},
+		ID:            board.ID,
+	})
+	require.Error(t, err)
+
+	template, err := testStore.GetTemplateForUser(context.Background(), GetTemplateForUserParams{
+		ID:     board.ID,
+		UserID: int64(user.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, board.ID, template.ID)
+
+	_, err = testStore.GetTemplateForUser(context.Background(), GetTemplateForUserParams{
+		ID:     board.ID,
+		UserID: int64(otherUser.ID),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	templates, err := testStore.GetTemplatesForUser(context.Background(), int64(otherUser.ID))
+	require.NoError(t, err)
+	for _, template := range templates {
+		require.Equal(t, "system", template.TemplateScope.String)
+	}
+
+	unmarked, err := testStore.UpdateBoardTemplateScope(context.Background(), UpdateBoardTemplateScopeParams{ID: board.ID})
+	require.NoError(t, err)
+	require.False(t, unmarked.TemplateScope.Valid)
+
+	_, err = testStore.GetTemplateForUser(context.Background(), GetTemplateForUserParams{
+		ID:     board.ID,
+		UserID: int64(user.ID),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestBoardThumbnails tests finding the first page of the boards and keeping the latest thumbnail
+func TestBoardThumbnails(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+
+	// boards without pages have nothing to render
+	_, err := testStore.GetBoardThumbnailSource(context.Background(), board.SlugID)
+	require.True(t, IsErrNoRows(err))
+
+	firstPage := createTestingBoardPage(t, board)
+	createTestingBoardPage(t, board)
+
+	revision, err := testStore.IncrementBoardRevision(context.Background(), board.SlugID)
+	require.NoError(t, err)
+
+	source, err := testStore.GetBoardThumbnailSource(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, board.ID, source.BoardID)
+	require.Equal(t, firstPage.ID, source.PageID)
+	require.Equal(t, revision.Revision, source.Revision)
+	require.False(t, source.ThumbnailRevision.Valid)
+
+	rowsAffected, err := testStore.SaveBoardThumbnail(context.Background(), SaveBoardThumbnailParams{
+		BoardID:  int64(board.ID),
+		Revision: revision.Revision,
+		Content:  []byte("new"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsAffected)
+
+	// thumbnails of older revisions are ignored
+	rowsAffected, err = testStore.SaveBoardThumbnail(context.Background(), SaveBoardThumbnailParams{
+		BoardID:  int64(board.ID),
+		Revision: revision.Revision - 1,
+		Content:  []byte("old"),
+	})
+	require.NoError(t, err)
+	require.Zero(t, rowsAffected)
+
+	thumbnail, err := testStore.GetBoardThumbnail(context.Background(), int64(board.ID))
+	require.NoError(t, err)
+	require.Equal(t, []byte("new"), thumbnail.Content)
+	require.Equal(t, revision.Revision, thumbnail.Revision)
+
+	source, err = testStore.GetBoardThumbnailSource(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, revision.Revision, source.ThumbnailRevision.Int64)
+
+}
@@ -43,7 +43,7 @@ func (s *SQLStore) ActivateUserTx(ctx context.Context, plainToken string) (Activ
 			AuthProviderID: tokenRow.AuthProviderID,
 		}
 
-		_, err = queries.UpdateUser(ctx, UpdateUserParams{
+		result.User, err = queries.UpdateUser(ctx, UpdateUserParams{
 			ID:         result.User.ID,
 			Version:    tokenRow.Version,
 			IsVerified: pgtype.Bool{Bool: true, Valid: true},
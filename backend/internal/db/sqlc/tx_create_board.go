@@ -3,24 +3,52 @@ package db
 import "context"
 
 type CreateBoardTxParams struct {
+	// Name of the board, the boards created from a template are named after the template when it is empty
 	Name     string
 	SlugId   string
 	OwnerId  int64
 	PageName *string
+	// TemplateId is the board to clone the pages and elements from, zero creates an empty board.
+	// The owner must be able to use the template, otherwise pgx.ErrNoRows is returned.
+	TemplateId int64
 }
 
 type CreateBoardTxResult struct {
 	Board Board
-	Page  BoardPage
+	// Page is the first page of the board
+	Page BoardPage
 }
 
-// CreateBoardTx creates a board along with a page within a transaction
+// CreateBoardTx creates a board along with a page within a transaction.
+// Boards created from a template get a copy of the pages and elements of the template instead.
 func (s *SQLStore) CreateBoardTx(ctx context.Context, params CreateBoardTxParams) (CreateBoardTxResult, error) {
 	var result CreateBoardTxResult
 
 	err := s.execTx(ctx, func(queries *Queries) error {
+		name := params.Name
+
+		var templatePages []GetBoardPageByBoardIdRow
+		if params.TemplateId != 0 {
+			template, err := queries.GetTemplateForUser(ctx, GetTemplateForUserParams{
+				ID:     int32(params.TemplateId),
+				UserID: params.OwnerId,
+			})
+			if err != nil {
+				return err
+			}
+
+			templatePages, err = queries.GetBoardPageByBoardId(ctx, params.TemplateId)
+			if err != nil {
+				return err
+			}
+
+			if name == "" {
+				name = template.Name
+			}
+		}
+
 		board, err := queries.CreateBoard(ctx, CreateBoardParams{
-			Name:    params.Name,
+			Name:    name,
 			SlugID:  params.SlugId,
 			OwnerID: params.OwnerId,
 		})
@@ -31,15 +59,49 @@ func (s *SQLStore) CreateBoardTx(ctx context.Context, params CreateBoardTxParams
 
 		result.Board = board
 
-		pageName := DefaultBoardPageName
-		if params.PageName != nil {
-			pageName = *params.PageName
+		if len(templatePages) == 0 {
+			pageName := DefaultBoardPageName
+			if params.PageName != nil {
+				pageName = *params.PageName
+			}
+
+			result.Page, err = queries.CreateBoardPage(ctx, CreateBoardPageParams{
+				Name:    pageName,
+				BoardID: int64(board.ID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for i, templatePage := range templatePages {
+			page, err := queries.CreateBoardPage(ctx, CreateBoardPageParams{
+				Name:    templatePage.Name,
+				BoardID: int64(board.ID),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = queries.CopyBoardPageElements(ctx, CopyBoardPageElementsParams{
+				TargetPageID: int64(page.ID),
+				SourcePageID: int64(templatePage.ID),
+			})
+			if err != nil {
+				return err
+			}
+
+			if i == 0 {
+				result.Page = page
+			}
 		}
 
-		result.Page, err = queries.CreateBoardPage(ctx, CreateBoardPageParams{
-			Name:    pageName,
-			BoardID: int64(board.ID),
-		})
+		if len(templatePages) > 0 {
+			err = createInitialSnapshot(ctx, queries, &result.Board)
+			if err != nil {
+				return err
+			}
+		}
 
 		_, err = queries.AddToBoardUsers(
 			ctx,
@@ -3,6 +3,8 @@ package db
 import (
 	"context"
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 	"strings"
 	"testing"
@@ -107,6 +109,62 @@ func TestCreateBoardTx(t *testing.T) {
 				require.True(t, IsErrForeignKeyViolation(err))
 			},
 		},
+		{
+			name: "From a system template",
+			handler: func(t *testing.T) {
+				templates, err := testStore.GetTemplatesForUser(context.Background(), int64(user.ID))
+				require.NoError(t, err)
+				require.NotEmpty(t, templates)
+				require.Equal(t, "system", templates[0].TemplateScope.String)
+
+				templateElements, err := testStore.GetBoardElementsByBoardId(context.Background(), int64(templates[0].ID))
+				require.NoError(t, err)
+				require.NotEmpty(t, templateElements)
+
+				result, err := testStore.CreateBoardTx(context.Background(), CreateBoardTxParams{
+					OwnerId:    int64(user.ID),
+					SlugId:     gofakeit.LetterN(10),
+					TemplateId: int64(templates[0].ID),
+				})
+				require.NoError(t, err)
+				require.Equal(t, templates[0].Name, result.Board.Name)
+				require.False(t, result.Board.TemplateScope.Valid)
+				require.Equal(t, int64(result.Board.ID), result.Page.BoardID)
+
+				elements, err := testStore.GetBoardElementsByBoardId(context.Background(), int64(result.Board.ID))
+				require.NoError(t, err)
+				require.Len(t, elements, len(templateElements))
+
+				// the copied elements are in the first snapshot of the board, since they are not logged as operations
+				require.Equal(t, int64(1), result.Board.Revision)
+				snapshots, err := testStore.GetLatestBoardSnapshots(context.Background(), result.Board.SlugID)
+				require.NoError(t, err)
+				require.NotEmpty(t, snapshots)
+				for _, snapshot := range snapshots {
+					require.Equal(t, int64(1), snapshot.Revision)
+				}
+			},
+		},
+		{
+			name: "From a personal template of another user",
+			handler: func(t *testing.T) {
+				otherUser := createTestUser(t)
+				template := createTestingBoard(t, otherUser)
+				_, err := testStore.UpdateBoardTemplateScope(context.Background(), UpdateBoardTemplateScopeParams{
+					TemplateScope: pgtype.Text{String: "personal", Valid: true},
+					ID:            template.ID,
+				})
+				require.NoError(t, err)
+
+				_, err = testStore.CreateBoardTx(context.Background(), CreateBoardTxParams{
+					Name:       gofakeit.LetterN(10),
+					OwnerId:    int64(user.ID),
+					SlugId:     gofakeit.LetterN(10),
+					TemplateId: int64(template.ID),
+				})
+				require.ErrorIs(t, err, pgx.ErrNoRows)
+			},
+		},
 		{
 			name: "Transaction Integrity",
 			handler: func(t *testing.T) {
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type CreateSnapshotTxResult struct {
+	Revision  int64
+	Snapshots []BoardSnapshot
+	// CompactedOperations is the number of operations deleted from the history
+	CompactedOperations int64
+}
+
+// CreateSnapshotTx saves the element state of every page of the board at its current revision within a transaction.
+// Operations up to the previous snapshot are compacted, so that the clients that are a bit behind the
+// new snapshot can still catch up with the operations.
+func (s *SQLStore) CreateSnapshotTx(ctx context.Context, boardSlugId string) (CreateSnapshotTxResult, error) {
+	var result CreateSnapshotTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		// locking the board blocks the operations until the snapshot is taken
+		board, err := queries.GetBoardForSnapshot(ctx, boardSlugId)
+		if err != nil {
+			return err
+		}
+		result.Revision = board.Revision
+
+		previousRevision, err := queries.GetLatestBoardSnapshotRevision(ctx, int64(board.ID))
+		if err != nil {
+			return err
+		}
+
+		// nothing has changed since the latest snapshot
+		if previousRevision == board.Revision {
+			return nil
+		}
+
+		result.Snapshots, err = createBoardSnapshots(ctx, queries, int64(board.ID), board.Revision)
+		if err != nil {
+			return err
+		}
+
+		result.CompactedOperations, err = queries.DeleteBoardOperationsUntilRevision(ctx, DeleteBoardOperationsUntilRevisionParams{
+			BoardID:  int64(board.ID),
+			Revision: previousRevision,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// createBoardSnapshots saves the element state of every page of the board as the snapshots of the revision
+func createBoardSnapshots(ctx context.Context, queries *Queries, boardId int64, revision int64) ([]BoardSnapshot, error) {
+	pages, err := queries.GetBoardPageByBoardId(ctx, boardId)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := queries.GetBoardElementsByBoardId(ctx, boardId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageElements := make(map[int64][]BoardElement, len(pages))
+	for _, element := range elements {
+		pageElements[element.PageID] = append(pageElements[element.PageID], element)
+	}
+
+	snapshots := make([]BoardSnapshot, 0, len(pages))
+	for _, page := range pages {
+		elementsOfPage, exists := pageElements[int64(page.ID)]
+		if !exists {
+			elementsOfPage = []BoardElement{}
+		}
+
+		snapshotData, err := json.Marshal(elementsOfPage)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot, err := queries.CreateBoardSnapshot(ctx, CreateBoardSnapshotParams{
+			BoardID:  boardId,
+			PageID:   int64(page.ID),
+			Revision: revision,
+			Data:     snapshotData,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// createInitialSnapshot starts the history of a board that is created with elements in it, such as a board
+// created from a template. Instead of logging every element as an operation, the board is moved to its first
+// revision and the elements are saved as the snapshots of that revision, which the clients load when they join.
+func createInitialSnapshot(ctx context.Context, queries *Queries, board *Board) error {
+	revision, err := queries.IncrementBoardRevision(ctx, board.SlugID)
+	if err != nil {
+		return err
+	}
+	board.Revision = revision.Revision
+
+	_, err = createBoardSnapshots(ctx, queries, int64(board.ID), board.Revision)
+	return err
+}
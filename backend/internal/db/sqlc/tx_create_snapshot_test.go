@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestCreateSnapshotTx tests taking snapshots of the pages and compacting the operations
+func TestCreateSnapshotTx(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+	emptyPage := createTestingBoardPage(t, board)
+
+	createElement := func() {
+		_, err := testStore.CreateElementTx(context.Background(), CreateElementTxParams{
+			BoardSlugId: board.SlugID,
+			UserId:      int64(user.ID),
+			Element: CreateBoardElementParams{
+				PageID:   int64(page.ID),
+				Type:     "rectangle",
+				FontSize: 14,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	createElement()
+	createElement()
+
+	result, err := testStore.CreateSnapshotTx(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Revision)
+	require.Len(t, result.Snapshots, 2)
+	// there is no previous snapshot, nothing to compact yet
+	require.Zero(t, result.CompactedOperations)
+
+	for _, snapshot := range result.Snapshots {
+		var elements []BoardElement
+		require.NoError(t, json.Unmarshal(snapshot.Data, &elements))
+
+		switch snapshot.PageID {
+		case int64(page.ID):
+			require.Len(t, elements, 2)
+		case int64(emptyPage.ID):
+			require.Empty(t, elements)
+		}
+	}
+
+	// unchanged board is not snapshot again
+	result, err = testStore.CreateSnapshotTx(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Empty(t, result.Snapshots)
+
+	createElement()
+
+	result, err = testStore.CreateSnapshotTx(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.Revision)
+	require.Equal(t, int64(2), result.CompactedOperations)
+
+	latest, err := testStore.GetLatestBoardSnapshots(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	require.Equal(t, int64(3), latest[0].Revision)
+
+	operations, err := testStore.GetBoardOperationsAfterRevision(context.Background(), GetBoardOperationsAfterRevisionParams{
+		SlugID: board.SlugID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	require.Equal(t, int64(3), operations[0].Revision)
+
+	_, err = testStore.CreateSnapshotTx(context.Background(), "missing")
+	require.True(t, IsErrNoRows(err))
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateWorkspaceTxParams struct {
+	Name   string
+	UserId int64
+}
+
+type CreateWorkspaceTxResult struct {
+	Workspace Workspace
+	Member    WorkspaceMember
+}
+
+// CreateWorkspaceTx creates a workspace and adds the user who creates it as an admin within a transaction
+func (s *SQLStore) CreateWorkspaceTx(ctx context.Context, params CreateWorkspaceTxParams) (CreateWorkspaceTxResult, error) {
+	var result CreateWorkspaceTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Workspace, err = queries.CreateWorkspace(ctx, CreateWorkspaceParams{
+			Name:      params.Name,
+			CreatedBy: pgtype.Int8{Int64: params.UserId, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Member, err = queries.AddWorkspaceMember(ctx, AddWorkspaceMemberParams{
+			WorkspaceID: int64(result.Workspace.ID),
+			UserID:      params.UserId,
+			Role:        WorkspaceRoleAdmin,
+		})
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func createTestingWorkspace(t *testing.T, user *User) *Workspace {
+	result, err := testStore.CreateWorkspaceTx(context.Background(), CreateWorkspaceTxParams{
+		Name:   gofakeit.Company(),
+		UserId: int64(user.ID),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, result.Workspace.ID)
+
+	return &result.Workspace
+}
+
+// TestCreateWorkspaceTx tests that the user who creates the workspace becomes its admin
+func TestCreateWorkspaceTx(t *testing.T) {
+	user := createTestUser(t)
+
+	result, err := testStore.CreateWorkspaceTx(context.Background(), CreateWorkspaceTxParams{
+		Name:   "team",
+		UserId: int64(user.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "team", result.Workspace.Name)
+	require.Equal(t, int64(user.ID), result.Workspace.CreatedBy.Int64)
+	require.Equal(t, int64(result.Workspace.ID), result.Member.WorkspaceID)
+	require.Equal(t, int64(user.ID), result.Member.UserID)
+	require.Equal(t, WorkspaceRoleAdmin, result.Member.Role)
+
+	role, err := testStore.GetWorkspaceMemberRole(context.Background(), GetWorkspaceMemberRoleParams{
+		WorkspaceID: int64(result.Workspace.ID),
+		UserID:      int64(user.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, WorkspaceRoleAdmin, role)
+}
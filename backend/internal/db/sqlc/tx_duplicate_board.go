@@ -0,0 +1,69 @@
+package db
+
+import "context"
+
+type DuplicateBoardTxParams struct {
+	SourceBoardId int64
+	Name          string
+	SlugId        string
+	OwnerId       int64
+}
+
+type DuplicateBoardTxResult struct {
+	Board Board
+	Pages []BoardPage
+}
+
+// DuplicateBoardTx creates a copy of the board with its pages and elements within a transaction.
+// Only the current state is copied as the first snapshot of the copy, which has only the owner as a member.
+func (s *SQLStore) DuplicateBoardTx(ctx context.Context, params DuplicateBoardTxParams) (DuplicateBoardTxResult, error) {
+	var result DuplicateBoardTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		sourcePages, err := queries.GetBoardPageByBoardId(ctx, params.SourceBoardId)
+		if err != nil {
+			return err
+		}
+
+		result.Board, err = queries.CreateBoard(ctx, CreateBoardParams{
+			Name:    params.Name,
+			SlugID:  params.SlugId,
+			OwnerID: params.OwnerId,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = queries.AddToBoardUsers(ctx, AddToBoardUsersParams{
+			UserID:  params.OwnerId,
+			BoardID: int64(result.Board.ID),
+			Role:    BoardRoleEditor,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Pages = make([]BoardPage, len(sourcePages))
+		for i, sourcePage := range sourcePages {
+			result.Pages[i], err = queries.CreateBoardPage(ctx, CreateBoardPageParams{
+				Name:    sourcePage.Name,
+				BoardID: int64(result.Board.ID),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = queries.CopyBoardPageElements(ctx, CopyBoardPageElementsParams{
+				TargetPageID: int64(result.Pages[i].ID),
+				SourcePageID: int64(sourcePage.ID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return createInitialSnapshot(ctx, queries, &result.Board)
+	})
+
+	return result, err
+}
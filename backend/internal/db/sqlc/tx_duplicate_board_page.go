@@ -0,0 +1,77 @@
+package db
+
+import "context"
+
+type DuplicateBoardPageTxParams struct {
+	BoardId      int64
+	UserId       int64
+	SourcePageId int64
+	Name         string
+}
+
+type DuplicateBoardPageTxResult struct {
+	Page     BoardPage
+	Elements []BoardElement
+	// Operations are the creation of the page and of each copied element
+	Operations []BoardOperation
+}
+
+// DuplicateBoardPageTx creates a copy of the page with its elements within a transaction.
+// The copy is added after the last page of the board, and both the page and its elements are logged as operations.
+func (s *SQLStore) DuplicateBoardPageTx(ctx context.Context, params DuplicateBoardPageTxParams) (DuplicateBoardPageTxResult, error) {
+	var result DuplicateBoardPageTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		boardSlugId, err := queries.LockBoard(ctx, int32(params.BoardId))
+		if err != nil {
+			return err
+		}
+
+		sourcePage, err := queries.GetBoardPage(ctx, GetBoardPageParams{
+			ID:      int32(params.SourcePageId),
+			BoardID: params.BoardId,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Page, err = queries.CreateBoardPage(ctx, CreateBoardPageParams{
+			BoardID: params.BoardId,
+			Name:    params.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		operation, err := appendPageOperation(ctx, queries, boardSlugId, params.UserId, OperationPageCreate, result.Page)
+		if err != nil {
+			return err
+		}
+		result.Operations = append(result.Operations, operation)
+
+		_, err = queries.CopyBoardPageElements(ctx, CopyBoardPageElementsParams{
+			TargetPageID: int64(result.Page.ID),
+			SourcePageID: int64(sourcePage.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Elements, err = queries.GetBoardElementsByPageId(ctx, int64(result.Page.ID))
+		if err != nil {
+			return err
+		}
+
+		for _, element := range result.Elements {
+			operation, err := appendBoardOperation(ctx, queries, boardSlugId, params.UserId, OperationElementCreate, element)
+			if err != nil {
+				return err
+			}
+			result.Operations = append(result.Operations, operation)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestDuplicateBoardPageTx tests copying a page with its elements to the end of the board
+func TestDuplicateBoardPageTx(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+	element := createTestingBoardElement(t, page)
+	createTestingBoardPage(t, board)
+
+	result, err := testStore.DuplicateBoardPageTx(context.Background(), DuplicateBoardPageTxParams{
+		BoardId:      int64(board.ID),
+		UserId:       int64(user.ID),
+		SourcePageId: int64(page.ID),
+		Name:         "Copy of " + page.Name,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, page.ID, result.Page.ID)
+	require.Equal(t, int64(board.ID), result.Page.BoardID)
+	require.Equal(t, "Copy of "+page.Name, result.Page.Name)
+	require.Equal(t, int32(2), result.Page.Position)
+
+	elements, err := testStore.GetBoardElementsByPageId(context.Background(), int64(result.Page.ID))
+	require.NoError(t, err)
+	require.Len(t, elements, 1)
+	require.Equal(t, element.Type, elements[0].Type)
+	require.Equal(t, element.X, elements[0].X)
+	require.Equal(t, elements, result.Elements)
+
+	// the page and its elements are logged, so the history and the snapshots include the copy
+	require.Len(t, result.Operations, 2)
+	require.Equal(t, OperationPageCreate, result.Operations[0].Type)
+	require.Equal(t, int64(result.Page.ID), result.Operations[0].PageID)
+	require.Equal(t, OperationElementCreate, result.Operations[1].Type)
+	require.Equal(t, int64(elements[0].ID), result.Operations[1].ElementID)
+	require.Equal(t, result.Operations[0].Revision+1, result.Operations[1].Revision)
+
+	revision, err := testStore.GetBoardRevisionBySlugId(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, result.Operations[1].Revision, revision)
+
+	// pages of other boards cannot be copied
+	otherBoard := createTestingBoard(t, user)
+	_, err = testStore.DuplicateBoardPageTx(context.Background(), DuplicateBoardPageTxParams{
+		BoardId:      int64(otherBoard.ID),
+		UserId:       int64(user.ID),
+		SourcePageId: int64(page.ID),
+		Name:         page.Name,
+	})
+	require.Error(t, err)
+	require.True(t, IsErrNoRows(err))
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestDuplicateBoardTx tests copying a board with its pages and the elements which are not deleted
+func TestDuplicateBoardTx(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+
+	element := createTestingBoardElement(t, page)
+	deletedElement := createTestingBoardElement(t, page)
+	_, err := testStore.DeleteBoardElement(context.Background(), DeleteBoardElementParams{
+		ID:          deletedElement.ID,
+		BoardSlugID: board.SlugID,
+	})
+	require.NoError(t, err)
+
+	otherUser := createTestUser(t)
+	args := DuplicateBoardTxParams{
+		SourceBoardId: int64(board.ID),
+		Name:          gofakeit.LetterN(10),
+		SlugId:        gofakeit.LetterN(10),
+		OwnerId:       int64(otherUser.ID),
+	}
+	result, err := testStore.DuplicateBoardTx(context.Background(), args)
+	require.NoError(t, err)
+	require.NotEqual(t, board.ID, result.Board.ID)
+	require.Equal(t, args.Name, result.Board.Name)
+	require.Equal(t, args.SlugId, result.Board.SlugID)
+	require.Equal(t, args.OwnerId, result.Board.OwnerID)
+	require.Len(t, result.Pages, 1)
+	require.Equal(t, page.Name, result.Pages[0].Name)
+
+	elements, err := testStore.GetBoardElementsByPageId(context.Background(), int64(result.Pages[0].ID))
+	require.NoError(t, err)
+	require.Len(t, elements, 1)
+	require.NotEqual(t, element.ID, elements[0].ID)
+	require.Equal(t, element.X, elements[0].X)
+	require.Equal(t, element.Width, elements[0].Width)
+
+	require.Equal(t, int64(1), result.Board.Revision)
+	snapshots, err := testStore.GetLatestBoardSnapshots(context.Background(), args.SlugId)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Equal(t, int64(result.Pages[0].ID), snapshots[0].PageID)
+
+	users, err := testStore.GetBoardUsers(context.Background(), int64(result.Board.ID))
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+}
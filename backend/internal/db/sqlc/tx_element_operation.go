@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ElementOperationTxResult struct {
+	Element   BoardElement
+	Operation BoardOperation
+	// Previous is the element state before the operation. It is only set by UpdateElementTx
+	Previous BoardElement
+}
+
+type CreateElementTxParams struct {
+	BoardSlugId string
+	UserId      int64
+	Element     CreateBoardElementParams
+}
+
+// CreateElementTx creates an element in a page of the board and logs it as an operation within a transaction
+func (s *SQLStore) CreateElementTx(ctx context.Context, params CreateElementTxParams) (ElementOperationTxResult, error) {
+	var result ElementOperationTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		// page has to belong to the board
+		page, err := queries.GetBoardPageBySlugId(ctx, GetBoardPageBySlugIdParams{
+			ID:     int32(params.Element.PageID),
+			SlugID: params.BoardSlugId,
+		})
+		if err != nil {
+			return err
+		}
+
+		elementParams := params.Element
+		elementParams.PageID = int64(page.ID)
+
+		result.Element, err = queries.CreateBoardElement(ctx, elementParams)
+		if err != nil {
+			return err
+		}
+
+		result.Operation, err = appendBoardOperation(ctx, queries, params.BoardSlugId, params.UserId, OperationElementCreate, result.Element)
+		return err
+	})
+
+	return result, err
+}
+
+type UpdateElementTxParams struct {
+	UserId int64
+	// OperationType is either OperationElementUpdate or OperationElementReorder
+	OperationType string
+	Element       UpdateBoardElementParams
+}
+
+// UpdateElementTx updates an element of the board and logs it as an operation within a transaction
+func (s *SQLStore) UpdateElementTx(ctx context.Context, params UpdateElementTxParams) (ElementOperationTxResult, error) {
+	var result ElementOperationTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		var err error
+		// the update checks the version, so the previous state is the one that the update is applied on
+		result.Previous, err = queries.GetBoardElementBySlugId(ctx, GetBoardElementBySlugIdParams{
+			ID:     params.Element.ID,
+			SlugID: params.Element.BoardSlugID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Element, err = queries.UpdateBoardElement(ctx, params.Element)
+		if err != nil {
+			return err
+		}
+
+		result.Operation, err = appendBoardOperation(ctx, queries, params.Element.BoardSlugID, params.UserId, params.OperationType, result.Element)
+		return err
+	})
+
+	return result, err
+}
+
+type RestoreElementTxParams struct {
+	BoardSlugId string
+	UserId      int64
+	Element     RestoreBoardElementParams
+}
+
+// RestoreElementTx brings back a deleted element of the board with the given fields and logs it as a
+// create operation within a transaction
+func (s *SQLStore) RestoreElementTx(ctx context.Context, params RestoreElementTxParams) (ElementOperationTxResult, error) {
+	var result ElementOperationTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		// page has to belong to the board
+		page, err := queries.GetBoardPageBySlugId(ctx, GetBoardPageBySlugIdParams{
+			ID:     int32(params.Element.PageID),
+			SlugID: params.BoardSlugId,
+		})
+		if err != nil {
+			return err
+		}
+
+		elementParams := params.Element
+		elementParams.PageID = int64(page.ID)
+
+		result.Element, err = queries.RestoreBoardElement(ctx, elementParams)
+		if err != nil {
+			return err
+		}
+
+		result.Operation, err = appendBoardOperation(ctx, queries, params.BoardSlugId, params.UserId, OperationElementCreate, result.Element)
+		return err
+	})
+
+	return result, err
+}
+
+type DeleteElementTxParams struct {
+	UserId  int64
+	Element DeleteBoardElementParams
+}
+
+// DeleteElementTx soft deletes an element of the board and logs it as an operation within a transaction
+func (s *SQLStore) DeleteElementTx(ctx context.Context, params DeleteElementTxParams) (ElementOperationTxResult, error) {
+	var result ElementOperationTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		var err error
+		result.Element, err = queries.DeleteBoardElement(ctx, params.Element)
+		if err != nil {
+			return err
+		}
+
+		result.Operation, err = appendBoardOperation(ctx, queries, params.Element.BoardSlugID, params.UserId, OperationElementDelete, result.Element)
+		return err
+	})
+
+	return result, err
+}
+
+// appendBoardOperation increments the revision of the board and logs the operation with the element state.
+// Incrementing the revision locks the board row, so operations of the same board get consecutive revisions.
+func appendBoardOperation(ctx context.Context, queries *Queries, boardSlugId string, userId int64, operationType string, element BoardElement) (BoardOperation, error) {
+	board, err := queries.IncrementBoardRevision(ctx, boardSlugId)
+	if err != nil {
+		return BoardOperation{}, err
+	}
+
+	elementData, err := json.Marshal(element)
+	if err != nil {
+		return BoardOperation{}, err
+	}
+
+	return queries.CreateBoardOperation(ctx, CreateBoardOperationParams{
+		BoardID:   int64(board.ID),
+		Revision:  board.Revision,
+		UserID:    pgtype.Int8{Int64: userId, Valid: userId != 0},
+		Type:      operationType,
+		PageID:    element.PageID,
+		ElementID: int64(element.ID),
+		Data:      elementData,
+	})
+}
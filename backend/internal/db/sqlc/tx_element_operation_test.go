@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestElementOperationTx tests that element transactions log operations with consecutive revisions
+func TestElementOperationTx(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	otherBoard := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+
+	// page of another board cannot be used
+	_, err := testStore.CreateElementTx(context.Background(), CreateElementTxParams{
+		BoardSlugId: otherBoard.SlugID,
+		UserId:      int64(user.ID),
+		Element: CreateBoardElementParams{
+			PageID:   int64(page.ID),
+			Type:     "rectangle",
+			FontSize: 14,
+		},
+	})
+	require.True(t, IsErrNoRows(err))
+
+	created, err := testStore.CreateElementTx(context.Background(), CreateElementTxParams{
+		BoardSlugId: board.SlugID,
+		UserId:      int64(user.ID),
+		Element: CreateBoardElementParams{
+			PageID:   int64(page.ID),
+			Type:     "rectangle",
+			Width:    10,
+			Height:   10,
+			FontSize: 14,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), created.Operation.Revision)
+	require.Equal(t, OperationElementCreate, created.Operation.Type)
+	require.Equal(t, int64(created.Element.ID), created.Operation.ElementID)
+	require.Equal(t, int64(user.ID), created.Operation.UserID.Int64)
+
+	var loggedElement BoardElement
+	require.NoError(t, json.Unmarshal(created.Operation.Data, &loggedElement))
+	require.Equal(t, created.Element.ID, loggedElement.ID)
+
+	updated, err := testStore.UpdateElementTx(context.Background(), UpdateElementTxParams{
+		UserId:        int64(user.ID),
+		OperationType: OperationElementUpdate,
+		Element: UpdateBoardElementParams{
+			ID:          created.Element.ID,
+			Version:     created.Element.Version,
+			BoardSlugID: board.SlugID,
+			Width:       pgtype.Float8{Float64: 20, Valid: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.Operation.Revision)
+	require.Equal(t, float64(20), updated.Element.Width)
+	require.Equal(t, created.Element.Width, updated.Previous.Width)
+	require.Equal(t, created.Element.Version, updated.Previous.Version)
+
+	deleted, err := testStore.DeleteElementTx(context.Background(), DeleteElementTxParams{
+		UserId:  int64(user.ID),
+		Element: DeleteBoardElementParams{ID: created.Element.ID, BoardSlugID: board.SlugID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), deleted.Operation.Revision)
+
+	revision, err := testStore.GetBoardRevisionBySlugId(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), revision)
+
+	operations, err := testStore.GetBoardOperationsAfterRevision(context.Background(), GetBoardOperationsAfterRevisionParams{
+		SlugID:   board.SlugID,
+		Revision: 1,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, operations, 2)
+	require.Equal(t, OperationElementUpdate, operations[0].Type)
+	require.Equal(t, OperationElementDelete, operations[1].Type)
+
+	// failed transactions should not change the revision
+	_, err = testStore.DeleteElementTx(context.Background(), DeleteElementTxParams{
+		UserId:  int64(user.ID),
+		Element: DeleteBoardElementParams{ID: created.Element.ID, BoardSlugID: board.SlugID},
+	})
+	require.True(t, IsErrNoRows(err))
+
+	revision, err = testStore.GetBoardRevisionBySlugId(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), revision)
+}
+
+// TestRestoreElementTx tests bringing back a deleted element
+func TestRestoreElementTx(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	otherBoard := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+	element := createTestingBoardElement(t, page)
+
+	deleted, err := testStore.DeleteElementTx(context.Background(), DeleteElementTxParams{
+		UserId:  int64(user.ID),
+		Element: DeleteBoardElementParams{ID: element.ID, BoardSlugID: board.SlugID},
+	})
+	require.NoError(t, err)
+
+	params := RestoreElementTxParams{
+		BoardSlugId: board.SlugID,
+		UserId:      int64(user.ID),
+		Element: RestoreBoardElementParams{
+			ID:       element.ID,
+			PageID:   int64(page.ID),
+			X:        element.X,
+			Y:        element.Y,
+			Width:    element.Width,
+			Height:   element.Height,
+			FontSize: element.FontSize,
+		},
+	}
+
+	// element cannot be restored through another board
+	otherParams := params
+	otherParams.BoardSlugId = otherBoard.SlugID
+	_, err = testStore.RestoreElementTx(context.Background(), otherParams)
+	require.True(t, IsErrNoRows(err))
+
+	restored, err := testStore.RestoreElementTx(context.Background(), params)
+	require.NoError(t, err)
+	require.False(t, restored.Element.IsDeleted)
+	require.Equal(t, deleted.Element.Version+1, restored.Element.Version)
+	require.Equal(t, OperationElementCreate, restored.Operation.Type)
+	require.Equal(t, deleted.Operation.Revision+1, restored.Operation.Revision)
+
+	elements, err := testStore.GetBoardElementsByPageId(context.Background(), int64(page.ID))
+	require.NoError(t, err)
+	require.Len(t, elements, 1)
+}
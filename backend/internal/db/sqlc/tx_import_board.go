@@ -0,0 +1,81 @@
+package db
+
+import "context"
+
+type ImportBoardTxParams struct {
+	Name    string
+	SlugId  string
+	OwnerId int64
+	Pages   []ImportBoardPage
+}
+
+// ImportBoardPage is a page to be created with its elements, in the order of the pages
+type ImportBoardPage struct {
+	Name string
+	// Elements are created on the page, their PageID is set by ImportBoardTx
+	Elements []CreateBoardElementParams
+}
+
+type ImportBoardTxResult struct {
+	Board Board
+	Pages []BoardPage
+	// ElementCount is the number of the elements created in all the pages
+	ElementCount int
+}
+
+// ImportBoardTx creates a board with the given pages and elements within a transaction, so that a board
+// is never imported partially. The imported elements are saved as the first snapshot of the board.
+func (s *SQLStore) ImportBoardTx(ctx context.Context, params ImportBoardTxParams) (ImportBoardTxResult, error) {
+	var result ImportBoardTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		var err error
+
+		result.Board, err = queries.CreateBoard(ctx, CreateBoardParams{
+			Name:    params.Name,
+			SlugID:  params.SlugId,
+			OwnerID: params.OwnerId,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Pages = make([]BoardPage, len(params.Pages))
+		for i, importPage := range params.Pages {
+			result.Pages[i], err = queries.CreateBoardPage(ctx, CreateBoardPageParams{
+				Name:    importPage.Name,
+				BoardID: int64(result.Board.ID),
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, element := range importPage.Elements {
+				element.PageID = int64(result.Pages[i].ID)
+				_, err = queries.CreateBoardElement(ctx, element)
+				if err != nil {
+					return err
+				}
+				result.ElementCount++
+			}
+		}
+
+		err = createInitialSnapshot(ctx, queries, &result.Board)
+		if err != nil {
+			return err
+		}
+
+		_, err = queries.AddToBoardUsers(
+			ctx,
+			AddToBoardUsersParams{
+				UserID:  params.OwnerId,
+				BoardID: int64(result.Board.ID),
+				Role:    BoardRoleEditor,
+			},
+		)
+
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestImportBoardTx tests creating a board with its pages and elements using Store.ImportBoardTx
+func TestImportBoardTx(t *testing.T) {
+	user := createTestUser(t)
+
+	args := ImportBoardTxParams{
+		Name:    gofakeit.LetterN(10),
+		SlugId:  gofakeit.LetterN(10),
+		OwnerId: int64(user.ID),
+		Pages: []ImportBoardPage{
+			{
+				Name: "Ideas",
+				Elements: []CreateBoardElementParams{
+					{Type: "rectangle", X: 10, Y: 20, Width: 100, Height: 50, StrokeColor: 255, StrokeWidth: 2, FontSize: 14},
+					{Type: "text_box", X: 10, Y: 80, Width: 100, Height: 20, ZIndex: 1, StrokeColor: 255, Text: "hello", FontSize: 18},
+				},
+			},
+			{Name: "Empty"},
+		},
+	}
+
+	result, err := testStore.ImportBoardTx(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, args.Name, result.Board.Name)
+	require.Equal(t, args.OwnerId, result.Board.OwnerID)
+	require.Len(t, result.Pages, 2)
+	require.Equal(t, "Ideas", result.Pages[0].Name)
+	require.Less(t, result.Pages[0].Position, result.Pages[1].Position)
+	require.Equal(t, 2, result.ElementCount)
+
+	elements, err := testStore.GetBoardElementsByPageId(context.Background(), int64(result.Pages[0].ID))
+	require.NoError(t, err)
+	require.Len(t, elements, 2)
+	require.Equal(t, "hello", elements[1].Text)
+	require.Equal(t, int32(18), elements[1].FontSize)
+
+	// the clients load the imported elements from the first snapshot of the board
+	require.Equal(t, int64(1), result.Board.Revision)
+	snapshots, err := testStore.GetLatestBoardSnapshots(context.Background(), args.SlugId)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	require.Equal(t, int64(1), snapshots[0].Revision)
+
+	// the owner is a member of the board
+	board, err := testStore.GetBoardBySlugId(context.Background(), GetBoardBySlugIdParams{
+		OwnerID: int64(user.ID),
+		SlugID:  args.SlugId,
+	})
+	require.NoError(t, err)
+	require.True(t, board.IsOwner)
+
+	// nothing is created when a part of the board cannot be created
+	args.SlugId = gofakeit.LetterN(10)
+	args.Pages[1].Elements = []CreateBoardElementParams{{Type: "unknown"}}
+	_, err = testStore.ImportBoardTx(context.Background(), args)
+	require.Error(t, err)
+
+	_, err = testStore.GetBoardBySlugId(context.Background(), GetBoardBySlugIdParams{
+		OwnerID: int64(user.ID),
+		SlugID:  args.SlugId,
+	})
+	require.True(t, IsErrNoRows(err))
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"sort"
+)
+
+type PageOperationTxResult struct {
+	Page      BoardPage
+	Operation BoardOperation
+}
+
+type CreatePageTxParams struct {
+	BoardId int64
+	UserId  int64
+	Name    string
+}
+
+// CreatePageTx adds a new page after the last page of the board and logs it as an operation within a transaction
+func (s *SQLStore) CreatePageTx(ctx context.Context, params CreatePageTxParams) (PageOperationTxResult, error) {
+	var result PageOperationTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		boardSlugId, err := queries.LockBoard(ctx, int32(params.BoardId))
+		if err != nil {
+			return err
+		}
+
+		result.Page, err = queries.CreateBoardPage(ctx, CreateBoardPageParams{
+			BoardID: params.BoardId,
+			Name:    params.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Operation, err = appendPageOperation(ctx, queries, boardSlugId, params.UserId, OperationPageCreate, result.Page)
+		return err
+	})
+
+	return result, err
+}
+
+type RenamePageTxParams struct {
+	BoardId int64
+	UserId  int64
+	PageId  int64
+	Name    string
+}
+
+// RenamePageTx changes the name of the page of the board and logs it as an operation within a transaction
+func (s *SQLStore) RenamePageTx(ctx context.Context, params RenamePageTxParams) (PageOperationTxResult, error) {
+	var result PageOperationTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		boardSlugId, err := queries.LockBoard(ctx, int32(params.BoardId))
+		if err != nil {
+			return err
+		}
+
+		result.Page, err = queries.RenameBoardPage(ctx, RenameBoardPageParams{
+			ID:      int32(params.PageId),
+			BoardID: params.BoardId,
+			Name:    params.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Operation, err = appendPageOperation(ctx, queries, boardSlugId, params.UserId, OperationPageRename, result.Page)
+		return err
+	})
+
+	return result, err
+}
+
+type ReorderPagesTxParams struct {
+	BoardId int64
+	UserId  int64
+	PageIds []int32
+}
+
+type ReorderPagesTxResult struct {
+	// Pages are in their new order
+	Pages      []BoardPage
+	Operations []BoardOperation
+}
+
+// ReorderPagesTx puts the pages of the board in the given order and logs the new position of every page as an
+// operation within a transaction. pgx.ErrNoRows is returned if one of the pages is not a page of the board.
+func (s *SQLStore) ReorderPagesTx(ctx context.Context, params ReorderPagesTxParams) (ReorderPagesTxResult, error) {
+	var result ReorderPagesTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		boardSlugId, err := queries.LockBoard(ctx, int32(params.BoardId))
+		if err != nil {
+			return err
+		}
+
+		result.Pages, err = queries.ReorderBoardPages(ctx, ReorderBoardPagesParams{
+			PageIds: params.PageIds,
+			BoardID: params.BoardId,
+		})
+		if err != nil {
+			return err
+		}
+
+		// a page could be deleted after the order was checked
+		if len(result.Pages) != len(params.PageIds) {
+			return pgx.ErrNoRows
+		}
+
+		sort.Slice(result.Pages, func(i, j int) bool {
+			return result.Pages[i].Position < result.Pages[j].Position
+		})
+
+		result.Operations = make([]BoardOperation, len(result.Pages))
+		for i, page := range result.Pages {
+			result.Operations[i], err = appendPageOperation(ctx, queries, boardSlugId, params.UserId, OperationPageReorder, page)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+type DeletePageTxParams struct {
+	BoardId int64
+	UserId  int64
+	PageId  int64
+}
+
+// DeletePageTx soft deletes the page of the board and logs it as an operation within a transaction.
+// The board is locked before the page count is checked, so concurrent deletes cannot remove the last page.
+func (s *SQLStore) DeletePageTx(ctx context.Context, params DeletePageTxParams) (PageOperationTxResult, error) {
+	var result PageOperationTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		boardSlugId, err := queries.LockBoard(ctx, int32(params.BoardId))
+		if err != nil {
+			return err
+		}
+
+		result.Page, err = queries.DeleteBoardPage(ctx, DeleteBoardPageParams{
+			ID:      int32(params.PageId),
+			BoardID: params.BoardId,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Operation, err = appendPageOperation(ctx, queries, boardSlugId, params.UserId, OperationPageDelete, result.Page)
+		return err
+	})
+
+	return result, err
+}
+
+// appendPageOperation increments the revision of the board and logs the operation with the page state.
+// Page operations do not refer to an element, so their element id is 0.
+func appendPageOperation(ctx context.Context, queries *Queries, boardSlugId string, userId int64, operationType string, page BoardPage) (BoardOperation, error) {
+	board, err := queries.IncrementBoardRevision(ctx, boardSlugId)
+	if err != nil {
+		return BoardOperation{}, err
+	}
+
+	pageData, err := json.Marshal(page)
+	if err != nil {
+		return BoardOperation{}, err
+	}
+
+	return queries.CreateBoardOperation(ctx, CreateBoardOperationParams{
+		BoardID:   int64(board.ID),
+		Revision:  board.Revision,
+		UserID:    pgtype.Int8{Int64: userId, Valid: userId != 0},
+		Type:      operationType,
+		PageID:    int64(page.ID),
+		ElementID: 0,
+		Data:      pageData,
+	})
+}
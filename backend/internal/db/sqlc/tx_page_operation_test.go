@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestPageOperationTx tests that page transactions log operations with consecutive revisions
+func TestPageOperationTx(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+
+	created, err := testStore.CreatePageTx(context.Background(), CreatePageTxParams{
+		BoardId: int64(board.ID),
+		UserId:  int64(user.ID),
+		Name:    "Second",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), created.Page.Position)
+	require.Equal(t, int64(1), created.Operation.Revision)
+	require.Equal(t, OperationPageCreate, created.Operation.Type)
+	require.Equal(t, int64(created.Page.ID), created.Operation.PageID)
+	require.Zero(t, created.Operation.ElementID)
+
+	var loggedPage BoardPage
+	require.NoError(t, json.Unmarshal(created.Operation.Data, &loggedPage))
+	require.Equal(t, created.Page.Name, loggedPage.Name)
+
+	renamed, err := testStore.RenamePageTx(context.Background(), RenamePageTxParams{
+		BoardId: int64(board.ID),
+		UserId:  int64(user.ID),
+		PageId:  int64(created.Page.ID),
+		Name:    "Renamed",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Renamed", renamed.Page.Name)
+	require.Equal(t, int64(2), renamed.Operation.Revision)
+	require.Equal(t, OperationPageRename, renamed.Operation.Type)
+
+	reordered, err := testStore.ReorderPagesTx(context.Background(), ReorderPagesTxParams{
+		BoardId: int64(board.ID),
+		UserId:  int64(user.ID),
+		PageIds: []int32{created.Page.ID, page.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, reordered.Pages, 2)
+	require.Equal(t, created.Page.ID, reordered.Pages[0].ID)
+	require.Equal(t, page.ID, reordered.Pages[1].ID)
+	require.Len(t, reordered.Operations, 2)
+	require.Equal(t, int64(4), reordered.Operations[1].Revision)
+	require.Equal(t, OperationPageReorder, reordered.Operations[1].Type)
+
+	deleted, err := testStore.DeletePageTx(context.Background(), DeletePageTxParams{
+		BoardId: int64(board.ID),
+		UserId:  int64(user.ID),
+		PageId:  int64(page.ID),
+	})
+	require.NoError(t, err)
+	require.True(t, deleted.Page.IsDeleted)
+	require.Equal(t, int64(5), deleted.Operation.Revision)
+	require.Equal(t, OperationPageDelete, deleted.Operation.Type)
+
+	revision, err := testStore.GetBoardRevisionBySlugId(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), revision)
+
+	// the last page cannot be deleted and nothing is logged
+	_, err = testStore.DeletePageTx(context.Background(), DeletePageTxParams{
+		BoardId: int64(board.ID),
+		UserId:  int64(user.ID),
+		PageId:  int64(created.Page.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+
+	// the deleted page cannot be a part of the order
+	_, err = testStore.ReorderPagesTx(context.Background(), ReorderPagesTxParams{
+		BoardId: int64(board.ID),
+		UserId:  int64(user.ID),
+		PageIds: []int32{created.Page.ID, page.ID},
+	})
+	require.True(t, IsErrNoRows(err))
+
+	revision, err = testStore.GetBoardRevisionBySlugId(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), revision)
+}
+
+// TestDeletePageTxConcurrently tests that concurrent deletes cannot remove every page of the board
+func TestDeletePageTxConcurrently(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	pages := []*BoardPage{createTestingBoardPage(t, board), createTestingBoardPage(t, board)}
+
+	errs := make(chan error, len(pages))
+	for _, page := range pages {
+		go func(pageId int32) {
+			_, err := testStore.DeletePageTx(context.Background(), DeletePageTxParams{
+				BoardId: int64(board.ID),
+				UserId:  int64(user.ID),
+				PageId:  int64(pageId),
+			})
+			errs <- err
+		}(page.ID)
+	}
+
+	var deletedCount int
+	for range pages {
+		if err := <-errs; err == nil {
+			deletedCount++
+		} else {
+			require.True(t, IsErrNoRows(err))
+		}
+	}
+	require.Equal(t, 1, deletedCount)
+
+	remaining, err := testStore.GetBoardPageByBoardId(context.Background(), int64(board.ID))
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+}
@@ -6,12 +6,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/umtdemr/wb-backend/internal/token"
 	"testing"
+	"time"
 )
 
 // TestRegisterUserTx tests registering user with transaction
 func TestRegisterUserTx(t *testing.T) {
+	board := createTestingBoard(t, createTestUser(t))
+	email := gofakeit.Email()
+	createTestingBoardInvitation(t, board, email, time.Now().Add(time.Hour))
+
 	args := RegisterUserTxParams{
-		Email:        gofakeit.Email(),
+		Email:        email,
 		FullName:     gofakeit.Name(),
 		PasswordHash: []byte(gofakeit.AppAuthor()),
 		AuthProvider: "email",
@@ -31,4 +36,9 @@ func TestRegisterUserTx(t *testing.T) {
 	userWithQuery, err := testStore.GetUserByEmail(context.Background(), args.Email)
 	require.NoError(t, err)
 	require.Equal(t, userWithQuery.Email, result.User.Email)
+
+	// the invitations of the email are not accepted before the user activates their account
+	boardUsers, err := testStore.GetBoardUsers(context.Background(), int64(board.ID))
+	require.NoError(t, err)
+	require.Empty(t, boardUsers)
 }
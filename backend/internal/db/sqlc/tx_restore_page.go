@@ -0,0 +1,239 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// ErrRevisionNotAvailable is returned when the state of the page at the revision cannot be built, because the
+// revision is after the current one, the page did not exist at it, or its operations are compacted
+var ErrRevisionNotAvailable = errors.New("revision is not available")
+
+type RestorePageTxParams struct {
+	BoardSlugId string
+	PageId      int64
+	UserId      int64
+	// Revision is the revision of the board that the page is restored to
+	Revision int64
+}
+
+type RestorePageTxResult struct {
+	Operations []BoardOperation
+	// Revision is the revision of the board after the restore
+	Revision int64
+}
+
+// RestorePageTx brings the elements of the page to their state at the given revision within a transaction.
+// The board is locked before the state is built, so the changes made meanwhile cannot be lost. Elements that are
+// not in the state are deleted, and the others are written back with their fields. Every change is logged as an
+// operation, so that the restore can be replayed and undone like the other changes.
+func (s *SQLStore) RestorePageTx(ctx context.Context, params RestorePageTxParams) (RestorePageTxResult, error) {
+	var result RestorePageTxResult
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		board, err := queries.GetBoardForSnapshot(ctx, params.BoardSlugId)
+		if err != nil {
+			return err
+		}
+		result.Revision = board.Revision
+
+		if params.Revision < 0 || params.Revision > board.Revision {
+			return ErrRevisionNotAvailable
+		}
+
+		page, err := queries.GetBoardPageBySlugId(ctx, GetBoardPageBySlugIdParams{
+			ID:     int32(params.PageId),
+			SlugID: params.BoardSlugId,
+		})
+		if err != nil {
+			return err
+		}
+
+		elements, err := pageStateAtRevision(ctx, queries, params.BoardSlugId, page.BoardID, int64(page.ID), params.Revision)
+		if err != nil {
+			return err
+		}
+
+		currentElements, err := queries.GetBoardElementsByPageId(ctx, int64(page.ID))
+		if err != nil {
+			return err
+		}
+
+		restoredIds := make(map[int32]bool, len(elements))
+		for _, element := range elements {
+			restoredIds[element.ID] = true
+		}
+
+		current := make(map[int32]BoardElement, len(currentElements))
+		for _, element := range currentElements {
+			current[element.ID] = element
+
+			if restoredIds[element.ID] {
+				continue
+			}
+
+			deleted, err := queries.DeleteBoardElement(ctx, DeleteBoardElementParams{
+				ID:          element.ID,
+				BoardSlugID: params.BoardSlugId,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := result.appendOperation(ctx, queries, params, OperationElementDelete, deleted); err != nil {
+				return err
+			}
+		}
+
+		for _, element := range elements {
+			currentElement, exists := current[element.ID]
+			if exists && hasSameState(&currentElement, &element) {
+				continue
+			}
+
+			restored, err := queries.RestoreBoardElement(ctx, RestoreBoardElementParams{
+				ID:           element.ID,
+				PageID:       int64(page.ID),
+				X:            element.X,
+				Y:            element.Y,
+				Width:        element.Width,
+				Height:       element.Height,
+				ZIndex:       element.ZIndex,
+				StrokeColor:  element.StrokeColor,
+				FillColor:    element.FillColor,
+				StrokeWidth:  element.StrokeWidth,
+				BorderRadius: element.BorderRadius,
+				Text:         element.Text,
+				FontSize:     element.FontSize,
+			})
+			if err != nil {
+				// element does not exist in this page anymore, it cannot be restored
+				if IsErrNoRows(err) {
+					continue
+				}
+				return err
+			}
+
+			operationType := OperationElementUpdate
+			if !exists {
+				operationType = OperationElementCreate
+			}
+
+			if err := result.appendOperation(ctx, queries, params, operationType, restored); err != nil {
+				return err
+			}
+		}
+
+		if len(result.Operations) > 0 {
+			result.Revision = result.Operations[len(result.Operations)-1].Revision
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// pageStateAtRevision builds the elements of the page at the revision from the latest snapshot of the page before
+// the revision and the operations after it. Pages that are not in any snapshot start empty at their creation.
+func pageStateAtRevision(ctx context.Context, queries *Queries, boardSlugId string, boardId int64, pageId int64, revision int64) ([]BoardElement, error) {
+	elements := make(map[int32]BoardElement)
+	var startRevision int64
+
+	snapshot, err := queries.GetPageSnapshotAtRevision(ctx, GetPageSnapshotAtRevisionParams{
+		PageID:   pageId,
+		Revision: revision,
+	})
+	switch {
+	case err == nil:
+		var snapshotElements []BoardElement
+		if err := json.Unmarshal(snapshot.Data, &snapshotElements); err != nil {
+			return nil, err
+		}
+
+		for _, element := range snapshotElements {
+			elements[element.ID] = element
+		}
+		startRevision = snapshot.Revision
+	case IsErrNoRows(err):
+		createRevision, err := queries.GetPageCreateRevision(ctx, GetPageCreateRevisionParams{
+			BoardID: boardId,
+			PageID:  pageId,
+		})
+		switch {
+		case err == nil:
+			if createRevision > revision {
+				return nil, ErrRevisionNotAvailable
+			}
+			startRevision = createRevision
+		case !IsErrNoRows(err):
+			return nil, err
+		}
+		// otherwise the page is created with the board, which has no snapshots, so its state starts from the
+		// first operation of the board
+	default:
+		return nil, err
+	}
+
+	operations, err := queries.GetBoardOperationsBetweenRevisions(ctx, GetBoardOperationsBetweenRevisionsParams{
+		SlugID:        boardSlugId,
+		Revision:      startRevision,
+		UntilRevision: revision,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// some of the operations are compacted, the state at the revision cannot be built
+	if int64(len(operations)) != revision-startRevision {
+		return nil, ErrRevisionNotAvailable
+	}
+
+	for _, operation := range operations {
+		// page operations do not refer to an element and do not change the elements of the page
+		if operation.PageID != pageId || operation.ElementID == 0 {
+			continue
+		}
+
+		var element BoardElement
+		if err := json.Unmarshal(operation.Data, &element); err != nil {
+			return nil, err
+		}
+
+		switch operation.Type {
+		case OperationElementDelete:
+			delete(elements, element.ID)
+		default:
+			elements[element.ID] = element
+		}
+	}
+
+	state := make([]BoardElement, 0, len(elements))
+	for _, element := range elements {
+		state = append(state, element)
+	}
+	sort.Slice(state, func(i, j int) bool {
+		return state[i].ID < state[j].ID
+	})
+
+	return state, nil
+}
+
+func (r *RestorePageTxResult) appendOperation(ctx context.Context, queries *Queries, params RestorePageTxParams, operationType string, element BoardElement) error {
+	operation, err := appendBoardOperation(ctx, queries, params.BoardSlugId, params.UserId, operationType, element)
+	if err != nil {
+		return err
+	}
+
+	r.Operations = append(r.Operations, operation)
+	return nil
+}
+
+// hasSameState checks if the drawn fields of the elements are the same
+func hasSameState(a *BoardElement, b *BoardElement) bool {
+	return a.X == b.X && a.Y == b.Y && a.Width == b.Width && a.Height == b.Height && a.ZIndex == b.ZIndex &&
+		a.StrokeColor == b.StrokeColor && a.FillColor == b.FillColor && a.StrokeWidth == b.StrokeWidth &&
+		a.BorderRadius == b.BorderRadius && a.Text == b.Text && a.FontSize == b.FontSize
+}
@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestRestorePageTx tests bringing the elements of a page back to a previous revision
+func TestRestorePageTx(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+
+	created, err := testStore.CreateElementTx(context.Background(), CreateElementTxParams{
+		BoardSlugId: board.SlugID,
+		UserId:      int64(user.ID),
+		Element: CreateBoardElementParams{
+			PageID:   int64(page.ID),
+			Type:     "rectangle",
+			FontSize: 14,
+		},
+	})
+	require.NoError(t, err)
+	revision := created.Operation.Revision
+
+	// change the element and add another one after the revision
+	_, err = testStore.UpdateElementTx(context.Background(), UpdateElementTxParams{
+		UserId:        int64(user.ID),
+		OperationType: OperationElementUpdate,
+		Element: UpdateBoardElementParams{
+			ID:          created.Element.ID,
+			Version:     created.Element.Version,
+			BoardSlugID: board.SlugID,
+			X:           pgtype.Float8{Float64: 42, Valid: true},
+		},
+	})
+	require.NoError(t, err)
+
+	other, err := testStore.CreateElementTx(context.Background(), CreateElementTxParams{
+		BoardSlugId: board.SlugID,
+		UserId:      int64(user.ID),
+		Element: CreateBoardElementParams{
+			PageID:   int64(page.ID),
+			Type:     "rectangle",
+			FontSize: 14,
+		},
+	})
+	require.NoError(t, err)
+
+	// revisions after the current one cannot be restored
+	_, err = testStore.RestorePageTx(context.Background(), RestorePageTxParams{
+		BoardSlugId: board.SlugID,
+		PageId:      int64(page.ID),
+		UserId:      int64(user.ID),
+		Revision:    other.Operation.Revision + 1,
+	})
+	require.ErrorIs(t, err, ErrRevisionNotAvailable)
+
+	result, err := testStore.RestorePageTx(context.Background(), RestorePageTxParams{
+		BoardSlugId: board.SlugID,
+		PageId:      int64(page.ID),
+		UserId:      int64(user.ID),
+		Revision:    revision,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Operations, 2)
+	require.Equal(t, OperationElementDelete, result.Operations[0].Type)
+	require.Equal(t, int64(other.Element.ID), result.Operations[0].ElementID)
+	require.Equal(t, OperationElementUpdate, result.Operations[1].Type)
+	require.Equal(t, int64(created.Element.ID), result.Operations[1].ElementID)
+	require.Equal(t, result.Operations[1].Revision, result.Revision)
+
+	elements, err := testStore.GetBoardElementsByPageId(context.Background(), int64(page.ID))
+	require.NoError(t, err)
+	require.Len(t, elements, 1)
+	require.Equal(t, created.Element.X, elements[0].X)
+
+	// restoring the same revision again changes nothing
+	currentRevision := result.Revision
+	result, err = testStore.RestorePageTx(context.Background(), RestorePageTxParams{
+		BoardSlugId: board.SlugID,
+		PageId:      int64(page.ID),
+		UserId:      int64(user.ID),
+		Revision:    revision,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Operations)
+	require.Equal(t, currentRevision, result.Revision)
+}
+
+// TestRestorePageTxAfterCompaction tests restoring a page that is created after the latest snapshot of the board
+func TestRestorePageTxAfterCompaction(t *testing.T) {
+	user := createTestUser(t)
+	board := createTestingBoard(t, user)
+	page := createTestingBoardPage(t, board)
+
+	createElement := func(pageId int32) ElementOperationTxResult {
+		result, err := testStore.CreateElementTx(context.Background(), CreateElementTxParams{
+			BoardSlugId: board.SlugID,
+			UserId:      int64(user.ID),
+			Element: CreateBoardElementParams{
+				PageID:   int64(pageId),
+				Type:     "rectangle",
+				FontSize: 14,
+			},
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	// the second snapshot compacts the operations up to the first one
+	createElement(page.ID)
+	_, err := testStore.CreateSnapshotTx(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	createElement(page.ID)
+	snapshot, err := testStore.CreateSnapshotTx(context.Background(), board.SlugID)
+	require.NoError(t, err)
+	require.NotZero(t, snapshot.CompactedOperations)
+
+	newPage, err := testStore.CreatePageTx(context.Background(), CreatePageTxParams{
+		BoardId: int64(board.ID),
+		UserId:  int64(user.ID),
+		Name:    "New",
+	})
+	require.NoError(t, err)
+
+	created := createElement(newPage.Page.ID)
+	createElement(newPage.Page.ID)
+
+	// the page did not exist at the snapshot
+	_, err = testStore.RestorePageTx(context.Background(), RestorePageTxParams{
+		BoardSlugId: board.SlugID,
+		PageId:      int64(newPage.Page.ID),
+		UserId:      int64(user.ID),
+		Revision:    snapshot.Revision,
+	})
+	require.ErrorIs(t, err, ErrRevisionNotAvailable)
+
+	result, err := testStore.RestorePageTx(context.Background(), RestorePageTxParams{
+		BoardSlugId: board.SlugID,
+		PageId:      int64(newPage.Page.ID),
+		UserId:      int64(user.ID),
+		Revision:    created.Operation.Revision,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Operations, 1)
+	require.Equal(t, OperationElementDelete, result.Operations[0].Type)
+
+	elements, err := testStore.GetBoardElementsByPageId(context.Background(), int64(newPage.Page.ID))
+	require.NoError(t, err)
+	require.Len(t, elements, 1)
+	require.Equal(t, created.Element.ID, elements[0].ID)
+}
@@ -0,0 +1,34 @@
+package db
+
+import "context"
+
+type TransferBoardOwnershipTxParams struct {
+	BoardId    int64
+	NewOwnerId int64
+}
+
+// TransferBoardOwnershipTx makes a member of the board its owner within a transaction.
+// The previous owner stays in the board as an editor.
+func (s *SQLStore) TransferBoardOwnershipTx(ctx context.Context, params TransferBoardOwnershipTxParams) (Board, error) {
+	var board Board
+
+	err := s.execTx(ctx, func(queries *Queries) error {
+		// new owner has to be a member of the board, owners are editors of their boards
+		_, err := queries.UpdateBoardUserRole(ctx, UpdateBoardUserRoleParams{
+			BoardID: params.BoardId,
+			UserID:  params.NewOwnerId,
+			Role:    BoardRoleEditor,
+		})
+		if err != nil {
+			return err
+		}
+
+		board, err = queries.UpdateBoardOwner(ctx, UpdateBoardOwnerParams{
+			ID:      int32(params.BoardId),
+			OwnerID: params.NewOwnerId,
+		})
+		return err
+	})
+
+	return board, err
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestTransferBoardOwnershipTx tests making a member the owner of the board
+func TestTransferBoardOwnershipTx(t *testing.T) {
+	owner := createTestUser(t)
+	member := createTestUser(t)
+	outsider := createTestUser(t)
+	board := createTestingBoard(t, owner)
+
+	_, err := testStore.AddToBoardUsers(context.Background(), AddToBoardUsersParams{
+		BoardID: int64(board.ID),
+		UserID:  int64(owner.ID),
+		Role:    BoardRoleEditor,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.AddToBoardUsers(context.Background(), AddToBoardUsersParams{
+		BoardID: int64(board.ID),
+		UserID:  int64(member.ID),
+		Role:    BoardRoleViewer,
+	})
+	require.NoError(t, err)
+
+	// only members can be the owner
+	_, err = testStore.TransferBoardOwnershipTx(context.Background(), TransferBoardOwnershipTxParams{
+		BoardId:    int64(board.ID),
+		NewOwnerId: int64(outsider.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+
+	updated, err := testStore.TransferBoardOwnershipTx(context.Background(), TransferBoardOwnershipTxParams{
+		BoardId:    int64(board.ID),
+		NewOwnerId: int64(member.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(member.ID), updated.OwnerID)
+
+	memberBoard, err := testStore.GetBoardBySlugId(context.Background(), GetBoardBySlugIdParams{
+		OwnerID: int64(member.ID),
+		SlugID:  board.SlugID,
+	})
+	require.NoError(t, err)
+	require.True(t, memberBoard.IsOwner)
+	require.Equal(t, BoardRoleEditor, memberBoard.Role)
+
+	// previous owner can be removed now
+	rowsAffected, err := testStore.DeleteBoardUser(context.Background(), DeleteBoardUserParams{
+		BoardID: int64(board.ID),
+		UserID:  int64(owner.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsAffected)
+}
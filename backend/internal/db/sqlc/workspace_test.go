@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestWorkspaceMembers tests managing the members of a workspace and protecting its last admin
+func TestWorkspaceMembers(t *testing.T) {
+	admin := createTestUser(t)
+	member := createTestUser(t)
+	workspace := createTestingWorkspace(t, admin)
+	workspaceId := int64(workspace.ID)
+
+	added, err := testStore.AddWorkspaceMember(context.Background(), AddWorkspaceMemberParams{
+		WorkspaceID: workspaceId,
+		UserID:      int64(member.ID),
+		Role:        WorkspaceRoleViewer,
+	})
+	require.NoError(t, err)
+	require.Equal(t, WorkspaceRoleViewer, added.Role)
+
+	_, err = testStore.AddWorkspaceMember(context.Background(), AddWorkspaceMemberParams{
+		WorkspaceID: workspaceId,
+		UserID:      int64(member.ID),
+		Role:        WorkspaceRoleEditor,
+	})
+	require.True(t, IsErrUniqueViolation(err))
+
+	members, err := testStore.GetWorkspaceMembers(context.Background(), workspaceId)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	require.Equal(t, admin.ID, members[0].ID)
+	require.Equal(t, WorkspaceRoleAdmin, members[0].Role)
+
+	workspaces, err := testStore.GetWorkspacesForUser(context.Background(), int64(member.ID))
+	require.NoError(t, err)
+	require.Len(t, workspaces, 1)
+	require.Equal(t, WorkspaceRoleViewer, workspaces[0].Role)
+
+	// the only admin can neither be demoted nor removed
+	rowsAffected, err := testStore.UpdateWorkspaceMemberRole(context.Background(), UpdateWorkspaceMemberRoleParams{
+		Role:        WorkspaceRoleEditor,
+		WorkspaceID: workspaceId,
+		UserID:      int64(admin.ID),
+	})
+	require.NoError(t, err)
+	require.Zero(t, rowsAffected)
+
+	rowsAffected, err = testStore.DeleteWorkspaceMember(context.Background(), DeleteWorkspaceMemberParams{
+		WorkspaceID: workspaceId,
+		UserID:      int64(admin.ID),
+	})
+	require.NoError(t, err)
+	require.Zero(t, rowsAffected)
+
+	// once there is another admin, it is possible
+	rowsAffected, err = testStore.UpdateWorkspaceMemberRole(context.Background(), UpdateWorkspaceMemberRoleParams{
+		Role:        WorkspaceRoleAdmin,
+		WorkspaceID: workspaceId,
+		UserID:      int64(member.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsAffected)
+
+	rowsAffected, err = testStore.DeleteWorkspaceMember(context.Background(), DeleteWorkspaceMemberParams{
+		WorkspaceID: workspaceId,
+		UserID:      int64(admin.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsAffected)
+
+	_, err = testStore.GetWorkspaceMemberRole(context.Background(), GetWorkspaceMemberRoleParams{
+		WorkspaceID: workspaceId,
+		UserID:      int64(admin.ID),
+	})
+	require.True(t, IsErrNoRows(err))
+
+	renamed, err := testStore.RenameWorkspace(context.Background(), RenameWorkspaceParams{
+		ID:   workspace.ID,
+		Name: "renamed",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "renamed", renamed.Name)
+
+	rowsAffected, err = testStore.DeleteWorkspace(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rowsAffected)
+
+	members, err = testStore.GetWorkspaceMembers(context.Background(), workspaceId)
+	require.NoError(t, err)
+	require.Empty(t, members)
+}
+
+// TestWorkspaceBoards tests that the workspace members inherit the access to the boards of the workspace
+func TestWorkspaceBoards(t *testing.T) {
+	owner := createTestUser(t)
+	editor := createTestUser(t)
+	viewer := createTestUser(t)
+	workspace := createTestingWorkspace(t, owner)
+	workspaceId := pgtype.Int8{Int64: int64(workspace.ID), Valid: true}
+
+	for user, role := range map[*User]string{editor: WorkspaceRoleEditor, viewer: WorkspaceRoleViewer} {
+		_, err := testStore.AddWorkspaceMember(context.Background(), AddWorkspaceMemberParams{
+			WorkspaceID: int64(workspace.ID),
+			UserID:      int64(user.ID),
+			Role:        role,
+		})
+		require.NoError(t, err)
+	}
+
+	board := createTestingBoard(t, owner)
+	_, err := testStore.AddToBoardUsers(context.Background(), AddToBoardUsersParams{
+		BoardID: int64(board.ID),
+		UserID:  int64(owner.ID),
+		Role:    BoardRoleEditor,
+	})
+	require.NoError(t, err)
+
+	// the board is not in the workspace yet
+	_, err = testStore.GetBoardBySlugId(context.Background(), GetBoardBySlugIdParams{
+		OwnerID: int64(viewer.ID),
+		SlugID:  board.SlugID,
+	})
+	require.True(t, IsErrNoRows(err))
+
+	moved, err := testStore.UpdateBoardWorkspace(context.Background(), UpdateBoardWorkspaceParams{
+		WorkspaceID: workspaceId,
+		ID:          board.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, workspaceId, moved.WorkspaceID)
+
+	boards, err := testStore.GetWorkspaceBoards(context.Background(), workspaceId)
+	require.NoError(t, err)
+	require.Len(t, boards, 1)
+	require.Equal(t, board.ID, boards[0].ID)
+
+	row, err := testStore.GetBoardBySlugId(context.Background(), GetBoardBySlugIdParams{
+		OwnerID: int64(viewer.ID),
+		SlugID:  board.SlugID,
+	})
+	require.NoError(t, err)
+	require.False(t, row.IsOwner)
+	require.Equal(t, BoardRoleViewer, row.Role)
+	require.Equal(t, workspaceId, row.WorkspaceID)
+
+	row, err = testStore.GetBoardBySlugId(context.Background(), GetBoardBySlugIdParams{
+		OwnerID: int64(editor.ID),
+		SlugID:  board.SlugID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, BoardRoleEditor, row.Role)
+
+	// workspace templates stop being templates when the board leaves the workspace
+	_, err = testStore.UpdateBoardTemplateScope(context.Background(), UpdateBoardTemplateScopeParams{
+		TemplateScope: pgtype.Text{String: "workspace", Valid: true},
+		ID:            board.ID,
+	})
+	require.NoError(t, err)
+
+	moved, err = testStore.UpdateBoardWorkspace(context.Background(), UpdateBoardWorkspaceParams{
+		ID: board.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, moved.WorkspaceID.Valid)
+	require.False(t, moved.TemplateScope.Valid)
+
+	_, err = testStore.GetBoardBySlugId(context.Background(), GetBoardBySlugIdParams{
+		OwnerID: int64(editor.ID),
+		SlugID:  board.SlugID,
+	})
+	require.True(t, IsErrNoRows(err))
+}
@@ -0,0 +1,217 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/umtdemr/wb-backend/internal/data"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ExcalidrawFileType is the type of the files saved by Excalidraw
+const ExcalidrawFileType = "excalidraw"
+
+const (
+	// maxStrokeWidth, maxBorderRadius, maxTextLength and the font size limits are the limits of data.ValidateElement
+	maxStrokeWidth  = 20.0
+	maxBorderRadius = 20.0
+	maxTextLength   = 2000
+	minFontSize     = 1
+	maxFontSize     = 200
+	// excalidrawRadius is the radius that Excalidraw gives to the rounded corners of the large shapes
+	excalidrawRadius = 32.0
+)
+
+// excalidrawFile is the part of an Excalidraw file that is imported
+type excalidrawFile struct {
+	Type     string              `json:"type"`
+	Version  int                 `json:"version"`
+	Elements []excalidrawElement `json:"elements"`
+}
+
+// excalidrawElement is the part of an Excalidraw element that can be drawn with the elements of the boards
+type excalidrawElement struct {
+	Type            string          `json:"type"`
+	X               float64         `json:"x"`
+	Y               float64         `json:"y"`
+	Width           float64         `json:"width"`
+	Height          float64         `json:"height"`
+	Angle           float64         `json:"angle"`
+	StrokeColor     string          `json:"strokeColor"`
+	BackgroundColor string          `json:"backgroundColor"`
+	StrokeWidth     float64         `json:"strokeWidth"`
+	Opacity         *float64        `json:"opacity"`
+	Roundness       json.RawMessage `json:"roundness"`
+	IsDeleted       bool            `json:"isDeleted"`
+	Text            string          `json:"text"`
+	FontSize        float64         `json:"fontSize"`
+}
+
+// Excalidraw converts an Excalidraw file into a board file with a single page. Rectangles, ellipses, diamonds and
+// texts are converted into the elements of the boards, diamonds are drawn as triangles since the boards have no
+// diamonds. The elements that cannot be converted are skipped and the changes made to the drawing are returned as
+// warnings, such as the skipped arrows or the rotations that are dropped.
+func Excalidraw(body []byte, boardName string) (*Result, error) {
+	var file excalidrawFile
+	err := json.Unmarshal(body, &file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid excalidraw file: %w", err)
+	}
+
+	if file.Type != ExcalidrawFileType {
+		return nil, fmt.Errorf("invalid excalidraw file: type must be %s", ExcalidrawFileType)
+	}
+
+	warnings := newWarnings()
+	elements := make([]data.BoardFileElement, 0, len(file.Elements))
+
+	// Excalidraw draws the elements in the order of the file
+	for _, source := range file.Elements {
+		if source.IsDeleted {
+			continue
+		}
+
+		element, ok := convertExcalidrawElement(&source, warnings)
+		if !ok {
+			continue
+		}
+
+		element.ZIndex = int32(len(elements))
+		elements = append(elements, element)
+	}
+
+	return &Result{
+		File: &data.BoardFile{
+			Type:    data.BoardFileType,
+			Version: data.BoardFileVersion,
+			Board:   data.BoardFileBoard{Name: boardName},
+			Pages: []data.BoardFilePage{
+				{Name: db.DefaultBoardPageName, Elements: elements},
+			},
+		},
+		Warnings: warnings.list(),
+	}, nil
+}
+
+// convertExcalidrawElement converts the element into an element of the boards, it returns false if the element
+// cannot be drawn on the boards
+func convertExcalidrawElement(source *excalidrawElement, warnings *warnings) (data.BoardFileElement, bool) {
+	element := data.BoardFileElement{
+		X:           source.X,
+		Y:           source.Y,
+		Width:       math.Abs(source.Width),
+		Height:      math.Abs(source.Height),
+		StrokeWidth: math.Min(math.Max(source.StrokeWidth, 0), maxStrokeWidth),
+		FontSize:    14,
+	}
+
+	// the shapes that are drawn from right to left or from bottom to top have negative sizes
+	if source.Width < 0 {
+		element.X += source.Width
+	}
+	if source.Height < 0 {
+		element.Y += source.Height
+	}
+
+	switch source.Type {
+	case "rectangle":
+		element.Type = data.ElementTypeRectangle
+		if hasRoundness(source.Roundness) {
+			element.BorderRadius = math.Min(math.Min(excalidrawRadius, 0.25*math.Min(element.Width, element.Height)), maxBorderRadius)
+		}
+	case "ellipse":
+		element.Type = data.ElementTypeEllipse
+	case "diamond":
+		element.Type = data.ElementTypeTriangle
+		warnings.add("diamond elements are imported as triangles")
+	case "text":
+		element.Type = data.ElementTypeTextBox
+		element.Text = source.Text
+		element.FontSize = int32(math.Min(math.Max(math.Round(source.FontSize), minFontSize), maxFontSize))
+		if len(element.Text) > maxTextLength {
+			element.Text = truncateText(element.Text, maxTextLength)
+			warnings.add(fmt.Sprintf("texts longer than %d bytes are shortened", maxTextLength))
+		}
+	default:
+		warnings.add(fmt.Sprintf("%s elements are not supported and skipped", source.Type))
+		return element, false
+	}
+
+	if source.Angle != 0 {
+		warnings.add("rotations of the elements are dropped")
+	}
+
+	opacity := 100.0
+	if source.Opacity != nil {
+		opacity = math.Min(math.Max(*source.Opacity, 0), 100)
+	}
+
+	strokeColor, ok := parseExcalidrawColor(source.StrokeColor, opacity)
+	if !ok {
+		strokeColor = data.ColorBlack
+		warnings.add(fmt.Sprintf("unknown colors are imported as black, such as %q", source.StrokeColor))
+	}
+	element.StrokeColor = strokeColor
+
+	// texts are drawn with the stroke color and have no background in the boards
+	if element.Type != data.ElementTypeTextBox {
+		fillColor, ok := parseExcalidrawColor(source.BackgroundColor, opacity)
+		if !ok {
+			fillColor = data.ColorTransparent
+			warnings.add(fmt.Sprintf("unknown background colors are imported as transparent, such as %q", source.BackgroundColor))
+		}
+		element.FillColor = fillColor
+	}
+
+	return element, true
+}
+
+// hasRoundness checks if the roundness of an Excalidraw element is set, sharp elements have a null roundness
+func hasRoundness(roundness json.RawMessage) bool {
+	value := strings.TrimSpace(string(roundness))
+	return value != "" && value != "null"
+}
+
+// parseExcalidrawColor converts an Excalidraw color into a packed 0xRRGGBBAA color. Excalidraw stores the colors
+// as #RRGGBB or #RGB, and the opacity of the element separately as a percentage.
+func parseExcalidrawColor(color string, opacity float64) (int64, bool) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if color == "" || color == "transparent" {
+		return data.ColorTransparent, true
+	}
+
+	hex, ok := strings.CutPrefix(color, "#")
+	if !ok {
+		return 0, false
+	}
+
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	case 6:
+	default:
+		return 0, false
+	}
+
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	alpha := int64(math.Round(opacity / 100 * 255))
+
+	return int64(rgb)<<8 | alpha, true
+}
+
+// truncateText shortens the text to the given number of bytes without splitting a character
+func truncateText(text string, maxBytes int) string {
+	for len(text) > maxBytes {
+		_, size := utf8.DecodeLastRuneInString(text)
+		text = text[:len(text)-size]
+	}
+
+	return text
+}
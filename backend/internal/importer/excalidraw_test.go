@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"strings"
+	"testing"
+)
+
+const excalidrawFixture = `{
+  "type": "excalidraw",
+  "version": 2,
+  "source": "https://excalidraw.com",
+  "elements": [
+    {"id": "r1", "type": "rectangle", "x": 100, "y": 50, "width": 200, "height": 100, "angle": 0,
+     "strokeColor": "#1e1e1e", "backgroundColor": "#ffc9c9", "fillStyle": "solid", "strokeWidth": 2,
+     "roughness": 1, "opacity": 100, "roundness": {"type": 3}, "isDeleted": false, "groupIds": []},
+    {"id": "e1", "type": "ellipse", "x": 400, "y": 200, "width": -80, "height": 60, "angle": 0,
+     "strokeColor": "#f00", "backgroundColor": "transparent", "strokeWidth": 4, "opacity": 50, "roundness": null},
+    {"id": "d1", "type": "diamond", "x": 0, "y": 0, "width": 40, "height": 40, "angle": 0.5,
+     "strokeColor": "#1971c2", "backgroundColor": "transparent", "strokeWidth": 1, "opacity": 100},
+    {"id": "t1", "type": "text", "x": 120, "y": 80, "width": 60, "height": 25, "angle": 0,
+     "strokeColor": "#1e1e1e", "backgroundColor": "transparent", "strokeWidth": 2, "opacity": 100,
+     "text": "Hello", "fontSize": 20.4, "fontFamily": 1, "containerId": "r1"},
+    {"id": "a1", "type": "arrow", "x": 0, "y": 0, "width": 100, "height": 0, "points": [[0, 0], [100, 0]]},
+    {"id": "a2", "type": "arrow", "x": 0, "y": 0, "width": 100, "height": 0, "points": [[0, 0], [100, 0]]},
+    {"id": "f1", "type": "freedraw", "x": 0, "y": 0, "width": 10, "height": 10},
+    {"id": "x1", "type": "rectangle", "x": 0, "y": 0, "width": 10, "height": 10, "isDeleted": true}
+  ],
+  "appState": {"gridSize": null, "viewBackgroundColor": "#ffffff"},
+  "files": {}
+}`
+
+// TestExcalidraw tests converting the elements of an Excalidraw file and reporting what is left out
+func TestExcalidraw(t *testing.T) {
+	require.Equal(t, ExcalidrawFileType, DetectType([]byte(excalidrawFixture)))
+
+	result, err := Excalidraw([]byte(excalidrawFixture), data.DefaultBoardName)
+	require.NoError(t, err)
+
+	file := result.File
+	require.Equal(t, data.BoardFileType, file.Type)
+	require.Equal(t, data.DefaultBoardName, file.Board.Name)
+	require.Len(t, file.Pages, 1)
+
+	elements := file.Pages[0].Elements
+	require.Len(t, elements, 4)
+
+	require.Equal(t, data.BoardFileElement{
+		Type: data.ElementTypeRectangle, X: 100, Y: 50, Width: 200, Height: 100, ZIndex: 0,
+		StrokeColor: 0x1E1E1EFF, FillColor: 0xFFC9C9FF, StrokeWidth: 2, BorderRadius: 20, FontSize: 14,
+	}, elements[0])
+
+	// the ellipse is drawn from right to left and half transparent
+	require.Equal(t, data.BoardFileElement{
+		Type: data.ElementTypeEllipse, X: 320, Y: 200, Width: 80, Height: 60, ZIndex: 1,
+		StrokeColor: 0xFF000080, FillColor: data.ColorTransparent, StrokeWidth: 4, FontSize: 14,
+	}, elements[1])
+
+	require.Equal(t, data.ElementTypeTriangle, elements[2].Type)
+	require.Equal(t, int32(2), elements[2].ZIndex)
+
+	require.Equal(t, data.ElementTypeTextBox, elements[3].Type)
+	require.Equal(t, "Hello", elements[3].Text)
+	require.Equal(t, int32(20), elements[3].FontSize)
+	require.Equal(t, int64(0x1E1E1EFF), elements[3].StrokeColor)
+	require.Equal(t, data.ColorTransparent, elements[3].FillColor)
+
+	require.Equal(t, []string{
+		"diamond elements are imported as triangles",
+		"rotations of the elements are dropped",
+		"arrow elements are not supported and skipped (2 elements)",
+		"freedraw elements are not supported and skipped",
+	}, result.Warnings)
+
+	v := validator.New()
+	data.ValidateBoardFile(v, file)
+	require.True(t, v.Valid())
+}
+
+// TestExcalidrawLimits tests that the values beyond the limits of the boards are brought into them
+func TestExcalidrawLimits(t *testing.T) {
+	body := `{"type": "excalidraw", "elements": [
+		{"type": "text", "width": 10, "height": 10, "strokeColor": "red", "strokeWidth": 40, "fontSize": 500, "text": "` +
+		strings.Repeat("é", 1500) + `"}
+	]}`
+
+	result, err := Excalidraw([]byte(body), data.DefaultBoardName)
+	require.NoError(t, err)
+
+	element := result.File.Pages[0].Elements[0]
+	require.Equal(t, data.ColorBlack, element.StrokeColor)
+	require.Equal(t, 20.0, element.StrokeWidth)
+	require.Equal(t, int32(200), element.FontSize)
+	require.Len(t, element.Text, 2000)
+	require.Len(t, result.Warnings, 2)
+
+	v := validator.New()
+	data.ValidateBoardFile(v, result.File)
+	require.True(t, v.Valid())
+
+	// files without elements give an empty page
+	result, err = Excalidraw([]byte(`{"type": "excalidraw", "elements": []}`), data.DefaultBoardName)
+	require.NoError(t, err)
+	require.Empty(t, result.File.Pages[0].Elements)
+	require.Empty(t, result.Warnings)
+
+	_, err = Excalidraw([]byte(`{"type": "excalidraw", "elements": {}}`), data.DefaultBoardName)
+	require.Error(t, err)
+}
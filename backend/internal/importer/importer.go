@@ -0,0 +1,61 @@
+// Package importer converts the files of other whiteboard apps into board files, so that the drawings made with
+// them can be imported as boards. The parts of the drawings that the boards cannot show are reported as warnings.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/umtdemr/wb-backend/internal/data"
+)
+
+// Result is a board file converted from another format
+type Result struct {
+	File *data.BoardFile
+	// Warnings describe what is changed or left out while converting the file, they are empty if nothing is lost
+	Warnings []string
+}
+
+// DetectType returns the type of the JSON document, such as data.BoardFileType or ExcalidrawFileType.
+// Both the board files and the files of the supported apps name their type in the "type" field.
+func DetectType(body []byte) string {
+	var header struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(body, &header); err != nil {
+		return ""
+	}
+
+	return header.Type
+}
+
+// warnings collects the warnings of an import. The same warning is reported once with the number of the times
+// it happened, so that a drawing with many arrows does not give a warning for every arrow.
+type warnings struct {
+	counts map[string]int
+	order  []string
+}
+
+func newWarnings() *warnings {
+	return &warnings{counts: make(map[string]int)}
+}
+
+func (w *warnings) add(message string) {
+	if _, exists := w.counts[message]; !exists {
+		w.order = append(w.order, message)
+	}
+	w.counts[message]++
+}
+
+// list returns the warnings in the order they first happened
+func (w *warnings) list() []string {
+	list := make([]string, len(w.order))
+	for i, message := range w.order {
+		list[i] = message
+		if count := w.counts[message]; count > 1 {
+			list[i] = fmt.Sprintf("%s (%d elements)", message, count)
+		}
+	}
+
+	return list
+}
@@ -5,6 +5,7 @@ import (
 	"embed"
 	"gopkg.in/gomail.v2"
 	"html/template"
+	"io"
 )
 
 //go:embed "templates"
@@ -15,6 +16,12 @@ type Mailer struct {
 	sender string
 }
 
+// Attachment is a file that is attached to the email
+type Attachment struct {
+	Filename string
+	Content  []byte
+}
+
 func New(host string, port int, username, password, sender string) Mailer {
 	dialer := gomail.NewDialer(host, port, username, password)
 
@@ -24,7 +31,7 @@ func New(host string, port int, username, password, sender string) Mailer {
 	}
 }
 
-func (m Mailer) Send(recipient, templateFile string, data any) error {
+func (m Mailer) Send(recipient, templateFile string, data any, attachments ...Attachment) error {
 	tmpl, err := template.New("email").ParseFS(templateFS, "templates/"+templateFile)
 	if err != nil {
 		return err
@@ -60,6 +67,13 @@ func (m Mailer) Send(recipient, templateFile string, data any) error {
 	msg.SetBody("text/plain", plainBody.String())
 	msg.AddAlternative("text/html", htmlBody.String())
 
+	for _, attachment := range attachments {
+		msg.Attach(attachment.Filename, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(attachment.Content)
+			return err
+		}))
+	}
+
 	err = m.dialer.DialAndSend(msg)
 	return err
 }
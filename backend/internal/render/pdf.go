@@ -0,0 +1,403 @@
+package render
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// MaxPDFPageSize is the largest width and height of the PDF pages in points that the PDF readers can open,
+// larger pages are scaled down to fit
+const MaxPDFPageSize = 14400.0
+
+// kappa is the distance of the control points from the ends of a Bézier curve drawing a quarter of a circle
+const kappa = 0.5522847498
+
+// ErrNoPages is returned when a PDF document is rendered without any pages
+var ErrNoPages = errors.New("document has no pages")
+
+// winAnsi maps the characters that are not in the Latin-1 range to the WinAnsiEncoding of the standard fonts
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// pdfWriter keeps the offsets of the objects written to the document for the cross-reference table
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+// object writes the object with the given number, the objects must be written in the order of their numbers
+func (pw *pdfWriter) object(number int, body string) {
+	pw.offsets = append(pw.offsets, pw.buf.Len())
+	fmt.Fprintf(&pw.buf, "%d 0 obj\n%s\nendobj\n", number, body)
+}
+
+// stream writes a stream object compressed with the Flate filter
+func (pw *pdfWriter) stream(number int, content []byte) error {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(content); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	pw.offsets = append(pw.offsets, pw.buf.Len())
+	fmt.Fprintf(&pw.buf, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", number, compressed.Len())
+	pw.buf.Write(compressed.Bytes())
+	pw.buf.WriteString("\nendstream\nendobj\n")
+
+	return nil
+}
+
+// PDF writes the pages as a PDF document with a PDF page for each board page, in the order of the pages.
+// The elements are drawn as vector shapes and the texts are written with Helvetica, so that they can be
+// selected and printed sharply. Each PDF page covers all the elements of its page with the padding around
+// them, and the pages are listed with their names in the bookmarks of the document.
+func PDF(w io.Writer, title string, pages []data.Page) error {
+	if len(pages) == 0 {
+		return ErrNoPages
+	}
+
+	// the objects of the document, the pages take three objects each after them
+	const (
+		catalogObject = iota + 1
+		pagesObject
+		fontObject
+		infoObject
+		outlinesObject
+		firstPageObject
+	)
+	pageObject := func(i int) int { return firstPageObject + 3*i }
+
+	pw := &pdfWriter{}
+	pw.buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	kids := make([]byte, 0, len(pages)*8)
+	for i := range pages {
+		kids = fmt.Appendf(kids, "%d 0 R ", pageObject(i))
+	}
+
+	pw.object(catalogObject, fmt.Sprintf(
+		"<< /Type /Catalog /Pages %d 0 R /Outlines %d 0 R /PageMode /UseOutlines >>", pagesObject, outlinesObject,
+	))
+	pw.object(pagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids), len(pages)))
+	pw.object(fontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	pw.object(infoObject, fmt.Sprintf("<< /Title %s /Producer (wb) >>", pdfTextString(title)))
+	pw.object(outlinesObject, fmt.Sprintf(
+		"<< /Type /Outlines /First %d 0 R /Last %d 0 R /Count %d >>",
+		pageObject(0)+2, pageObject(len(pages)-1)+2, len(pages),
+	))
+
+	for i := range pages {
+		page := pageObject(i)
+		content, resources, width, height := pdfPageContent(pages[i].Elements)
+
+		pw.object(page, fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R >> /ExtGState << %s >> >> /Contents %d 0 R >>",
+			pagesObject, pdfNum(width), pdfNum(height), fontObject, resources, page+1,
+		))
+
+		if err := pw.stream(page+1, content); err != nil {
+			return err
+		}
+
+		// the bookmark of the page
+		outline := fmt.Sprintf("<< /Title %s /Parent %d 0 R /Dest [%d 0 R /Fit]", pdfTextString(pages[i].Name), outlinesObject, page)
+		if i > 0 {
+			outline += fmt.Sprintf(" /Prev %d 0 R", pageObject(i-1)+2)
+		}
+		if i < len(pages)-1 {
+			outline += fmt.Sprintf(" /Next %d 0 R", pageObject(i+1)+2)
+		}
+		pw.object(page+2, outline+" >>")
+	}
+
+	xref := pw.buf.Len()
+	fmt.Fprintf(&pw.buf, "xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, offset := range pw.offsets {
+		fmt.Fprintf(&pw.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(
+		&pw.buf,
+		"trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(pw.offsets)+1, catalogObject, infoObject, xref,
+	)
+
+	_, err := w.Write(pw.buf.Bytes())
+	return err
+}
+
+// pdfPageContent draws the elements into the content stream of a page. It returns the content, the graphics
+// states of the transparent colors used in the content and the size of the page in points.
+func pdfPageContent(elements []data.Element) ([]byte, string, float64, float64) {
+	bounds := Bounds(elements)
+	scale := math.Min(1, MaxPDFPageSize/math.Max(bounds.Width, bounds.Height))
+	width, height := bounds.Width*scale, bounds.Height*scale
+
+	c := &pdfCanvas{alphas: make(map[string]string)}
+
+	// the y axis of the PDF pages points up, it is flipped so that the elements are drawn in page coordinates
+	fmt.Fprintf(
+		&c.buf, "%s 0 0 %s %s %s cm\n",
+		pdfNum(scale), pdfNum(-scale), pdfNum(-bounds.X*scale), pdfNum(height+bounds.Y*scale),
+	)
+
+	for _, element := range sortByZIndex(elements) {
+		// the client cannot draw the shapes without an area either
+		if element.Width <= 0 || element.Height <= 0 {
+			continue
+		}
+
+		switch element.Type {
+		case data.ElementTypeRectangle:
+			c.drawRectangle(&element)
+		case data.ElementTypeEllipse:
+			c.drawEllipse(&element)
+		case data.ElementTypeTriangle:
+			c.drawTriangle(&element)
+		case data.ElementTypeTextBox:
+			c.drawTextBox(&element)
+		}
+	}
+
+	names := slices.Sorted(maps.Keys(c.alphas))
+	resources := make([]string, len(names))
+	for i, name := range names {
+		resources[i] = fmt.Sprintf("/%s << %s >>", name, c.alphas[name])
+	}
+
+	return c.buf.Bytes(), strings.Join(resources, " "), width, height
+}
+
+// pdfCanvas writes the drawing operators of a page
+type pdfCanvas struct {
+	buf bytes.Buffer
+	// alphas are the graphics states used for the transparent colors by their names
+	alphas map[string]string
+}
+
+// paint fills and strokes the path with the colors of the element. As in the client, the stroke is drawn inside
+// the element, so the path must be inset by the half of the stroke width.
+func (c *pdfCanvas) paint(path string, element *data.Element) {
+	fill := isVisible(element.FillColor)
+	stroke := element.StrokeWidth > 0 && isVisible(element.StrokeColor)
+
+	var operator string
+	switch {
+	case fill && stroke:
+		operator = "B"
+	case fill:
+		operator = "f"
+	case stroke:
+		operator = "S"
+	default:
+		return
+	}
+
+	c.buf.WriteString("q\n")
+	if fill {
+		c.setColor(element.FillColor, "rg", "ca")
+	}
+	if stroke {
+		c.setColor(element.StrokeColor, "RG", "CA")
+		fmt.Fprintf(&c.buf, "%s w\n", pdfNum(element.StrokeWidth))
+	}
+	c.buf.WriteString(path)
+	c.buf.WriteString(operator + "\nQ\n")
+}
+
+// setColor sets the color with the operator, rg for the fill color and RG for the stroke color. The transparent
+// colors are drawn with a graphics state that sets the alpha key, ca for the fill and CA for the stroke.
+func (c *pdfCanvas) setColor(color int64, operator string, alphaKey string) {
+	r, g, b, a := rgba(color)
+	fmt.Fprintf(&c.buf, "%s %s %s %s\n", pdfColor(r), pdfColor(g), pdfColor(b), operator)
+
+	if a < 255 {
+		name := alphaKey + strconv.Itoa(int(a))
+		c.alphas[name] = fmt.Sprintf("/%s %s", alphaKey, pdfColor(a))
+		fmt.Fprintf(&c.buf, "/%s gs\n", name)
+	}
+}
+
+// isVisible checks if the color is not fully transparent
+func isVisible(color int64) bool {
+	_, _, _, a := rgba(color)
+	return a > 0
+}
+
+// drawRectangle draws the rectangle with its rounded corners
+func (c *pdfCanvas) drawRectangle(element *data.Element) {
+	c.paint(roundedRectPath(insetArea(element), element.BorderRadius-element.StrokeWidth/2), element)
+}
+
+func (c *pdfCanvas) drawEllipse(element *data.Element) {
+	area := insetArea(element)
+	c.paint(ellipsePath(area), element)
+}
+
+// drawTriangle draws the triangle pointing up, with its base on the bottom of the element
+func (c *pdfCanvas) drawTriangle(element *data.Element) {
+	area := insetArea(element)
+	c.paint(fmt.Sprintf(
+		"%s %s m %s %s l %s %s l h\n",
+		pdfNum(area.X), pdfNum(area.Y+area.Height),
+		pdfNum(area.X+area.Width/2), pdfNum(area.Y),
+		pdfNum(area.X+area.Width), pdfNum(area.Y+area.Height),
+	), element)
+}
+
+// drawTextBox writes the text line by line, the stroke color is the color of the text. The background of the
+// text box is drawn when it is filled.
+func (c *pdfCanvas) drawTextBox(element *data.Element) {
+	background := *element
+	background.StrokeWidth = 0
+	c.paint(roundedRectPath(elementArea(element), 0), &background)
+
+	if element.Text == "" || !isVisible(element.StrokeColor) {
+		return
+	}
+
+	c.buf.WriteString("q\n")
+	c.setColor(element.StrokeColor, "rg", "ca")
+
+	// the text matrix flips the glyphs back, since the y axis of the page is flipped
+	fmt.Fprintf(
+		&c.buf, "BT\n/F1 %d Tf\n%s TL\n1 0 0 -1 %s %s Tm\n",
+		element.FontSize, pdfNum(lineHeight(element.FontSize)), pdfNum(element.X), pdfNum(element.Y+float64(element.FontSize)),
+	)
+	for i, line := range strings.Split(element.Text, "\n") {
+		if i > 0 {
+			c.buf.WriteString("T*\n")
+		}
+		c.buf.WriteString(pdfString(line) + " Tj\n")
+	}
+	c.buf.WriteString("ET\nQ\n")
+}
+
+// insetArea returns the area of the element shrunk by the half of its stroke width, so that the strokes
+// drawn on the edges of the area stay inside the element
+func insetArea(element *data.Element) Rect {
+	inset := element.StrokeWidth / 2
+	return Rect{
+		X:      element.X + inset,
+		Y:      element.Y + inset,
+		Width:  math.Max(element.Width-element.StrokeWidth, 0),
+		Height: math.Max(element.Height-element.StrokeWidth, 0),
+	}
+}
+
+// roundedRectPath returns the path of the rect with the rounded corners
+func roundedRectPath(rect Rect, radius float64) string {
+	radius = max(min(radius, rect.Width/2, rect.Height/2), 0)
+	if radius == 0 {
+		return fmt.Sprintf("%s %s %s %s re\n", pdfNum(rect.X), pdfNum(rect.Y), pdfNum(rect.Width), pdfNum(rect.Height))
+	}
+
+	left, top := rect.X, rect.Y
+	right, bottom := rect.X+rect.Width, rect.Y+rect.Height
+	k := radius * (1 - kappa)
+
+	var path bytes.Buffer
+	fmt.Fprintf(&path, "%s %s m\n", pdfNum(left+radius), pdfNum(top))
+	fmt.Fprintf(&path, "%s %s l\n", pdfNum(right-radius), pdfNum(top))
+	writeCurve(&path, right-k, top, right, top+k, right, top+radius)
+	fmt.Fprintf(&path, "%s %s l\n", pdfNum(right), pdfNum(bottom-radius))
+	writeCurve(&path, right, bottom-k, right-k, bottom, right-radius, bottom)
+	fmt.Fprintf(&path, "%s %s l\n", pdfNum(left+radius), pdfNum(bottom))
+	writeCurve(&path, left+k, bottom, left, bottom-k, left, bottom-radius)
+	fmt.Fprintf(&path, "%s %s l\n", pdfNum(left), pdfNum(top+radius))
+	writeCurve(&path, left, top+k, left+k, top, left+radius, top)
+	path.WriteString("h\n")
+
+	return path.String()
+}
+
+// ellipsePath returns the path of the ellipse fitting the rect, drawn with a curve for each quarter
+func ellipsePath(rect Rect) string {
+	rx, ry := rect.Width/2, rect.Height/2
+	cx, cy := rect.X+rx, rect.Y+ry
+	kx, ky := rx*kappa, ry*kappa
+
+	var path bytes.Buffer
+	fmt.Fprintf(&path, "%s %s m\n", pdfNum(cx+rx), pdfNum(cy))
+	writeCurve(&path, cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	writeCurve(&path, cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	writeCurve(&path, cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	writeCurve(&path, cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	path.WriteString("h\n")
+
+	return path.String()
+}
+
+// writeCurve writes a Bézier curve from the current point with the two control points and the end point
+func writeCurve(path *bytes.Buffer, x1, y1, x2, y2, x3, y3 float64) {
+	fmt.Fprintf(path, "%s %s %s %s %s %s c\n", pdfNum(x1), pdfNum(y1), pdfNum(x2), pdfNum(y2), pdfNum(x3), pdfNum(y3))
+}
+
+// pdfString encodes the text as a literal string of the WinAnsiEncoding, the characters that the standard
+// fonts do not have are replaced with a question mark
+func pdfString(text string) string {
+	encoded := make([]byte, 0, len(text)+2)
+	encoded = append(encoded, '(')
+	for _, r := range text {
+		var b byte
+		switch {
+		case r == '\t':
+			b = ' '
+		case r == '(' || r == ')' || r == '\\':
+			encoded = append(encoded, '\\')
+			b = byte(r)
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			b = byte(r)
+		default:
+			var ok bool
+			if b, ok = winAnsi[r]; !ok {
+				b = '?'
+			}
+		}
+		encoded = append(encoded, b)
+	}
+
+	return string(append(encoded, ')'))
+}
+
+// pdfTextString encodes the text of the document properties such as the title, the texts are written as UTF-16
+// so that they can have any character
+func pdfTextString(text string) string {
+	encoded := []byte("<FEFF")
+	for _, unit := range utf16.Encode([]rune(text)) {
+		encoded = fmt.Appendf(encoded, "%04X", unit)
+	}
+
+	return string(append(encoded, '>'))
+}
+
+// pdfColor formats a color channel as a number between 0 and 1
+func pdfColor(channel uint8) string {
+	return strconv.FormatFloat(float64(channel)/255, 'f', 3, 64)
+}
+
+// pdfNum formats the numbers with at most two decimals, which is enough for the points of the page
+func pdfNum(f float64) string {
+	s := strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+	if s == "-0" {
+		return "0"
+	}
+
+	return s
+}
@@ -0,0 +1,114 @@
+package render
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var pdfStreamRegexp = regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`)
+
+// TestPDF tests writing a document with a page for each board page and the vector shapes of the elements
+func TestPDF(t *testing.T) {
+	pages := []data.Page{
+		{
+			Name: "First",
+			Elements: []data.Element{
+				{
+					Type: data.ElementTypeTextBox, X: 10, Y: 10, Width: 100, Height: 40, ZIndex: 2,
+					StrokeColor: data.ColorBlack, Text: "a (b)\nçalış", FontSize: 20,
+				},
+				{
+					Type: data.ElementTypeRectangle, X: 0, Y: 0, Width: 200, Height: 100, ZIndex: 0,
+					StrokeColor: 0xFF000080, FillColor: 0x00FF00FF, StrokeWidth: 2, BorderRadius: 8,
+				},
+				{
+					Type: data.ElementTypeEllipse, X: 0, Y: 0, Width: 50, Height: 30, ZIndex: 1,
+					StrokeColor: data.ColorBlack, FillColor: data.ColorTransparent, StrokeWidth: 0,
+				},
+			},
+		},
+		{
+			Name: "Second",
+			Elements: []data.Element{
+				{
+					Type: data.ElementTypeTriangle, X: 100, Y: 0, Width: 40, Height: 40,
+					StrokeColor: data.ColorBlack, FillColor: 0x0000FFFF, StrokeWidth: 2,
+				},
+			},
+		},
+		{Name: "Empty", Elements: []data.Element{}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, PDF(&buf, "Board ✓", pages))
+	document := buf.String()
+
+	require.True(t, strings.HasPrefix(document, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(document, "%%EOF\n"))
+	require.Contains(t, document, "/Count 3 >>")
+	require.Contains(t, document, "/Title <FEFF0042006F00610072006400202713>")
+	require.Contains(t, document, "/Title <FEFF005300650063006F006E0064>")
+
+	// the pages cover the elements with the padding around them
+	require.Contains(t, document, "/MediaBox [0 0 240 140]")
+	require.Contains(t, document, "/MediaBox [0 0 80 80]")
+	require.Contains(t, document, "/MediaBox [0 0 40 40]")
+	require.Contains(t, document, "/ExtGState << /CA128 << /CA 0.502 >> >>")
+
+	// the cross-reference table points to the objects
+	xref, err := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(document)[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(document[xref:], "xref\n0 15\n"))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(document[xref:], -1)
+	require.Len(t, offsets, 14)
+	for i, offset := range offsets {
+		position, err := strconv.Atoi(offset[1])
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(document[position:], fmt.Sprintf("%d 0 obj", i+1)))
+	}
+
+	streams := pdfStreamRegexp.FindAllStringSubmatch(document, -1)
+	require.Len(t, streams, 3)
+	contents := make([]string, len(streams))
+	for i, stream := range streams {
+		r, err := zlib.NewReader(strings.NewReader(stream[1]))
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		contents[i] = string(content)
+	}
+
+	first := contents[0]
+	require.True(t, strings.HasPrefix(first, "1 0 0 -1 20 120 cm\n"))
+	// the rounded rectangle is filled and stroked with its transparent stroke color
+	require.Contains(t, first, "0.000 1.000 0.000 rg\n1.000 0.000 0.000 RG\n/CA128 gs\n2 w\n8 1 m\n192 1 l\n")
+	require.Contains(t, first, "B\nQ\n")
+	// the transparent ellipse and the background of the text box are not drawn
+	require.Equal(t, 2, strings.Count(first, "Q\n"))
+	require.Contains(t, first, "BT\n/F1 20 Tf\n24 TL\n1 0 0 -1 10 30 Tm\n(a \\(b\\)) Tj\nT*\n(\xE7al??) Tj\nET\nQ\n")
+
+	require.Contains(t, contents[1], "101 39 m 120 1 l 139 39 l h\nB\n")
+	require.Equal(t, "1 0 0 -1 0 40 cm\n", contents[2])
+
+	require.ErrorIs(t, PDF(&buf, "Board", nil), ErrNoPages)
+}
+
+// TestPDFPageSize tests that the pages larger than the PDF readers can open are scaled down
+func TestPDFPageSize(t *testing.T) {
+	elements := []data.Element{
+		{Type: data.ElementTypeRectangle, X: 0, Y: 0, Width: 28760, Height: 1000, FillColor: data.ColorBlack},
+	}
+
+	content, _, width, height := pdfPageContent(elements)
+	require.Equal(t, MaxPDFPageSize, width)
+	require.InDelta(t, 520, height, 0.01)
+	require.True(t, strings.HasPrefix(string(content), "0.5 0 0 -0.5 10 510 cm\n"))
+}
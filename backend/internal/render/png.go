@@ -0,0 +1,285 @@
+package render
+
+import (
+	"errors"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// MaxImageSize is the largest width and height of the raster images in pixels
+const MaxImageSize = data.MaxExportSize
+
+// samples is the number of samples taken along each axis of a pixel, it smooths the edges of the shapes
+const samples = 4
+
+var (
+	// ErrImageTooLarge is returned when the image would be wider or taller than MaxImageSize
+	ErrImageTooLarge = errors.New("image is too large")
+	// ErrInvalidScale is returned when the scale is not positive
+	ErrInvalidScale = errors.New("scale must be positive")
+)
+
+// backgroundColor is the color of the board in the client
+var backgroundColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+// PNG writes the elements as a PNG image. The region is the area of the page to draw, when it is nil the image
+// covers all the elements with the padding around them. The scale is the number of pixels for a unit of the page.
+func PNG(w io.Writer, elements []data.Element, scale float64, region *Rect) error {
+	img, err := Raster(elements, scale, region)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+// Raster draws the elements into an image, see PNG for the region and the scale
+func Raster(elements []data.Element, scale float64, region *Rect) (*image.RGBA, error) {
+	if scale <= 0 {
+		return nil, ErrInvalidScale
+	}
+
+	area := Bounds(elements)
+	if region != nil {
+		area = *region
+	}
+
+	width := max(int(math.Ceil(area.Width*scale)), 1)
+	height := max(int(math.Ceil(area.Height*scale)), 1)
+	if width > MaxImageSize || height > MaxImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+
+	c := &canvas{img: img, originX: area.X, originY: area.Y, scale: scale}
+	for _, element := range sortByZIndex(elements) {
+		if element.Width <= 0 || element.Height <= 0 {
+			continue
+		}
+
+		switch element.Type {
+		case data.ElementTypeRectangle:
+			c.drawRectangle(&element)
+		case data.ElementTypeEllipse:
+			c.drawEllipse(&element)
+		case data.ElementTypeTriangle:
+			c.drawTriangle(&element)
+		case data.ElementTypeTextBox:
+			c.drawTextBox(&element)
+		}
+	}
+
+	return img, nil
+}
+
+// canvas draws the shapes in page coordinates into the image
+type canvas struct {
+	img *image.RGBA
+	// originX and originY are the page coordinates of the top left corner of the image
+	originX float64
+	originY float64
+	scale   float64
+}
+
+// fill paints the pixels in the area that the shape covers. The pixels on the edges of the shape are blended by how
+// much of them is covered.
+func (c *canvas) fill(area Rect, inside func(x, y float64) bool, fillColor int64) {
+	r, g, b, a := rgba(fillColor)
+	if a == 0 {
+		return
+	}
+
+	imgBounds := c.img.Bounds()
+	minX := max(int(math.Floor((area.X-c.originX)*c.scale)), imgBounds.Min.X)
+	minY := max(int(math.Floor((area.Y-c.originY)*c.scale)), imgBounds.Min.Y)
+	maxX := min(int(math.Ceil((area.X+area.Width-c.originX)*c.scale)), imgBounds.Max.X)
+	maxY := min(int(math.Ceil((area.Y+area.Height-c.originY)*c.scale)), imgBounds.Max.Y)
+
+	for py := minY; py < maxY; py++ {
+		for px := minX; px < maxX; px++ {
+			covered := 0
+			for sy := 0; sy < samples; sy++ {
+				y := c.originY + (float64(py)+(float64(sy)+0.5)/samples)/c.scale
+				for sx := 0; sx < samples; sx++ {
+					x := c.originX + (float64(px)+(float64(sx)+0.5)/samples)/c.scale
+					if inside(x, y) {
+						covered++
+					}
+				}
+			}
+
+			if covered == 0 {
+				continue
+			}
+
+			alpha := float64(a) / 255 * float64(covered) / (samples * samples)
+			dst := c.img.RGBAAt(px, py)
+			c.img.SetRGBA(px, py, color.RGBA{
+				R: blend(r, dst.R, alpha),
+				G: blend(g, dst.G, alpha),
+				B: blend(b, dst.B, alpha),
+				A: 255,
+			})
+		}
+	}
+}
+
+// blend mixes the source channel over the destination channel
+func blend(src, dst uint8, alpha float64) uint8 {
+	return uint8(math.Round(float64(src)*alpha + float64(dst)*(1-alpha)))
+}
+
+// elementArea returns the box of the element
+func elementArea(element *data.Element) Rect {
+	return Rect{X: element.X, Y: element.Y, Width: element.Width, Height: element.Height}
+}
+
+// drawRectangle draws the rectangle with its rounded corners. As in the client, the border is drawn
+// inside the element so that it does not grow out of its box.
+func (c *canvas) drawRectangle(element *data.Element) {
+	area := elementArea(element)
+	outer := roundedRect(area, element.BorderRadius)
+	c.fill(area, outer, element.FillColor)
+
+	if element.StrokeWidth > 0 {
+		sw := element.StrokeWidth
+		inner := roundedRect(Rect{X: area.X + sw, Y: area.Y + sw, Width: area.Width - 2*sw, Height: area.Height - 2*sw}, element.BorderRadius-sw)
+		c.fill(area, func(x, y float64) bool { return outer(x, y) && !inner(x, y) }, element.StrokeColor)
+	}
+}
+
+func (c *canvas) drawEllipse(element *data.Element) {
+	area := elementArea(element)
+	cx, cy := element.X+element.Width/2, element.Y+element.Height/2
+	outer := ellipse(cx, cy, element.Width/2, element.Height/2)
+	c.fill(area, outer, element.FillColor)
+
+	if element.StrokeWidth > 0 {
+		sw := element.StrokeWidth
+		inner := ellipse(cx, cy, element.Width/2-sw, element.Height/2-sw)
+		c.fill(area, func(x, y float64) bool { return outer(x, y) && !inner(x, y) }, element.StrokeColor)
+	}
+}
+
+// drawTriangle draws the triangle pointing up, with its base on the bottom of the element
+func (c *canvas) drawTriangle(element *data.Element) {
+	area := elementArea(element)
+	points := [3][2]float64{
+		{element.X, element.Y + element.Height},
+		{element.X + element.Width/2, element.Y},
+		{element.X + element.Width, element.Y + element.Height},
+	}
+	outer := triangle(points)
+	c.fill(area, outer, element.FillColor)
+
+	if element.StrokeWidth > 0 {
+		sw := element.StrokeWidth
+		c.fill(area, func(x, y float64) bool {
+			if !outer(x, y) {
+				return false
+			}
+			for i := range points {
+				if distanceToSegment(x, y, points[i], points[(i+1)%len(points)]) < sw {
+					return true
+				}
+			}
+			return false
+		}, element.StrokeColor)
+	}
+}
+
+// drawTextBox draws the text line by line with the bitmap font, the stroke color is the color of the text.
+// The background of the text box is drawn when it is filled.
+func (c *canvas) drawTextBox(element *data.Element) {
+	area := elementArea(element)
+	c.fill(area, roundedRect(area, 0), element.FillColor)
+
+	// the glyphs are 7 pixels tall, it makes their height close to the capital letters of the client font
+	pixel := float64(element.FontSize) / 10
+	baseline := element.Y + float64(element.FontSize)
+
+	line := 0
+	x := element.X
+	for _, r := range element.Text {
+		if r == '\n' {
+			line++
+			x = element.X
+			continue
+		}
+
+		top := baseline + float64(line)*lineHeight(element.FontSize) - glyphRows*pixel
+		g := glyph(r)
+		for col := 0; col < glyphColumns; col++ {
+			for row := 0; row < glyphRows; row++ {
+				if g[col]&(1<<row) == 0 {
+					continue
+				}
+				dot := Rect{X: x + float64(col)*pixel, Y: top + float64(row)*pixel, Width: pixel, Height: pixel}
+				c.fill(dot, roundedRect(dot, 0), element.StrokeColor)
+			}
+		}
+
+		x += glyphAdvance * pixel
+	}
+}
+
+// roundedRect returns whether the points are inside the rect with the rounded corners
+func roundedRect(rect Rect, radius float64) func(x, y float64) bool {
+	radius = max(min(radius, rect.Width/2, rect.Height/2), 0)
+	return func(x, y float64) bool {
+		if rect.IsEmpty() || x < rect.X || y < rect.Y || x > rect.X+rect.Width || y > rect.Y+rect.Height {
+			return false
+		}
+
+		// the distance to the closest point of the rect that is not on a corner
+		dx := x - math.Max(rect.X+radius, math.Min(x, rect.X+rect.Width-radius))
+		dy := y - math.Max(rect.Y+radius, math.Min(y, rect.Y+rect.Height-radius))
+		return dx*dx+dy*dy <= radius*radius
+	}
+}
+
+// ellipse returns whether the points are inside the ellipse
+func ellipse(cx, cy, rx, ry float64) func(x, y float64) bool {
+	return func(x, y float64) bool {
+		if rx <= 0 || ry <= 0 {
+			return false
+		}
+
+		dx, dy := (x-cx)/rx, (y-cy)/ry
+		return dx*dx+dy*dy <= 1
+	}
+}
+
+// triangle returns whether the points are inside the triangle
+func triangle(points [3][2]float64) func(x, y float64) bool {
+	return func(x, y float64) bool {
+		var hasNegative, hasPositive bool
+		for i := range points {
+			a, b := points[i], points[(i+1)%len(points)]
+			cross := (b[0]-a[0])*(y-a[1]) - (b[1]-a[1])*(x-a[0])
+			hasNegative = hasNegative || cross < 0
+			hasPositive = hasPositive || cross > 0
+		}
+
+		return !(hasNegative && hasPositive)
+	}
+}
+
+// distanceToSegment returns the distance of the point to the line segment between a and b
+func distanceToSegment(x, y float64, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	lengthSquared := dx*dx + dy*dy
+	if lengthSquared == 0 {
+		return math.Hypot(x-a[0], y-a[1])
+	}
+
+	t := math.Max(0, math.Min(1, ((x-a[0])*dx+(y-a[1])*dy)/lengthSquared))
+	return math.Hypot(x-(a[0]+t*dx), y-(a[1]+t*dy))
+}
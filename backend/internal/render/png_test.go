@@ -0,0 +1,141 @@
+package render
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// TestRaster tests drawing the elements into an image with the scale and the region
+func TestRaster(t *testing.T) {
+	elements := []data.Element{
+		{
+			Type: data.ElementTypeRectangle, X: 0, Y: 0, Width: 100, Height: 50, ZIndex: 0,
+			StrokeColor: data.ColorBlack, FillColor: 0xFF0000FF, StrokeWidth: 4,
+		},
+		{
+			Type: data.ElementTypeEllipse, X: 40, Y: 10, Width: 20, Height: 20, ZIndex: 1,
+			StrokeColor: data.ColorBlack, FillColor: 0x0000FFFF, StrokeWidth: 0,
+		},
+		// transparent fill keeps what is below it
+		{
+			Type: data.ElementTypeTriangle, X: 0, Y: 0, Width: 30, Height: 30, ZIndex: 2,
+			StrokeColor: data.ColorTransparent, FillColor: data.ColorTransparent, StrokeWidth: 2,
+		},
+	}
+
+	img, err := Raster(elements, 2, nil)
+	require.NoError(t, err)
+	// the image covers the elements with the padding
+	require.Equal(t, 2*(100+2*Padding), float64(img.Bounds().Dx()))
+	require.Equal(t, 2*(50+2*Padding), float64(img.Bounds().Dy()))
+
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	black := color.RGBA{A: 255}
+
+	toPixel := func(x, y float64) (int, int) {
+		return int((x + Padding) * 2), int((y + Padding) * 2)
+	}
+
+	require.Equal(t, white, img.RGBAAt(toPixel(-10, -10)))
+	require.Equal(t, black, img.RGBAAt(toPixel(1, 25)))
+	require.Equal(t, red, img.RGBAAt(toPixel(20, 40)))
+	require.Equal(t, blue, img.RGBAAt(toPixel(50, 20)))
+	require.Equal(t, red, img.RGBAAt(toPixel(10, 20)))
+
+	// only the region is drawn
+	img, err = Raster(elements, 1, &Rect{X: 45, Y: 15, Width: 10, Height: 10})
+	require.NoError(t, err)
+	require.Equal(t, 10, img.Bounds().Dx())
+	require.Equal(t, blue, img.RGBAAt(5, 5))
+
+	_, err = Raster(elements, 0, nil)
+	require.ErrorIs(t, err, ErrInvalidScale)
+
+	_, err = Raster(elements, 1, &Rect{Width: MaxImageSize + 1, Height: 10})
+	require.ErrorIs(t, err, ErrImageTooLarge)
+}
+
+// TestRasterText tests drawing the text boxes with the bitmap font
+func TestRasterText(t *testing.T) {
+	elements := []data.Element{
+		{Type: data.ElementTypeTextBox, X: 0, Y: 0, Width: 100, Height: 30, StrokeColor: data.ColorBlack, Text: "I\nI", FontSize: 10},
+	}
+
+	img, err := Raster(elements, 1, &Rect{Width: 20, Height: 30})
+	require.NoError(t, err)
+
+	black := color.RGBA{A: 255}
+	// the vertical bar of I is the third column of the glyph, the glyph pixels are 1 unit for the font size 10
+	require.Equal(t, black, img.RGBAAt(2, 5))
+	require.Equal(t, black, img.RGBAAt(2, 17))
+	require.NotEqual(t, black, img.RGBAAt(0, 5))
+	require.NotEqual(t, black, img.RGBAAt(2, 12))
+
+	require.Equal(t, unknownGlyph, glyph('ş'))
+	require.Equal(t, glyphs[1], glyph('!'))
+}
+
+// TestPNG tests that the images are encoded as PNG
+func TestPNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, PNG(&buf, nil, 1, nil))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int(2*Padding), img.Bounds().Dx())
+}
+
+// TestThumbnail tests that the thumbnails have the given size and show the elements in the center
+func TestThumbnail(t *testing.T) {
+	elements := []data.Element{
+		{
+			Type: data.ElementTypeRectangle, X: 1000, Y: 500, Width: 2000, Height: 500,
+			StrokeColor: data.ColorBlack, FillColor: 0xFF0000FF, StrokeWidth: 0,
+		},
+	}
+
+	var buf bytes.Buffer
+	err := Thumbnail(&buf, elements, 320, 200)
+	require.NoError(t, err)
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 320, img.Bounds().Dx())
+	require.Equal(t, 200, img.Bounds().Dy())
+
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	red := color.RGBA{R: 255, A: 255}
+	// the wide rectangle fills the width of the image and is centered vertically
+	require.Equal(t, red, color.RGBAModel.Convert(img.At(160, 100)))
+	require.Equal(t, red, color.RGBAModel.Convert(img.At(10, 100)))
+	require.Equal(t, white, color.RGBAModel.Convert(img.At(160, 10)))
+	require.Equal(t, white, color.RGBAModel.Convert(img.At(160, 190)))
+
+	// small pages are not enlarged
+	buf.Reset()
+	elements[0].Width, elements[0].Height = 20, 20
+	err = Thumbnail(&buf, elements, 320, 200)
+	require.NoError(t, err)
+
+	img, err = png.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 320, img.Bounds().Dx())
+	require.Equal(t, red, color.RGBAModel.Convert(img.At(160, 100)))
+	require.Equal(t, white, color.RGBAModel.Convert(img.At(140, 100)))
+	require.Equal(t, white, color.RGBAModel.Convert(img.At(180, 100)))
+
+	// empty pages give a blank thumbnail
+	buf.Reset()
+	err = Thumbnail(&buf, nil, 320, 200)
+	require.NoError(t, err)
+
+	img, err = png.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, white, color.RGBAModel.Convert(img.At(160, 100)))
+}
@@ -0,0 +1,70 @@
+// Package render draws the elements of a board page into image formats, so that the boards can be shared
+// outside the app. The elements are drawn the same way the client draws them.
+package render
+
+import (
+	"github.com/umtdemr/wb-backend/internal/data"
+	"math"
+	"sort"
+)
+
+// Padding is the space left around the elements in the rendered images
+const Padding = 20.0
+
+// Rect is an area of a board page in page coordinates
+type Rect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// IsEmpty checks if the rect does not cover any area
+func (r Rect) IsEmpty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Bounds returns the smallest rect containing all the elements with the padding around it.
+// An empty page gives a rect that only has the padding.
+func Bounds(elements []data.Element) Rect {
+	if len(elements) == 0 {
+		return Rect{Width: 2 * Padding, Height: 2 * Padding}
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, element := range elements {
+		minX = math.Min(minX, element.X)
+		minY = math.Min(minY, element.Y)
+		maxX = math.Max(maxX, element.X+element.Width)
+		maxY = math.Max(maxY, element.Y+element.Height)
+	}
+
+	return Rect{
+		X:      minX - Padding,
+		Y:      minY - Padding,
+		Width:  maxX - minX + 2*Padding,
+		Height: maxY - minY + 2*Padding,
+	}
+}
+
+// sortByZIndex returns a copy of the elements in the order they are drawn, the ones at the back come first
+func sortByZIndex(elements []data.Element) []data.Element {
+	sorted := make([]data.Element, len(elements))
+	copy(sorted, elements)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ZIndex < sorted[j].ZIndex
+	})
+
+	return sorted
+}
+
+// rgba unpacks the 0xRRGGBBAA colors of the elements
+func rgba(color int64) (r, g, b, a uint8) {
+	return uint8(color >> 24), uint8(color >> 16), uint8(color >> 8), uint8(color)
+}
+
+// lineHeight is the distance between the baselines of the lines in a text box
+func lineHeight(fontSize int32) float64 {
+	return float64(fontSize) * 1.2
+}
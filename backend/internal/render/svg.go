@@ -0,0 +1,164 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// fontFamily is the font used by the client for the text boxes
+const fontFamily = "Open Sans, sans-serif"
+
+// SVG writes the elements as an SVG document. The document covers all the elements with the padding around them.
+func SVG(w io.Writer, elements []data.Element) error {
+	bounds := Bounds(elements)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(
+		&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="%s %s %s %s">`+"\n",
+		num(bounds.Width), num(bounds.Height), num(bounds.X), num(bounds.Y), num(bounds.Width), num(bounds.Height),
+	)
+
+	for _, element := range sortByZIndex(elements) {
+		// the client cannot draw the shapes without an area either
+		if element.Width <= 0 || element.Height <= 0 {
+			continue
+		}
+
+		switch element.Type {
+		case data.ElementTypeRectangle:
+			writeSVGRectangle(&buf, &element)
+		case data.ElementTypeEllipse:
+			writeSVGEllipse(&buf, &element)
+		case data.ElementTypeTriangle:
+			writeSVGTriangle(&buf, &element)
+		case data.ElementTypeTextBox:
+			writeSVGTextBox(&buf, &element)
+		}
+	}
+
+	buf.WriteString("</svg>\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeSVGRectangle writes the rectangle with its rounded corners. As in the client, the border is drawn
+// inside the element so that it does not grow out of its box.
+func writeSVGRectangle(buf *bytes.Buffer, element *data.Element) {
+	inset := element.StrokeWidth / 2
+	fmt.Fprintf(
+		buf,
+		`<rect x="%s" y="%s" width="%s" height="%s" rx="%s" %s/>`+"\n",
+		num(element.X+inset), num(element.Y+inset),
+		num(element.Width-element.StrokeWidth), num(element.Height-element.StrokeWidth),
+		num(element.BorderRadius), paintAttrs(element),
+	)
+}
+
+func writeSVGEllipse(buf *bytes.Buffer, element *data.Element) {
+	inset := element.StrokeWidth / 2
+	fmt.Fprintf(
+		buf,
+		`<ellipse cx="%s" cy="%s" rx="%s" ry="%s" %s/>`+"\n",
+		num(element.X+element.Width/2), num(element.Y+element.Height/2),
+		num(element.Width/2-inset), num(element.Height/2-inset),
+		paintAttrs(element),
+	)
+}
+
+// writeSVGTriangle writes the triangle pointing up, with its base on the bottom of the element
+func writeSVGTriangle(buf *bytes.Buffer, element *data.Element) {
+	inset := element.StrokeWidth / 2
+	left, right := element.X+inset, element.X+element.Width-inset
+	top, bottom := element.Y+inset, element.Y+element.Height-inset
+
+	fmt.Fprintf(
+		buf,
+		`<polygon points="%s,%s %s,%s %s,%s" %s/>`+"\n",
+		num(left), num(bottom), num(element.X+element.Width/2), num(top), num(right), num(bottom),
+		paintAttrs(element),
+	)
+}
+
+// writeSVGTextBox writes the text of the text box line by line, the stroke color is the color of the text.
+// The background of the text box is written when it is filled.
+func writeSVGTextBox(buf *bytes.Buffer, element *data.Element) {
+	if fill, opacity := svgColor(element.FillColor); fill != "none" {
+		fmt.Fprintf(
+			buf,
+			`<rect x="%s" y="%s" width="%s" height="%s" fill="%s"%s/>`+"\n",
+			num(element.X), num(element.Y), num(element.Width), num(element.Height), fill, opacityAttr("fill-opacity", opacity),
+		)
+	}
+
+	if element.Text == "" {
+		return
+	}
+
+	color, opacity := svgColor(element.StrokeColor)
+	fmt.Fprintf(
+		buf,
+		`<text x="%s" y="%s" font-family="%s" font-size="%d" fill="%s"%s>`,
+		num(element.X), num(element.Y), fontFamily, element.FontSize, color, opacityAttr("fill-opacity", opacity),
+	)
+
+	for i, line := range strings.Split(element.Text, "\n") {
+		dy := lineHeight(element.FontSize)
+		if i == 0 {
+			dy = float64(element.FontSize)
+		}
+
+		fmt.Fprintf(buf, `<tspan x="%s" dy="%s" xml:space="preserve">`, num(element.X), num(dy))
+		_ = xml.EscapeText(buf, []byte(line))
+		buf.WriteString("</tspan>")
+	}
+
+	buf.WriteString("</text>\n")
+}
+
+// paintAttrs returns the fill and stroke attributes of the shapes
+func paintAttrs(element *data.Element) string {
+	fill, fillOpacity := svgColor(element.FillColor)
+	stroke, strokeOpacity := svgColor(element.StrokeColor)
+
+	if element.StrokeWidth <= 0 {
+		stroke = "none"
+	}
+
+	attrs := fmt.Sprintf(`fill="%s"%s`, fill, opacityAttr("fill-opacity", fillOpacity))
+	if stroke == "none" {
+		return attrs + ` stroke="none"`
+	}
+
+	return attrs + fmt.Sprintf(` stroke="%s" stroke-width="%s"%s`, stroke, num(element.StrokeWidth), opacityAttr("stroke-opacity", strokeOpacity))
+}
+
+// svgColor converts the color into an SVG color and its opacity, transparent colors are "none" without an opacity
+func svgColor(color int64) (string, float64) {
+	r, g, b, a := rgba(color)
+	if a == 0 {
+		return "none", 1
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b), float64(a) / 255
+}
+
+// opacityAttr returns the opacity attribute of a color, it is left out for the opaque colors
+func opacityAttr(name string, opacity float64) string {
+	if opacity >= 1 {
+		return ""
+	}
+
+	return fmt.Sprintf(` %s="%s"`, name, strconv.FormatFloat(opacity, 'f', 3, 64))
+}
+
+// num formats the coordinates without the trailing zeros
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
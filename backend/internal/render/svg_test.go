@@ -0,0 +1,74 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"strings"
+	"testing"
+)
+
+// TestBounds tests that the rendered area covers all the elements with the padding
+func TestBounds(t *testing.T) {
+	require.Equal(t, Rect{Width: 2 * Padding, Height: 2 * Padding}, Bounds(nil))
+
+	bounds := Bounds([]data.Element{
+		{X: 100, Y: 50, Width: 200, Height: 100},
+		{X: -40, Y: 120, Width: 60, Height: 80},
+	})
+	require.Equal(t, Rect{X: -40 - Padding, Y: 50 - Padding, Width: 340 + 2*Padding, Height: 150 + 2*Padding}, bounds)
+}
+
+// TestSVG tests rendering each type of element with their colors and z order
+func TestSVG(t *testing.T) {
+	elements := []data.Element{
+		{
+			Type: data.ElementTypeTextBox, X: 10, Y: 10, Width: 100, Height: 40, ZIndex: 3,
+			StrokeColor: data.ColorBlack, Text: "a < b\nsecond line", FontSize: 20,
+		},
+		{
+			Type: data.ElementTypeRectangle, X: 0, Y: 0, Width: 200, Height: 100, ZIndex: 0,
+			StrokeColor: 0xFF000080, FillColor: 0x00FF00FF, StrokeWidth: 2, BorderRadius: 8,
+		},
+		{
+			Type: data.ElementTypeEllipse, X: 0, Y: 0, Width: 50, Height: 30, ZIndex: 1,
+			StrokeColor: data.ColorBlack, FillColor: data.ColorTransparent, StrokeWidth: 0,
+		},
+		{
+			Type: data.ElementTypeTriangle, X: 100, Y: 0, Width: 40, Height: 40, ZIndex: 2,
+			StrokeColor: data.ColorBlack, FillColor: 0x0000FFFF, StrokeWidth: 2,
+		},
+		// elements without an area are not drawn
+		{Type: data.ElementTypeRectangle, X: 0, Y: 0, Width: 0, Height: 10, ZIndex: 4},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, SVG(&buf, elements))
+	svg := buf.String()
+
+	// the document must be well-formed
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			require.Equal(t, "EOF", err.Error())
+			break
+		}
+	}
+
+	require.Contains(t, svg, `width="240" height="140" viewBox="-20 -20 240 140"`)
+	require.Contains(t, svg, `<rect x="1" y="1" width="198" height="98" rx="8" fill="#00ff00" stroke="#ff0000" stroke-width="2" stroke-opacity="0.502"/>`)
+	require.Contains(t, svg, `<ellipse cx="25" cy="15" rx="25" ry="15" fill="none" stroke="none"/>`)
+	require.Contains(t, svg, `<polygon points="101,39 120,1 139,39" fill="#0000ff" stroke="#000000" stroke-width="2"/>`)
+	require.Contains(t, svg, `a &lt; b</tspan>`)
+	require.Contains(t, svg, `dy="24" xml:space="preserve">second line</tspan>`)
+	require.Equal(t, 1, strings.Count(svg, "<rect"))
+
+	// shapes are written from back to front
+	rectangle := strings.Index(svg, "<rect")
+	ellipse := strings.Index(svg, "<ellipse")
+	triangle := strings.Index(svg, "<polygon")
+	text := strings.Index(svg, "<text")
+	require.True(t, rectangle < ellipse && ellipse < triangle && triangle < text)
+}
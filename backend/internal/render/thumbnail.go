@@ -0,0 +1,33 @@
+package render
+
+import (
+	"github.com/umtdemr/wb-backend/internal/data"
+	"image"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Thumbnail writes a PNG image of the given size that shows all the elements. The elements are shrunk to fit
+// into the image and centered, they are never drawn larger than they are on the page.
+func Thumbnail(w io.Writer, elements []data.Element, width int, height int) error {
+	bounds := Bounds(elements)
+	scale := math.Min(1, math.Min(float64(width)/bounds.Width, float64(height)/bounds.Height))
+
+	regionWidth := float64(width) / scale
+	regionHeight := float64(height) / scale
+	region := Rect{
+		X:      bounds.X - (regionWidth-bounds.Width)/2,
+		Y:      bounds.Y - (regionHeight-bounds.Height)/2,
+		Width:  regionWidth,
+		Height: regionHeight,
+	}
+
+	img, err := Raster(elements, scale, &region)
+	if err != nil {
+		return err
+	}
+
+	// the rounding of the scaled region might add a pixel
+	return png.Encode(w, img.SubImage(image.Rect(0, 0, width, height)))
+}
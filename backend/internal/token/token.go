@@ -9,6 +9,9 @@ import (
 const (
 	ScopeActivation     = "activation"
 	ScopeAuthentication = "authentication"
+	// ScopeInvitation is for the board invitations of the people who do not have an account yet.
+	// Since there is no user for them, these tokens are kept in board_invitations instead of tokens.
+	ScopeInvitation = "invitation"
 )
 
 func GenerateToken() (string, []byte, error) {
@@ -0,0 +1,7 @@
+package worker
+
+type ExportJob struct {
+	ExportId int64 `json:"export_id"`
+	// BoardName is the title of the rendered documents
+	BoardName string `json:"board_name"`
+}
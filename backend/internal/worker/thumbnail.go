@@ -0,0 +1,5 @@
+package worker
+
+type ThumbnailJob struct {
+	BoardSlugId string `json:"board_slug_id"`
+}
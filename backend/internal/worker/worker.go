@@ -14,7 +14,10 @@ import (
 type JobType string
 
 const (
-	JobTypeEmail JobType = "email"
+	JobTypeEmail     JobType = "email"
+	JobTypeSnapshot  JobType = "snapshot"
+	JobTypeExport    JobType = "export"
+	JobTypeThumbnail JobType = "thumbnail"
 )
 
 type Job struct {
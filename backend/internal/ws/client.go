@@ -3,13 +3,17 @@ package ws
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog/log"
+	"github.com/umtdemr/wb-backend/internal/crdt"
 	"github.com/umtdemr/wb-backend/internal/data"
 	"github.com/umtdemr/wb-backend/internal/jsonHelper"
 	"github.com/umtdemr/wb-backend/internal/validator"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	"sync"
 	"time"
 )
 
@@ -24,7 +28,7 @@ const (
 	pingPeriod = (pongWait * 9) / 10
 
 	// Maximum message size allowed from peer.
-	maxMessageSize = 512
+	maxMessageSize = 4096
 
 	joinWait = 10 * time.Second
 )
@@ -43,6 +47,14 @@ type Client struct {
 
 	user *data.User
 
+	// role is the role of the user in the board, see data.BoardRoleOwner.
+	// It can be changed by the owner while the client is connected.
+	role   string
+	roleMu sync.RWMutex
+
+	// shareLinkId is the id of the share link that the client joined the board with, it is zero for the members
+	shareLinkId int64
+
 	cursor *Cursor
 
 	joined chan struct{}
@@ -67,12 +79,7 @@ func CreateNewClient(hub *Hub, conn *websocket.Conn) *Client {
 				timer.Stop()
 				return
 			case <-timer.C:
-				client.conn.WriteControl(
-					websocket.CloseMessage,
-					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "join timeout"),
-					time.Now().Add(5*time.Second),
-				)
-				client.conn.Close()
+				client.kick("join timeout")
 			}
 		}
 	}()
@@ -164,6 +171,12 @@ func (c *Client) ReadPump() {
 				c.sendErrorResponse(message.Id, ErrAuth.toResponse())
 				continue
 			}
+
+			// viewers can only follow the changes
+			if mutationCommands[message.Type] && !data.CanEditBoard(c.getRole()) {
+				c.sendErrorResponse(message.Id, ErrPermissionDenied.toResponse())
+				continue
+			}
 		}
 
 		err = c.handleMessage(&message)
@@ -174,6 +187,49 @@ func (c *Client) ReadPump() {
 	}
 }
 
+// kick closes the connection of the client with the given reason
+func (c *Client) kick(reason string) {
+	c.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason),
+		time.Now().Add(5*time.Second),
+	)
+	c.conn.Close()
+}
+
+func (c *Client) getRole() string {
+	c.roleMu.RLock()
+	defer c.roleMu.RUnlock()
+
+	return c.role
+}
+
+func (c *Client) setRole(role string) {
+	c.roleMu.Lock()
+	defer c.roleMu.Unlock()
+
+	c.role = role
+}
+
+// operationUserId returns the user id that the operations of the client are recorded with.
+// Guests do not have an account, so their operations are recorded without a user.
+func (c *Client) operationUserId() int64 {
+	if c.user.IsGuest {
+		return 0
+	}
+
+	return int64(c.user.ID)
+}
+
+// accessTargetId returns the id that the access changes with the given target header are matched with
+func (c *Client) accessTargetId(targetHeader string) int64 {
+	if targetHeader == targetShareLinkHeader {
+		return c.shareLinkId
+	}
+
+	return int64(c.user.ID)
+}
+
 // sendCompressedData compresses given message and sends to the client
 func (c *Client) sendCompressedData(message messageResponse) {
 	compressed, err := compressData(message)
@@ -196,6 +252,31 @@ func (c *Client) broadCastMessage(message messageResponse) {
 	c.hub.broadcastToBoard(c.boardId, compressed, c.user.ID)
 }
 
+// enqueueSnapshot enqueues a job to take a snapshot of the board
+func (c *Client) enqueueSnapshot(boardSlugId string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := c.hub.jobPublisher.EnqueueJob(ctx, worker.Job{
+		Type: worker.JobTypeSnapshot,
+		Data: worker.SnapshotJob{BoardSlugId: boardSlugId},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to enqueue snapshot job")
+	}
+}
+
+// broadcastOperation sends given message of an element operation to other users in the board
+func (c *Client) broadcastOperation(message messageResponse, operation crdt.Operation) {
+	compressed, err := compressData(message)
+
+	if err != nil {
+		return
+	}
+
+	c.hub.broadcastOperationToBoard(c.boardId, compressed, c.user.ID, operation)
+}
+
 func (c *Client) sendErrorResponse(replyTo string, message any) {
 	resp := messageResponse{
 		ReplyTo: replyTo,
@@ -265,16 +346,51 @@ func (c *Client) decodeMessageData(messageType string, data json.RawMessage) (Me
 		}
 
 		return &cursorMsg, nil
+	case CmdElementCreate:
+		return decodeValidatedMessage(data, &elementCreateMessage{})
+	case CmdElementUpdate:
+		return decodeValidatedMessage(data, &elementUpdateMessage{})
+	case CmdElementDelete:
+		return decodeValidatedMessage(data, &elementDeleteMessage{})
+	case CmdElementReorder:
+		return decodeValidatedMessage(data, &elementReorderMessage{})
+	case CmdUndo:
+		return &undoMessage{}, nil
+	case CmdRedo:
+		return &redoMessage{}, nil
 	}
 
 	return nil, ErrCmdNotFound
 }
 
+// decodeValidatedMessage decodes data into req and runs the validation rules of req
+func decodeValidatedMessage(data json.RawMessage, req ValidatedMessageHandler) (MessageHandler, error) {
+	err := jsonHelper.ReadJson(bytes.NewReader(data), req)
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator.New()
+	req.Validate(v)
+
+	if !v.Valid() {
+		return nil, FieldError{errors: v.Errors}
+	}
+
+	return req, nil
+}
+
 // MessageHandler is an interface for different incoming request
 type MessageHandler interface {
 	Handle(replyTo string, client *Client) error
 }
 
+// ValidatedMessageHandler is a MessageHandler which validates its data before handling
+type ValidatedMessageHandler interface {
+	MessageHandler
+	Validate(v *validator.Validator)
+}
+
 func (c *Client) WritePump() {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
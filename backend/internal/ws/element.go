@@ -0,0 +1,181 @@
+package ws
+
+import (
+	"errors"
+	"github.com/rs/zerolog/log"
+	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/validator"
+)
+
+// elementCreateMessage is a request type for drawing a new element on a board page
+type elementCreateMessage struct {
+	PageId       int64   `json:"page_id"`
+	Type         string  `json:"type"`
+	X            float64 `json:"x"`
+	Y            float64 `json:"y"`
+	Width        float64 `json:"width"`
+	Height       float64 `json:"height"`
+	ZIndex       int32   `json:"z_index"`
+	StrokeColor  int64   `json:"stroke_color"`
+	FillColor    int64   `json:"fill_color"`
+	StrokeWidth  float64 `json:"stroke_width"`
+	BorderRadius float64 `json:"border_radius"`
+	Text         string  `json:"text"`
+	FontSize     int32   `json:"font_size"`
+}
+
+func (m *elementCreateMessage) toElement() *data.Element {
+	return &data.Element{
+		PageId:       m.PageId,
+		Type:         m.Type,
+		X:            m.X,
+		Y:            m.Y,
+		Width:        m.Width,
+		Height:       m.Height,
+		ZIndex:       m.ZIndex,
+		StrokeColor:  m.StrokeColor,
+		FillColor:    m.FillColor,
+		StrokeWidth:  m.StrokeWidth,
+		BorderRadius: m.BorderRadius,
+		Text:         m.Text,
+		FontSize:     m.FontSize,
+	}
+}
+
+func (m *elementCreateMessage) Validate(v *validator.Validator) {
+	data.ValidateElement(v, m.toElement())
+}
+
+func (m *elementCreateMessage) Handle(replyTo string, client *Client) error {
+	operation, err := client.hub.models.Elements.Create(client.boardId, client.operationUserId(), m.toElement())
+	if err != nil {
+		client.sendElementError(replyTo, err)
+		return nil
+	}
+
+	client.recordOperation(operation)
+	client.sendElementResult(replyTo, EventElementCreated, operation)
+	return nil
+}
+
+// elementUpdateMessage is a request type for changing the fields of an existing element
+type elementUpdateMessage struct {
+	Id int64 `json:"id"`
+	// Version is the version of the element that the client has changed
+	Version int32 `json:"version"`
+	data.ElementPatch
+}
+
+func (m *elementUpdateMessage) Validate(v *validator.Validator) {
+	v.Check(m.Id > 0, "id", "must be set")
+	v.Check(m.Version > 0, "version", "must be set")
+	data.ValidateElementPatch(v, &m.ElementPatch)
+}
+
+func (m *elementUpdateMessage) Handle(replyTo string, client *Client) error {
+	operation, err := client.hub.models.Elements.Update(client.boardId, client.operationUserId(), m.Id, m.Version, &m.ElementPatch)
+	if err != nil {
+		client.sendElementError(replyTo, err)
+		return nil
+	}
+
+	client.recordOperation(operation)
+	client.sendElementResult(replyTo, EventElementUpdated, operation)
+	return nil
+}
+
+// elementDeleteMessage is a request type for removing an element from the board
+type elementDeleteMessage struct {
+	Id int64 `json:"id"`
+}
+
+func (m *elementDeleteMessage) Validate(v *validator.Validator) {
+	v.Check(m.Id > 0, "id", "must be set")
+}
+
+func (m *elementDeleteMessage) Handle(replyTo string, client *Client) error {
+	operation, err := client.hub.models.Elements.Delete(client.boardId, client.operationUserId(), m.Id, nil)
+	if err != nil {
+		client.sendElementError(replyTo, err)
+		return nil
+	}
+
+	client.recordOperation(operation)
+	client.sendElementResult(replyTo, EventElementDeleted, operation)
+	return nil
+}
+
+// elementReorderMessage is a request type for moving an element forward or backward in the z order
+type elementReorderMessage struct {
+	Id      int64 `json:"id"`
+	Version int32 `json:"version"`
+	ZIndex  int32 `json:"z_index"`
+}
+
+func (m *elementReorderMessage) Validate(v *validator.Validator) {
+	v.Check(m.Id > 0, "id", "must be set")
+	v.Check(m.Version > 0, "version", "must be set")
+}
+
+func (m *elementReorderMessage) Handle(replyTo string, client *Client) error {
+	operation, err := client.hub.models.Elements.Reorder(client.boardId, client.operationUserId(), m.Id, m.Version, m.ZIndex)
+	if err != nil {
+		client.sendElementError(replyTo, err)
+		return nil
+	}
+
+	client.recordOperation(operation)
+	client.sendElementResult(replyTo, EventElementReordered, operation)
+	return nil
+}
+
+// sendElementResult replies the authoritative element state to the client and lets other users in
+// the board know about the change with the given event. Both messages carry the board revision of the
+// operation, so that clients can notice the operations they missed.
+func (c *Client) sendElementResult(replyTo string, event string, operation *data.Operation) {
+	c.sendCompressedData(messageResponse{
+		ReplyTo: replyTo,
+		Data:    envelope{"element": operation.Element, "revision": operation.Revision, "type": operation.Type},
+	})
+
+	// snapshots are taken periodically to keep the operation history short
+	if operation.Revision%data.SnapshotInterval == 0 {
+		c.enqueueSnapshot(c.boardId)
+	}
+	c.hub.thumbnails.schedule(c.boardId)
+
+	message := messageResponse{
+		Event: event,
+		Data:  envelope{"element": operation.Element, "revision": operation.Revision},
+	}
+
+	crdtOperation, err := operation.CrdtOperation()
+	if err != nil {
+		log.Error().Err(err).Msg("error while converting element operation")
+		c.broadCastMessage(message)
+		return
+	}
+
+	c.broadcastOperation(message, crdtOperation)
+}
+
+// sendElementError sends the matching error response for element model errors
+func (c *Client) sendElementError(replyTo string, err error) {
+	var conflictErr *data.ElementConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		// stale changes are rejected, client gets the authoritative state of the element to rebase on
+		c.sendErrorResponse(replyTo, EditConflictErrorResponse{
+			ErrorResponse: ErrEditConflict.toResponse(),
+			Element:       conflictErr.Element,
+		})
+	case errors.Is(err, data.ErrRecordNotFound):
+		c.sendErrorResponse(replyTo, ErrNotFound.toResponse())
+	default:
+		log.Error().Err(err).Msg("error while handling element message")
+		c.sendErrorResponse(replyTo, ErrorResponse{
+			Code:    ErrCodeUnknown,
+			Message: "the server encountered a problem and could not process your request",
+		})
+	}
+}
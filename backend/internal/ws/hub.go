@@ -1,10 +1,13 @@
 package ws
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog/log"
+	"github.com/umtdemr/wb-backend/internal/crdt"
 	"github.com/umtdemr/wb-backend/internal/data"
+	"github.com/umtdemr/wb-backend/internal/worker"
 	"strconv"
 	"strings"
 )
@@ -12,6 +15,14 @@ import (
 const (
 	subjectPrefix       = "board."
 	excludeClientHeader = "Exclude-Client"
+	// operationHeader carries the crdt.Operation of the element messages
+	operationHeader = "Crdt-Operation"
+	// accessHeader carries the new role of the user in targetUserHeader, or accessRevoked. Access changes
+	// of the people who joined with a share link are targeted with targetShareLinkHeader instead.
+	accessHeader          = "Board-Access"
+	targetUserHeader      = "Target-User"
+	targetShareLinkHeader = "Target-Share-Link"
+	accessRevoked         = "revoked"
 )
 
 // Hub maintains the set of active clients
@@ -26,24 +37,44 @@ type Hub struct {
 
 	models data.Models
 
+	// undoStacks keeps the operations of the users that can be undone, per board
+	undoStacks *undoStacks
+
+	// jobPublisher enqueues background jobs such as board snapshots
+	jobPublisher worker.Publisher
+
+	// thumbnails renders the thumbnails of the boards once their edits settle
+	thumbnails *thumbnailScheduler
+
 	nc   *nats.Conn
 	subs map[string]*nats.Subscription
 }
 
-func NewHub(models data.Models, nc *nats.Conn) *Hub {
-	return &Hub{
-		register:   make(chan *RegistrationRequest),
-		unregister: make(chan *Client),
-		boards:     make(map[string]map[*Client]bool),
-		subs:       make(map[string]*nats.Subscription),
-		models:     models,
-		nc:         nc,
+func NewHub(models data.Models, nc *nats.Conn, jobPublisher worker.Publisher) *Hub {
+	h := &Hub{
+		register:     make(chan *RegistrationRequest),
+		unregister:   make(chan *Client),
+		boards:       make(map[string]map[*Client]bool),
+		subs:         make(map[string]*nats.Subscription),
+		models:       models,
+		undoStacks:   newUndoStacks(),
+		jobPublisher: jobPublisher,
+		nc:           nc,
 	}
+	h.thumbnails = newThumbnailScheduler(data.ThumbnailDelay, h.enqueueThumbnail)
+
+	return h
 }
 
 type RegistrationRequest struct {
 	Client  *Client
 	ReplyTo string
+
+	// Revision is the board revision at the time of the join
+	Revision       int64
+	Snapshots      []data.Snapshot
+	Operations     []data.Operation
+	ReloadRequired bool
 }
 
 func (h *Hub) Run() {
@@ -80,7 +111,13 @@ func (h *Hub) Run() {
 			}
 			resp := messageResponse{
 				ReplyTo: request.ReplyTo,
-				Data:    envelope{"join": joinResponse{OnlineUsers: usersList}},
+				Data: envelope{"join": joinResponse{
+					OnlineUsers:    usersList,
+					Revision:       request.Revision,
+					Snapshots:      request.Snapshots,
+					Operations:     request.Operations,
+					ReloadRequired: request.ReloadRequired,
+				}},
 			}
 
 			client.sendCompressedData(resp)
@@ -100,6 +137,7 @@ func (h *Hub) Run() {
 					// clean empty boards
 					if len(board) == 0 {
 						delete(h.boards, client.boardId)
+						h.undoStacks.clearBoard(client.boardId)
 					}
 
 					h.cleanupSubscription(client.boardId)
@@ -124,33 +162,154 @@ func (h *Hub) GetAllClientsInBoard(boardSlugId string) []*Client {
 	return clients
 }
 
+// BroadcastEvent sends the event to all clients in the board. It is used for the changes that are not
+// made over the websocket connections, such as the changes made with the REST API.
+func (h *Hub) BroadcastEvent(boardSlugId string, event string, eventData map[string]any) {
+	compressed, err := compressData(messageResponse{
+		Event: event,
+		Data:  eventData,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to compress event")
+		return
+	}
+
+	h.broadcastToBoard(boardSlugId, compressed, 0)
+}
+
+// ChangeUserRole applies the new role of the user to their connections in the board
+func (h *Hub) ChangeUserRole(boardSlugId string, userId int32, role string) {
+	h.publishAccessChange(boardSlugId, targetUserHeader, int64(userId), role, messageResponse{
+		Event: EventRoleChanged,
+		Data:  envelope{"role": role},
+	})
+}
+
+// RevokeUserAccess disconnects the user from the board
+func (h *Hub) RevokeUserAccess(boardSlugId string, userId int32) {
+	h.publishAccessChange(boardSlugId, targetUserHeader, int64(userId), accessRevoked, messageResponse{
+		Event: EventAccessRevoked,
+		Data:  envelope{},
+	})
+}
+
+// RevokeShareLinkAccess disconnects the people who joined the board with the share link
+func (h *Hub) RevokeShareLinkAccess(boardSlugId string, shareLinkId int64) {
+	h.publishAccessChange(boardSlugId, targetShareLinkHeader, shareLinkId, accessRevoked, messageResponse{
+		Event: EventAccessRevoked,
+		Data:  envelope{},
+	})
+}
+
+// publishAccessChange lets every node know about the access change, since the target may be connected to any of them
+func (h *Hub) publishAccessChange(boardSlugId string, targetHeader string, targetId int64, access string, message messageResponse) {
+	compressed, err := compressData(message)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to compress event")
+		return
+	}
+
+	m := h.newBoardMessage(boardSlugId, compressed, 0)
+	m.Header.Set(accessHeader, access)
+	m.Header.Set(targetHeader, strconv.FormatInt(targetId, 10))
+
+	h.publish(m)
+}
+
+// handleAccessChange applies the access change message to the connections of the target user or share link
+func (h *Hub) handleAccessChange(m *nats.Msg, clients map[*Client]bool) {
+	targetHeader := targetUserHeader
+	if m.Header.Get(targetShareLinkHeader) != "" {
+		targetHeader = targetShareLinkHeader
+	}
+
+	targetId, err := strconv.ParseInt(m.Header.Get(targetHeader), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to decode access change target")
+		return
+	}
+
+	access := m.Header.Get(accessHeader)
+	for client := range clients {
+		if client.accessTargetId(targetHeader) != targetId {
+			continue
+		}
+
+		if access == accessRevoked {
+			client.kick("access revoked")
+			continue
+		}
+
+		client.setRole(access)
+		client.send <- m.Data
+	}
+}
+
 func (h *Hub) broadcastToBoard(boardId string, msg []byte, excludeClientId int32) {
-	subject := subjectPrefix + boardId
+	h.publish(h.newBoardMessage(boardId, msg, excludeClientId))
+}
 
+// broadcastOperationToBoard broadcasts the message of an element operation. Nodes apply the operation to
+// their board document and drop the message if it is overridden by an operation they already delivered.
+func (h *Hub) broadcastOperationToBoard(boardId string, msg []byte, excludeClientId int32, operation crdt.Operation) {
+	m := h.newBoardMessage(boardId, msg, excludeClientId)
+
+	encodedOperation, err := json.Marshal(operation)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode operation")
+		return
+	}
+	m.Header.Set(operationHeader, string(encodedOperation))
+
+	h.publish(m)
+}
+
+func (h *Hub) newBoardMessage(boardId string, msg []byte, excludeClientId int32) *nats.Msg {
 	m := &nats.Msg{
-		Subject: subject,
+		Subject: subjectPrefix + boardId,
 		Data:    msg,
+		Header:  nats.Header{},
 	}
 
 	// add header to exclude specific client for broadcast message
 	if excludeClientId != 0 {
-		m.Header = nats.Header{
-			excludeClientHeader: []string{fmt.Sprintf("%d", excludeClientId)},
-		}
+		m.Header.Set(excludeClientHeader, fmt.Sprintf("%d", excludeClientId))
 	}
 
+	return m
+}
+
+func (h *Hub) publish(m *nats.Msg) {
 	if err := h.nc.PublishMsg(m); err != nil {
 		log.Error().Err(err).Msg("Failed to publish message")
 	}
 }
 
-func (h *Hub) handleNatsMessage(m *nats.Msg) {
+func (h *Hub) handleNatsMessage(m *nats.Msg, document *crdt.Document) {
 	boardId := strings.TrimPrefix(m.Subject, subjectPrefix)
 	clients, exists := h.boards[boardId]
 	if !exists {
 		return
 	}
 
+	if m.Header.Get(accessHeader) != "" {
+		h.handleAccessChange(m, clients)
+		return
+	}
+
+	if encodedOperation := m.Header.Get(operationHeader); encodedOperation != "" {
+		var operation crdt.Operation
+		if err := json.Unmarshal([]byte(encodedOperation), &operation); err != nil {
+			log.Error().Err(err).Msg("Failed to decode operation")
+			return
+		}
+
+		// operation is either delivered before or overridden by a later one
+		if !document.Apply(operation) {
+			return
+		}
+	}
+
 	var excludeClientId int32
 	if excludeId := m.Header.Get(excludeClientHeader); excludeId != "" {
 		if id, err := strconv.Atoi(excludeId); err == nil {
@@ -172,8 +331,11 @@ func (h *Hub) ensureSubscription(boardId string) {
 	subject := subjectPrefix + boardId
 
 	if _, exists := h.subs[subject]; !exists {
+		// every subscription keeps its own document of the board, built from the operations it receives.
+		// It only decides which messages are delivered, the elements are persisted before they are published.
+		document := crdt.NewDocument()
 		sub, err := h.nc.Subscribe(subject, func(m *nats.Msg) {
-			h.handleNatsMessage(m)
+			h.handleNatsMessage(m, document)
 		})
 
 		if err != nil {
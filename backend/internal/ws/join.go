@@ -1,6 +1,8 @@
 package ws
 
 import (
+	"errors"
+	"github.com/rs/zerolog/log"
 	"github.com/umtdemr/wb-backend/internal/data"
 	"github.com/umtdemr/wb-backend/internal/token"
 	"github.com/umtdemr/wb-backend/internal/validator"
@@ -10,6 +12,11 @@ import (
 type joinMessage struct {
 	BoardSlugId   string `json:"board_slug_id"`
 	UserAuthToken string `json:"user_auth_token"`
+	// ShareToken is the token of a share link of the board for the people who are not its members.
+	// Guests join only with the share token, without a user auth token.
+	ShareToken string `json:"share_token"`
+	// LastRevision is the last board revision that a reconnecting client has seen
+	LastRevision int64 `json:"last_revision"`
 }
 
 type joinResponseUser struct {
@@ -19,30 +26,163 @@ type joinResponseUser struct {
 
 type joinResponse struct {
 	OnlineUsers []*joinResponseUser `json:"online_users"`
+	Revision    int64               `json:"revision"`
+	// Snapshots are the latest snapshots of the pages for the clients that are not reconnecting
+	Snapshots []data.Snapshot `json:"snapshots,omitempty"`
+	// Operations are the operations after the LastRevision of joinMessage, or after the snapshots
+	Operations []data.Operation `json:"operations,omitempty"`
+	// ReloadRequired is set when the client is too far behind to catch up with operations
+	ReloadRequired bool `json:"reload_required,omitempty"`
 }
 
 func (m *joinMessage) Handle(replyTo string, client *Client) error {
-	user, err := client.hub.models.User.GetForToken(token.ScopeAuthentication, m.UserAuthToken)
-	if err != nil || user == nil {
-		client.sendErrorAuthResponse(replyTo)
+	var user *data.User
+	if m.UserAuthToken != "" {
+		var err error
+		user, err = client.hub.models.User.GetForToken(token.ScopeAuthentication, m.UserAuthToken)
+		if err != nil || user == nil {
+			client.sendErrorAuthResponse(replyTo)
+			return nil
+		}
+	}
+
+	board, err := m.getBoard(client, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			client.sendErrorResponse(replyTo, ErrNotFound.toResponse())
+		default:
+			log.Error().Err(err).Msg("error while getting board")
+			client.sendErrorResponse(replyTo, ErrorResponse{Code: ErrCodeUnknown, Message: err.Error()})
+		}
+		return nil
+	}
+
+	// people without an account are shown as guests in the board
+	if user == nil {
+		user, err = data.NewGuestUser()
+		if err != nil {
+			log.Error().Err(err).Msg("error while creating guest user")
+			client.sendErrorResponse(replyTo, ErrorResponse{Code: ErrCodeUnknown, Message: err.Error()})
+			return nil
+		}
+	}
+
+	revision, err := client.hub.models.Operations.GetCurrentRevision(m.BoardSlugId)
+	if err != nil {
+		switch {
+		case errors.Is(err, data.ErrRecordNotFound):
+			client.sendErrorResponse(replyTo, ErrNotFound.toResponse())
+		default:
+			log.Error().Err(err).Msg("error while getting board revision")
+			client.sendErrorResponse(replyTo, ErrorResponse{Code: ErrCodeUnknown, Message: err.Error()})
+		}
 		return nil
 	}
 
+	request := &RegistrationRequest{
+		Client:   client,
+		ReplyTo:  replyTo,
+		Revision: revision,
+	}
+
+	// a reconnecting client only needs the operations it missed,
+	// others load the latest snapshot plus the operations after it
+	if m.LastRevision == 0 || !m.replayOperations(client, request, m.LastRevision) {
+		m.loadSnapshot(client, request)
+	}
+
+	// members' recent boards are ordered by the last time they joined
+	if board.ShareLinkId == 0 {
+		if err := client.hub.models.Boards.MarkOpened(board.Id, int64(user.ID)); err != nil {
+			log.Error().Err(err).Msg("error while marking board as opened")
+		}
+	}
+
 	// set user for the client
 	client.user = user
 	client.boardId = m.BoardSlugId
+	client.setRole(board.Role)
+	client.shareLinkId = board.ShareLinkId
 
 	close(client.joined)
 
-	client.hub.register <- &RegistrationRequest{
-		Client:  client,
-		ReplyTo: replyTo,
-	}
+	client.hub.register <- request
 
 	return nil
 }
 
+// getBoard returns the board with the role of the user in it. Members join with their own role,
+// others need a valid share token of the board.
+func (m *joinMessage) getBoard(client *Client, user *data.User) (*data.BoardResult, error) {
+	var board *data.BoardResult
+	err := data.ErrRecordNotFound
+	if user != nil {
+		board, err = client.hub.models.Boards.GetBoard(int64(user.ID), m.BoardSlugId)
+	}
+
+	if m.ShareToken != "" && errors.Is(err, data.ErrRecordNotFound) {
+		return client.hub.models.Boards.GetSharedBoard(m.BoardSlugId, m.ShareToken)
+	}
+
+	return board, err
+}
+
+// loadSnapshot sets the latest snapshot of the board and the operations after it to the registration request
+func (m *joinMessage) loadSnapshot(client *Client, request *RegistrationRequest) {
+	snapshots, err := client.hub.models.Snapshots.GetLatest(m.BoardSlugId)
+	if err != nil {
+		log.Error().Err(err).Msg("error while getting board snapshots")
+		request.ReloadRequired = true
+		return
+	}
+
+	var snapshotRevision int64
+	if len(snapshots) > 0 {
+		snapshotRevision = snapshots[0].Revision
+	}
+
+	if !m.replayOperations(client, request, snapshotRevision) {
+		// snapshot is too old, a new one is needed for the next joins
+		client.enqueueSnapshot(m.BoardSlugId)
+		request.ReloadRequired = true
+		return
+	}
+
+	request.Snapshots = snapshots
+}
+
+// replayOperations sets the operations after the given revision to the registration request.
+// It reports false if the operations cannot be replayed since they are too many or already compacted.
+func (m *joinMessage) replayOperations(client *Client, request *RegistrationRequest, afterRevision int64) bool {
+	if afterRevision > request.Revision || request.Revision-afterRevision > data.MaxReplayOperations {
+		return false
+	}
+
+	if afterRevision == request.Revision {
+		return true
+	}
+
+	operations, err := client.hub.models.Operations.GetAllAfter(m.BoardSlugId, afterRevision)
+	if err != nil {
+		log.Error().Err(err).Msg("error while getting missed operations")
+		return false
+	}
+
+	// operations until the previous snapshot are compacted
+	if len(operations) == 0 || operations[0].Revision != afterRevision+1 {
+		return false
+	}
+
+	request.Operations = operations
+	return true
+}
+
 func (m *joinMessage) Validate(v *validator.Validator) {
 	v.Check(len(m.BoardSlugId) == 12, "board_slug_id", "must be 12 bytes long")
-	v.Check(len(m.UserAuthToken) > 5, "user_auth_token", "required")
+	// guests join only with a share token
+	if m.ShareToken == "" || m.UserAuthToken != "" {
+		v.Check(len(m.UserAuthToken) > 5, "user_auth_token", "required")
+	}
+	v.Check(m.LastRevision >= 0, "last_revision", "must not be negative")
 }
@@ -0,0 +1,230 @@
+package ws
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	"github.com/umtdemr/wb-backend/internal/token"
+	"github.com/umtdemr/wb-backend/internal/validator"
+	"testing"
+)
+
+// TestJoinMessage_NotMember tests that users cannot join the boards they are not a member of
+func TestJoinMessage_NotMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userModel := mockdata.NewMockUserModel(ctrl)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+
+	client := &Client{
+		hub:    NewHub(data.Models{User: userModel, Boards: boardModel}, nil, nil),
+		send:   make(chan []byte, 1),
+		joined: make(chan struct{}),
+	}
+
+	userModel.EXPECT().
+		GetForToken(token.ScopeAuthentication, "valid-token").
+		Return(&data.User{ID: 1}, nil)
+	boardModel.EXPECT().
+		GetBoard(int64(1), "valid-12-ch-").
+		Return(nil, data.ErrRecordNotFound)
+
+	message := &joinMessage{BoardSlugId: "valid-12-ch-", UserAuthToken: "valid-token"}
+	require.NoError(t, message.Handle("1", client))
+
+	require.Len(t, client.send, 1)
+	require.Empty(t, client.boardId)
+	require.Empty(t, client.getRole())
+}
+
+// TestJoinMessage_ShareToken tests joining a board as a guest with a share link
+func TestJoinMessage_ShareToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	operationModel := mockdata.NewMockOperationModel(ctrl)
+	snapshotModel := mockdata.NewMockSnapshotModel(ctrl)
+
+	hub := NewHub(data.Models{Boards: boardModel, Operations: operationModel, Snapshots: snapshotModel}, nil, nil)
+	hub.register = make(chan *RegistrationRequest, 1)
+
+	// revoked or expired links are not found
+	client := &Client{hub: hub, send: make(chan []byte, 1), joined: make(chan struct{})}
+	boardModel.EXPECT().
+		GetSharedBoard("valid-12-ch-", "revoked-token").
+		Return(nil, data.ErrRecordNotFound)
+
+	message := &joinMessage{BoardSlugId: "valid-12-ch-", ShareToken: "revoked-token"}
+	require.NoError(t, message.Handle("1", client))
+	require.Len(t, client.send, 1)
+	require.Nil(t, client.user)
+
+	client = &Client{hub: hub, send: make(chan []byte, 1), joined: make(chan struct{})}
+	boardModel.EXPECT().
+		GetSharedBoard("valid-12-ch-", "share-token").
+		Return(&data.BoardResult{Id: 3, SlugId: "valid-12-ch-", Role: data.BoardRoleViewer, ShareLinkId: 4}, nil)
+	operationModel.EXPECT().
+		GetCurrentRevision("valid-12-ch-").
+		Return(int64(0), nil)
+	snapshotModel.EXPECT().
+		GetLatest("valid-12-ch-").
+		Return(nil, nil)
+
+	message = &joinMessage{BoardSlugId: "valid-12-ch-", ShareToken: "share-token"}
+	require.NoError(t, message.Handle("1", client))
+
+	request := <-hub.register
+	require.Equal(t, client, request.Client)
+	require.True(t, client.user.IsGuest)
+	require.Less(t, client.user.ID, int32(0))
+	require.Equal(t, int64(0), client.operationUserId())
+	require.Equal(t, data.BoardRoleViewer, client.getRole())
+	require.Equal(t, int64(4), client.shareLinkId)
+}
+
+// TestJoinMessage_MarksOpened tests that joining a board is recorded for its members
+func TestJoinMessage_MarksOpened(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userModel := mockdata.NewMockUserModel(ctrl)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	operationModel := mockdata.NewMockOperationModel(ctrl)
+	snapshotModel := mockdata.NewMockSnapshotModel(ctrl)
+
+	hub := NewHub(data.Models{User: userModel, Boards: boardModel, Operations: operationModel, Snapshots: snapshotModel}, nil, nil)
+	hub.register = make(chan *RegistrationRequest, 1)
+
+	client := &Client{hub: hub, send: make(chan []byte, 1), joined: make(chan struct{})}
+	userModel.EXPECT().
+		GetForToken(token.ScopeAuthentication, "valid-token").
+		Return(&data.User{ID: 1}, nil)
+	boardModel.EXPECT().
+		GetBoard(int64(1), "valid-12-ch-").
+		Return(&data.BoardResult{Id: 3, SlugId: "valid-12-ch-", Role: data.BoardRoleEditor}, nil)
+	boardModel.EXPECT().
+		MarkOpened(int64(3), int64(1)).
+		Return(nil)
+	operationModel.EXPECT().
+		GetCurrentRevision("valid-12-ch-").
+		Return(int64(0), nil)
+	snapshotModel.EXPECT().
+		GetLatest("valid-12-ch-").
+		Return(nil, nil)
+
+	message := &joinMessage{BoardSlugId: "valid-12-ch-", UserAuthToken: "valid-token"}
+	require.NoError(t, message.Handle("1", client))
+
+	request := <-hub.register
+	require.Equal(t, client, request.Client)
+	require.Equal(t, data.BoardRoleEditor, client.getRole())
+}
+
+// TestJoinMessage_CopiedBoard tests joining a board created from a template, whose elements are in its first
+// snapshot instead of its operations
+func TestJoinMessage_CopiedBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userModel := mockdata.NewMockUserModel(ctrl)
+	boardModel := mockdata.NewMockBoardModel(ctrl)
+	operationModel := mockdata.NewMockOperationModel(ctrl)
+	snapshotModel := mockdata.NewMockSnapshotModel(ctrl)
+
+	hub := NewHub(data.Models{User: userModel, Boards: boardModel, Operations: operationModel, Snapshots: snapshotModel}, nil, nil)
+	hub.register = make(chan *RegistrationRequest, 1)
+
+	snapshots := []data.Snapshot{
+		{PageId: 5, Revision: 1, Elements: []data.Element{{Id: 8, PageId: 5, Type: data.ElementTypeRectangle}}},
+		{PageId: 6, Revision: 1, Elements: []data.Element{}},
+	}
+
+	client := &Client{hub: hub, send: make(chan []byte, 1), joined: make(chan struct{})}
+	userModel.EXPECT().
+		GetForToken(token.ScopeAuthentication, "valid-token").
+		Return(&data.User{ID: 1}, nil)
+	boardModel.EXPECT().
+		GetBoard(int64(1), "valid-12-ch-").
+		Return(&data.BoardResult{Id: 3, SlugId: "valid-12-ch-", IsOwner: true, Role: data.BoardRoleOwner}, nil)
+	boardModel.EXPECT().
+		MarkOpened(int64(3), int64(1)).
+		Return(nil)
+	operationModel.EXPECT().
+		GetCurrentRevision("valid-12-ch-").
+		Return(int64(1), nil)
+	snapshotModel.EXPECT().
+		GetLatest("valid-12-ch-").
+		Return(snapshots, nil)
+
+	message := &joinMessage{BoardSlugId: "valid-12-ch-", UserAuthToken: "valid-token"}
+	require.NoError(t, message.Handle("1", client))
+
+	request := <-hub.register
+	require.False(t, request.ReloadRequired)
+	require.Equal(t, int64(1), request.Revision)
+	require.Equal(t, snapshots, request.Snapshots)
+	require.Empty(t, request.Operations)
+}
+
+// TestJoinMessage_Validate tests that guests can join with only a share token
+func TestJoinMessage_Validate(t *testing.T) {
+	v := validator.New()
+	(&joinMessage{BoardSlugId: "valid-12-ch-"}).Validate(v)
+	require.Contains(t, v.Errors, "user_auth_token")
+
+	v = validator.New()
+	(&joinMessage{BoardSlugId: "valid-12-ch-", ShareToken: "share-token"}).Validate(v)
+	require.True(t, v.Valid())
+
+	v = validator.New()
+	(&joinMessage{BoardSlugId: "valid-12-ch-", UserAuthToken: "x", ShareToken: "share-token"}).Validate(v)
+	require.Contains(t, v.Errors, "user_auth_token")
+}
+
+// TestHub_ChangeUserRole tests applying role changes to the connections of the user
+func TestHub_ChangeUserRole(t *testing.T) {
+	client := &Client{
+		send: make(chan []byte, 1),
+		user: &data.User{ID: 2},
+		role: data.BoardRoleEditor,
+	}
+	otherClient := &Client{
+		send: make(chan []byte, 1),
+		user: &data.User{ID: 3},
+		role: data.BoardRoleEditor,
+	}
+	hub := NewHub(data.Models{}, nil, nil)
+
+	m := hub.newBoardMessage("valid-12-ch-", []byte("event"), 0)
+	m.Header.Set(accessHeader, data.BoardRoleViewer)
+	m.Header.Set(targetUserHeader, "2")
+
+	hub.handleAccessChange(m, map[*Client]bool{client: true, otherClient: true})
+
+	require.Equal(t, data.BoardRoleViewer, client.getRole())
+	require.Len(t, client.send, 1)
+	require.Equal(t, data.BoardRoleEditor, otherClient.getRole())
+	require.Empty(t, otherClient.send)
+}
+
+// TestHub_ShareLinkAccessChange tests that the access changes of a share link only apply to the people who joined with it
+func TestHub_ShareLinkAccessChange(t *testing.T) {
+	guest := &Client{
+		send:        make(chan []byte, 1),
+		user:        &data.User{ID: -7, IsGuest: true},
+		role:        data.BoardRoleEditor,
+		shareLinkId: 4,
+	}
+	member := &Client{
+		send: make(chan []byte, 1),
+		user: &data.User{ID: 4},
+		role: data.BoardRoleEditor,
+	}
+	hub := NewHub(data.Models{}, nil, nil)
+
+	m := hub.newBoardMessage("valid-12-ch-", []byte("event"), 0)
+	m.Header.Set(accessHeader, data.BoardRoleViewer)
+	m.Header.Set(targetShareLinkHeader, "4")
+
+	hub.handleAccessChange(m, map[*Client]bool{guest: true, member: true})
+
+	require.Equal(t, data.BoardRoleViewer, guest.getRole())
+	require.Len(t, guest.send, 1)
+	require.Equal(t, data.BoardRoleEditor, member.getRole())
+	require.Empty(t, member.send)
+}
@@ -0,0 +1,72 @@
+package ws
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"github.com/umtdemr/wb-backend/internal/worker"
+	"sync"
+	"time"
+)
+
+// thumbnailScheduler delays rendering the thumbnails of the boards until the edits settle. Every change of a board
+// postpones its thumbnail, so that a board being edited is rendered once after the last change.
+type thumbnailScheduler struct {
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+	delay  time.Duration
+	// enqueue is called with the slug id of the board when the board has not changed for the delay
+	enqueue func(boardSlugId string)
+}
+
+func newThumbnailScheduler(delay time.Duration, enqueue func(boardSlugId string)) *thumbnailScheduler {
+	return &thumbnailScheduler{
+		timers:  make(map[string]*time.Timer),
+		delay:   delay,
+		enqueue: enqueue,
+	}
+}
+
+// schedule renders the thumbnail of the board after the delay, unless the board changes again in the meantime
+func (s *thumbnailScheduler) schedule(boardSlugId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if timer, exists := s.timers[boardSlugId]; exists {
+		// a timer that has already fired only enqueues a job again, the worker skips the boards that are up-to-date
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(s.delay, func() {
+		s.mu.Lock()
+		if s.timers[boardSlugId] == timer {
+			delete(s.timers, boardSlugId)
+		}
+		s.mu.Unlock()
+
+		s.enqueue(boardSlugId)
+	})
+	s.timers[boardSlugId] = timer
+}
+
+// pending returns the number of boards waiting for their thumbnails
+func (s *thumbnailScheduler) pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.timers)
+}
+
+// enqueueThumbnail enqueues a job to render the thumbnail of the board
+func (h *Hub) enqueueThumbnail(boardSlugId string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := h.jobPublisher.EnqueueJob(ctx, worker.Job{
+		Type: worker.JobTypeThumbnail,
+		Data: worker.ThumbnailJob{BoardSlugId: boardSlugId},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to enqueue thumbnail job")
+	}
+}
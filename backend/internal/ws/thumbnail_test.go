@@ -0,0 +1,39 @@
+package ws
+
+import (
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestThumbnailScheduler tests that the thumbnails are enqueued once the boards stop changing
+func TestThumbnailScheduler(t *testing.T) {
+	var mu sync.Mutex
+	enqueued := make(map[string]int)
+
+	scheduler := newThumbnailScheduler(50*time.Millisecond, func(boardSlugId string) {
+		mu.Lock()
+		defer mu.Unlock()
+		enqueued[boardSlugId]++
+	})
+
+	// changes within the delay postpone the thumbnail of the board
+	for i := 0; i < 4; i++ {
+		scheduler.schedule("first")
+		time.Sleep(20 * time.Millisecond)
+	}
+	scheduler.schedule("second")
+	require.Equal(t, 2, scheduler.pending())
+
+	mu.Lock()
+	require.Empty(t, enqueued)
+	mu.Unlock()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return enqueued["first"] == 1 && enqueued["second"] == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Zero(t, scheduler.pending())
+}
@@ -0,0 +1,252 @@
+package ws
+
+import (
+	"errors"
+	"github.com/rs/zerolog/log"
+	"github.com/umtdemr/wb-backend/internal/data"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"sync"
+)
+
+// maxUndoDepth is the maximum number of operations that a user can undo in a board
+const maxUndoDepth = 100
+
+// undoEntry is a change of an element that can be applied to revert an operation.
+// A nil element state means that the element does not exist, i.e. it is deleted.
+type undoEntry struct {
+	// operationType is the type of the operation that the entry reverts
+	operationType string
+	elementId     int64
+	// from is the state that the operation left the element in
+	from *data.Element
+	// to is the state that the element will be brought to
+	to *data.Element
+}
+
+// newUndoEntry creates the entry that reverts the given operation
+func newUndoEntry(operation *data.Operation) *undoEntry {
+	entry := &undoEntry{
+		operationType: operation.Type,
+		elementId:     operation.ElementId,
+		from:          operation.Element,
+	}
+
+	switch operation.Type {
+	case db.OperationElementCreate:
+		// reverting a create deletes the element
+	case db.OperationElementDelete:
+		entry.from = nil
+		entry.to = operation.Element
+	default:
+		entry.to = operation.Previous
+	}
+
+	return entry
+}
+
+// reverse returns the entry that reverts the change of this entry. The result operation is the
+// operation that has been made by applying this entry.
+func (e *undoEntry) reverse(result *data.Operation) *undoEntry {
+	reversed := &undoEntry{
+		operationType: result.Type,
+		elementId:     e.elementId,
+		from:          result.Element,
+		to:            e.from,
+	}
+
+	if result.Type == db.OperationElementDelete {
+		reversed.from = nil
+	}
+
+	return reversed
+}
+
+// apply brings the element to the state of the entry. Changes are made with the version of the element
+// that the entry knows, so that changes of the collaborators made in the meantime are not overridden.
+func (e *undoEntry) apply(client *Client) (*data.Operation, error) {
+	elements := client.hub.models.Elements
+	userId := client.operationUserId()
+
+	switch {
+	case e.to == nil:
+		return elements.Delete(client.boardId, userId, e.elementId, &e.from.Version)
+	case e.from == nil:
+		return elements.Restore(client.boardId, userId, e.to)
+	case e.operationType == db.OperationElementReorder:
+		return elements.Reorder(client.boardId, userId, e.elementId, e.from.Version, e.to.ZIndex)
+	default:
+		return elements.Update(client.boardId, userId, e.elementId, e.from.Version, &data.ElementPatch{
+			X:            &e.to.X,
+			Y:            &e.to.Y,
+			Width:        &e.to.Width,
+			Height:       &e.to.Height,
+			ZIndex:       &e.to.ZIndex,
+			StrokeColor:  &e.to.StrokeColor,
+			FillColor:    &e.to.FillColor,
+			StrokeWidth:  &e.to.StrokeWidth,
+			BorderRadius: &e.to.BorderRadius,
+			Text:         &e.to.Text,
+			FontSize:     &e.to.FontSize,
+		})
+	}
+}
+
+type undoStackKey struct {
+	boardId string
+	userId  int32
+}
+
+// undoStacks keeps the undo and redo stacks of the users for each board. Only the operations of the user are
+// in their stacks, so that undoing never reverts the changes of a collaborator.
+type undoStacks struct {
+	mu   sync.Mutex
+	undo map[undoStackKey][]*undoEntry
+	redo map[undoStackKey][]*undoEntry
+}
+
+func newUndoStacks() *undoStacks {
+	return &undoStacks{
+		undo: make(map[undoStackKey][]*undoEntry),
+		redo: make(map[undoStackKey][]*undoEntry),
+	}
+}
+
+// record adds a new operation of the user. New operations clear the redo stack.
+func (s *undoStacks) record(key undoStackKey, entry *undoEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.undo[key] = pushEntry(s.undo[key], entry)
+	delete(s.redo, key)
+}
+
+// pop removes the latest entry of the undo or redo stack
+func (s *undoStacks) pop(key undoStackKey, redo bool) *undoEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stacks := s.undo
+	if redo {
+		stacks = s.redo
+	}
+
+	stack := stacks[key]
+	if len(stack) == 0 {
+		return nil
+	}
+
+	entry := stack[len(stack)-1]
+	stacks[key] = stack[:len(stack)-1]
+
+	return entry
+}
+
+// push adds the entry to the undo or redo stack
+func (s *undoStacks) push(key undoStackKey, entry *undoEntry, redo bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if redo {
+		s.redo[key] = pushEntry(s.redo[key], entry)
+	} else {
+		s.undo[key] = pushEntry(s.undo[key], entry)
+	}
+}
+
+// clearBoard removes the stacks of all users in the board
+func (s *undoStacks) clearBoard(boardId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.undo {
+		if key.boardId == boardId {
+			delete(s.undo, key)
+		}
+	}
+
+	for key := range s.redo {
+		if key.boardId == boardId {
+			delete(s.redo, key)
+		}
+	}
+}
+
+// pushEntry appends the entry to the stack and drops the oldest entries beyond maxUndoDepth
+func pushEntry(stack []*undoEntry, entry *undoEntry) []*undoEntry {
+	stack = append(stack, entry)
+	if len(stack) > maxUndoDepth {
+		stack = stack[len(stack)-maxUndoDepth:]
+	}
+
+	return stack
+}
+
+// undoMessage is a request type for reverting the latest operation of the user in the board
+type undoMessage struct{}
+
+func (m *undoMessage) Handle(replyTo string, client *Client) error {
+	client.applyUndoEntry(replyTo, false)
+	return nil
+}
+
+// redoMessage is a request type for applying the latest undone operation of the user again
+type redoMessage struct{}
+
+func (m *redoMessage) Handle(replyTo string, client *Client) error {
+	client.applyUndoEntry(replyTo, true)
+	return nil
+}
+
+// recordOperation adds the operation of the user to their undo stack
+func (c *Client) recordOperation(operation *data.Operation) {
+	c.hub.undoStacks.record(c.undoStackKey(), newUndoEntry(operation))
+}
+
+func (c *Client) undoStackKey() undoStackKey {
+	return undoStackKey{boardId: c.boardId, userId: c.user.ID}
+}
+
+// applyUndoEntry applies the latest entry of the undo or redo stack and moves its reverse to the other stack.
+// The result is broadcast like any other element operation.
+func (c *Client) applyUndoEntry(replyTo string, redo bool) {
+	key := c.undoStackKey()
+
+	entry := c.hub.undoStacks.pop(key, redo)
+	if entry == nil {
+		if redo {
+			c.sendErrorResponse(replyTo, ErrNothingToRedo.toResponse())
+		} else {
+			c.sendErrorResponse(replyTo, ErrNothingToUndo.toResponse())
+		}
+		return
+	}
+
+	operation, err := entry.apply(c)
+	if err != nil {
+		// the element is changed by someone else or removed, the entry cannot be applied anymore
+		if !errors.Is(err, data.ErrEditConflict) && !errors.Is(err, data.ErrRecordNotFound) {
+			c.hub.undoStacks.push(key, entry, redo)
+		}
+
+		c.sendElementError(replyTo, err)
+		return
+	}
+
+	c.hub.undoStacks.push(key, entry.reverse(operation), !redo)
+
+	event, ok := operationEvents[operation.Type]
+	if !ok {
+		log.Error().Str("type", operation.Type).Msg("unknown operation type on undo")
+		return
+	}
+
+	c.sendElementResult(replyTo, event, operation)
+}
+
+// operationEvents maps the element operation types to the events that are sent to the collaborators
+var operationEvents = map[string]string{
+	db.OperationElementCreate:  EventElementCreated,
+	db.OperationElementUpdate:  EventElementUpdated,
+	db.OperationElementDelete:  EventElementDeleted,
+	db.OperationElementReorder: EventElementReordered,
+}
@@ -0,0 +1,157 @@
+package ws
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/umtdemr/wb-backend/internal/data"
+	mockdata "github.com/umtdemr/wb-backend/internal/data/mock"
+	db "github.com/umtdemr/wb-backend/internal/db/sqlc"
+	"testing"
+)
+
+func createTestingClient(models data.Models) *Client {
+	return &Client{
+		hub:     NewHub(models, nil, nil),
+		send:    make(chan []byte, 16),
+		boardId: "test",
+		user:    &data.User{ID: 1},
+	}
+}
+
+// TestUndoRedo tests reverting the operations of a user and applying them again
+func TestUndoRedo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	elementModel := mockdata.NewMockElementModel(ctrl)
+	client := createTestingClient(data.Models{Elements: elementModel})
+
+	client.recordOperation(&data.Operation{
+		Revision:  10,
+		Type:      db.OperationElementUpdate,
+		ElementId: 5,
+		Element:   &data.Element{Id: 5, X: 5, Version: 3},
+		Previous:  &data.Element{Id: 5, X: 1, Version: 2},
+	})
+
+	// undo brings the previous state back with the version that the operation left the element in
+	elementModel.EXPECT().
+		Update("test", int64(1), int64(5), int32(3), gomock.Any()).
+		DoAndReturn(func(_ string, _ int64, _ int64, _ int32, patch *data.ElementPatch) (*data.Operation, error) {
+			require.Equal(t, float64(1), *patch.X)
+			return &data.Operation{
+				Revision:  11,
+				Type:      db.OperationElementUpdate,
+				ElementId: 5,
+				Element:   &data.Element{Id: 5, X: 1, Version: 4},
+			}, nil
+		})
+	client.applyUndoEntry("1", false)
+
+	elementModel.EXPECT().
+		Update("test", int64(1), int64(5), int32(4), gomock.Any()).
+		DoAndReturn(func(_ string, _ int64, _ int64, _ int32, patch *data.ElementPatch) (*data.Operation, error) {
+			require.Equal(t, float64(5), *patch.X)
+			return &data.Operation{
+				Revision:  12,
+				Type:      db.OperationElementUpdate,
+				ElementId: 5,
+				Element:   &data.Element{Id: 5, X: 5, Version: 5},
+			}, nil
+		})
+	client.applyUndoEntry("2", true)
+
+	require.Nil(t, client.hub.undoStacks.pop(client.undoStackKey(), true))
+	entry := client.hub.undoStacks.pop(client.undoStackKey(), false)
+	require.NotNil(t, entry)
+	require.Equal(t, int32(5), entry.from.Version)
+	require.Equal(t, float64(1), entry.to.X)
+}
+
+// TestUndoDeleteAndCreate tests that deletes are undone by restoring and creates by deleting
+func TestUndoDeleteAndCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	elementModel := mockdata.NewMockElementModel(ctrl)
+	client := createTestingClient(data.Models{Elements: elementModel})
+
+	deletedElement := &data.Element{Id: 5, PageId: 3, X: 5, Version: 4}
+	client.recordOperation(&data.Operation{
+		Revision:  10,
+		Type:      db.OperationElementDelete,
+		ElementId: 5,
+		Element:   deletedElement,
+	})
+
+	elementModel.EXPECT().
+		Restore("test", int64(1), deletedElement).
+		Return(&data.Operation{
+			Revision:  11,
+			Type:      db.OperationElementCreate,
+			ElementId: 5,
+			Element:   &data.Element{Id: 5, PageId: 3, X: 5, Version: 5},
+		}, nil)
+	client.applyUndoEntry("1", false)
+
+	// the create is only undone if the element is not changed since it is restored
+	restoredVersion := int32(5)
+	elementModel.EXPECT().
+		Delete("test", int64(1), int64(5), gomock.Eq(&restoredVersion)).
+		Return(&data.Operation{
+			Revision:  12,
+			Type:      db.OperationElementDelete,
+			ElementId: 5,
+			Element:   &data.Element{Id: 5, PageId: 3, X: 5, Version: 6},
+		}, nil)
+	client.applyUndoEntry("2", true)
+}
+
+// TestUndoWithConflict tests that the entries that cannot be applied anymore are dropped
+func TestUndoWithConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	elementModel := mockdata.NewMockElementModel(ctrl)
+	client := createTestingClient(data.Models{Elements: elementModel})
+
+	// nothing to undo yet
+	client.applyUndoEntry("1", false)
+	require.Len(t, client.send, 1)
+
+	client.recordOperation(&data.Operation{
+		Revision:  10,
+		Type:      db.OperationElementReorder,
+		ElementId: 5,
+		Element:   &data.Element{Id: 5, ZIndex: 2, Version: 3},
+		Previous:  &data.Element{Id: 5, ZIndex: 1, Version: 2},
+	})
+
+	elementModel.EXPECT().
+		Reorder("test", int64(1), int64(5), int32(3), int32(1)).
+		Return(nil, &data.ElementConflictError{Element: &data.Element{Id: 5, Version: 4}})
+	client.applyUndoEntry("2", false)
+	require.Len(t, client.send, 2)
+
+	require.Nil(t, client.hub.undoStacks.pop(client.undoStackKey(), false))
+	require.Nil(t, client.hub.undoStacks.pop(client.undoStackKey(), true))
+}
+
+// TestUndoStacks tests that new operations clear the redo stack and that the stacks are limited
+func TestUndoStacks(t *testing.T) {
+	stacks := newUndoStacks()
+	key := undoStackKey{boardId: "test", userId: 1}
+	otherKey := undoStackKey{boardId: "test", userId: 2}
+
+	stacks.push(key, &undoEntry{elementId: 1}, true)
+	stacks.record(otherKey, &undoEntry{elementId: 2})
+	require.NotNil(t, stacks.pop(key, true))
+
+	stacks.push(key, &undoEntry{elementId: 1}, true)
+	stacks.record(key, &undoEntry{elementId: 3})
+	require.Nil(t, stacks.pop(key, true))
+
+	for i := 0; i < maxUndoDepth+10; i++ {
+		stacks.record(key, &undoEntry{elementId: int64(i)})
+	}
+	require.Len(t, stacks.undo[key], maxUndoDepth)
+	require.Equal(t, int64(maxUndoDepth+9), stacks.pop(key, false).elementId)
+
+	stacks.clearBoard("test")
+	require.Nil(t, stacks.pop(key, false))
+	require.Nil(t, stacks.pop(otherKey, false))
+}
@@ -5,18 +5,49 @@ import (
 	"compress/zlib"
 	"encoding/json"
 	"fmt"
+	"github.com/umtdemr/wb-backend/internal/data"
 )
 
 const (
-	CmdJoin   = "join"
-	CmdCursor = "cursor" // collaborator cursors
+	CmdJoin           = "join"
+	CmdCursor         = "cursor" // collaborator cursors
+	CmdElementCreate  = "element.create"
+	CmdElementUpdate  = "element.update"
+	CmdElementDelete  = "element.delete"
+	CmdElementReorder = "element.reorder"
+	CmdUndo           = "undo"
+	CmdRedo           = "redo"
 )
 
+// mutationCommands are the commands that change the board, only owners and editors can send them
+var mutationCommands = map[string]bool{
+	CmdElementCreate:  true,
+	CmdElementUpdate:  true,
+	CmdElementDelete:  true,
+	CmdElementReorder: true,
+	CmdUndo:           true,
+	CmdRedo:           true,
+}
+
 // Server to client events
 const (
-	EventUserJoined = "USER_JOINED"
-	EventUserLeft   = "USER_LEFT"
-	EventCursor     = "CURSOR" // on client's cursor update
+	EventUserJoined       = "USER_JOINED"
+	EventUserLeft         = "USER_LEFT"
+	EventCursor           = "CURSOR" // on client's cursor update
+	EventElementCreated   = "ELEMENT_CREATED"
+	EventElementUpdated   = "ELEMENT_UPDATED"
+	EventElementDeleted   = "ELEMENT_DELETED"
+	EventElementReordered = "ELEMENT_REORDERED"
+	EventPageRestored     = "PAGE_RESTORED" // clients should reload the page
+	EventRoleChanged      = "ROLE_CHANGED"
+	EventAccessRevoked    = "ACCESS_REVOKED"
+	EventBoardRenamed     = "BOARD_RENAMED"
+	EventBoardDeleted     = "BOARD_DELETED" // clients should leave the board
+	EventBoardRestored    = "BOARD_RESTORED"
+	EventPageCreated      = "PAGE_CREATED"
+	EventPageRenamed      = "PAGE_RENAMED"
+	EventPagesReordered   = "PAGES_REORDERED"
+	EventPageDeleted      = "PAGE_DELETED"
 )
 
 type ErrorCode int
@@ -30,6 +61,11 @@ const (
 	ErrCodeUnknownMessageType
 	ErrCodeUnknownCompressionMethod
 	ErrCodeJsonDecoding
+	ErrCodeNotFound
+	ErrCodeEditConflict
+	ErrCodeNothingToUndo
+	ErrCodePermissionDenied
+	ErrCodeNothingToRedo
 )
 
 type WsError struct {
@@ -54,6 +90,11 @@ var (
 	ErrAuth                     = &WsError{ErrCodeAuth, "not authorized"}
 	ErrUnknownMessageType       = &WsError{ErrCodeUnknownMessageType, "only binary messages are allowed"}
 	ErrUnknownCompressionMethod = &WsError{ErrCodeUnknownCompressionMethod, "unknown compression method"}
+	ErrNotFound                 = &WsError{ErrCodeNotFound, "the requested resource could not be found"}
+	ErrEditConflict             = &WsError{ErrCodeEditConflict, "the element has been changed by someone else, please try again"}
+	ErrNothingToUndo            = &WsError{ErrCodeNothingToUndo, "there is nothing to undo"}
+	ErrNothingToRedo            = &WsError{ErrCodeNothingToRedo, "there is nothing to redo"}
+	ErrPermissionDenied         = &WsError{ErrCodePermissionDenied, "your role in this board does not permit this action"}
 )
 
 // envelope wraps JSON
@@ -77,6 +118,12 @@ type FieldErrorResponse struct {
 	Fields map[string]string `json:"fields"`
 }
 
+// EditConflictErrorResponse is sent when a stale version of an element is changed
+type EditConflictErrorResponse struct {
+	ErrorResponse
+	Element *data.Element `json:"element"`
+}
+
 func compressData(message any) ([]byte, error) {
 	decoded, err := json.Marshal(message)
 